@@ -84,6 +84,19 @@ func BindBody(c Context, i interface{}) (err error) {
 
 			return NewHTTPErrorWithInternal(http.StatusBadRequest, err, err.Error())
 		}
+	case strings.HasPrefix(ctype, MIMEApplicationProtobuf):
+		if c.Echo().ProtobufSerializer == nil {
+			return ErrUnsupportedMediaType
+		}
+
+		if err = c.Echo().ProtobufSerializer.Deserialize(c, i); err != nil {
+			switch err.(type) {
+			case *HTTPError:
+				return err
+			default:
+				return NewHTTPErrorWithInternal(http.StatusBadRequest, err, err.Error())
+			}
+		}
 	case strings.HasPrefix(ctype, MIMEApplicationForm), strings.HasPrefix(ctype, MIMEMultipartForm):
 		values, err := c.FormValues()
 
@@ -492,6 +492,60 @@ func TestBindUnsupportedMediaType(t *testing.T) {
 	testBindError(t, strings.NewReader(invalidContent), MIMEApplicationJSON, &json.SyntaxError{})
 }
 
+func TestBindProtobuf(t *testing.T) {
+	var testCases = []struct {
+		name              string
+		givenSerializer   ProtobufSerializer
+		whenBody          []byte
+		expect            *testProtoMessage
+		expectErrorStatus int
+	}{
+		{
+			name:            "ok",
+			givenSerializer: testProtobufSerializer{},
+			whenBody:        []byte{0x08, 0x01, 0x12, 0x03, 'J', 'o', 'n'},
+			expect:          &testProtoMessage{ID: 1, Name: "Jon"},
+		},
+		{
+			name:              "nok, malformed body",
+			givenSerializer:   testProtobufSerializer{},
+			whenBody:          []byte{0x08, 0x01, 0x12, 0x09, 'J', 'o', 'n'},
+			expectErrorStatus: http.StatusBadRequest,
+		},
+		{
+			name:              "nok, serializer not registered",
+			whenBody:          []byte{0x08, 0x01},
+			expectErrorStatus: http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			e.ProtobufSerializer = tc.givenSerializer
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tc.whenBody))
+			req.Header.Set(HeaderContentType, MIMEApplicationProtobuf)
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			result := new(testProtoMessage)
+			err := c.Bind(result)
+
+			if tc.expectErrorStatus != 0 {
+				var he *HTTPError
+				if assert.ErrorAs(t, err, &he) {
+					assert.Equal(t, tc.expectErrorStatus, he.Code)
+				}
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, result)
+		})
+	}
+}
+
 func TestBindbindData(t *testing.T) {
 	ts := new(bindTestStruct)
 	err := bindData(ts, values, "form")
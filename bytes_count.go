@@ -0,0 +1,54 @@
+package echox
+
+import (
+	"io"
+	"net/http"
+)
+
+// requestBodyCounter counts bytes read from the wrapped request body
+type requestBodyCounter struct {
+	io.ReadCloser
+	n int64
+}
+
+func (r *requestBodyCounter) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.n += int64(n)
+
+	return n, err
+}
+
+// countRequestBody wraps the original request body with counter. Bodies replaced later (i.e. by middlewares) are
+// counted only for bytes they read from the original body. Counter is a value field of the pooled context so wrapping
+// does not allocate.
+func (c *DefaultContext) countRequestBody() {
+	if c.request == nil {
+		c.bodyCounter = requestBodyCounter{}
+		return
+	}
+
+	body := c.request.Body
+	if body == &c.bodyCounter {
+		return // context is reset with the request it already counts
+	}
+
+	if body == nil || body == http.NoBody {
+		c.bodyCounter = requestBodyCounter{}
+		return
+	}
+
+	c.bodyCounter = requestBodyCounter{ReadCloser: body}
+	c.request.Body = &c.bodyCounter
+}
+
+// BytesIn returns number of request body bytes read so far. Unlike ContentLength it is accurate also for chunked
+// requests and reports only bytes actually consumed by handler and middlewares. Bytes are counted for requests
+// served by Echo.ServeHTTP.
+func (c *DefaultContext) BytesIn() int64 {
+	return c.bodyCounter.n
+}
+
+// BytesOut returns number of response body bytes written so far.
+func (c *DefaultContext) BytesOut() int64 {
+	return c.response.Size
+}
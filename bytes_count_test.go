@@ -0,0 +1,104 @@
+package echox
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContext_BytesInBytesOut(t *testing.T) {
+	body := strings.Repeat("0123456789", 100)
+
+	var testCases = []struct {
+		name          string
+		whenReadBytes int64
+		expectBytesIn int64
+	}{
+		{
+			name:          "ok, whole chunked body read",
+			whenReadBytes: -1,
+			expectBytesIn: 1000,
+		},
+		{
+			name:          "ok, part of chunked body read",
+			whenReadBytes: 100,
+			expectBytesIn: 100,
+		},
+		{
+			name:          "ok, body not read",
+			whenReadBytes: 0,
+			expectBytesIn: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+
+			var bytesIn, bytesOut, contentLength int64
+			e.POST("/", func(c Context) error {
+				r := c.Request().Body
+				if tc.whenReadBytes >= 0 {
+					r = io.NopCloser(io.LimitReader(r, tc.whenReadBytes))
+				}
+				b, err := io.ReadAll(r)
+				if err != nil {
+					return err
+				}
+
+				err = c.String(http.StatusOK, "read:"+string(b[:min(len(b), 10)]))
+
+				contentLength = c.ContentLength()
+				bytesIn = c.BytesIn()
+				bytesOut = c.BytesOut()
+				return err
+			})
+
+			// io.MultiReader hides body length so request is sent as chunked request without Content-Length
+			req := httptest.NewRequest(http.MethodPost, "/", io.MultiReader(strings.NewReader(body)))
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, int64(-1), contentLength)
+			assert.Equal(t, tc.expectBytesIn, bytesIn)
+			assert.Equal(t, int64(rec.Body.Len()), bytesOut)
+		})
+	}
+}
+
+func TestContext_BytesInNoBody(t *testing.T) {
+	e := New()
+
+	bytesIn := int64(-1)
+	e.GET("/", func(c Context) error {
+		bytesIn = c.BytesIn()
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, int64(0), bytesIn)
+}
+
+func TestContext_ResetCountsBodyWithoutAllocation(t *testing.T) {
+	e := New()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("body"))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec).(*DefaultContext)
+	body := req.Body
+
+	allocs := testing.AllocsPerRun(10, func() {
+		req.Body = body
+		c.Reset(req, rec)
+	})
+
+	assert.Equal(t, float64(0), allocs)
+	assert.Same(t, &c.bodyCounter, req.Body)
+}
@@ -14,6 +14,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"unicode/utf8"
 )
 
 // Context represents the context of the current HTTP request. It holds request and
@@ -37,6 +38,32 @@ type Context interface {
 	// IsWebSocket returns true if HTTP connection is WebSocket otherwise false.
 	IsWebSocket() bool
 
+	// ContentLength returns request body length declared by client or -1 when length is unknown (i.e. chunked request).
+	// Declared length is advisory - client can send less or more data so it must not be trusted for reading the body.
+	ContentLength() int64
+
+	// ContentLengthOrZero returns request body length declared by client or 0 when length is unknown.
+	ContentLengthOrZero() int64
+
+	// BytesIn returns number of request body bytes read so far. Unlike ContentLength it is accurate also for chunked
+	// requests and reports only bytes actually consumed by handler and middlewares. Bytes are counted for requests
+	// served by Echo.ServeHTTP.
+	BytesIn() int64
+
+	// BytesOut returns number of response body bytes written so far.
+	BytesOut() int64
+
+	// Range parses `Range` request header against content of given size and returns requested byte ranges. Returns
+	// nil ranges and nil error when request has no `Range` header. Returns ErrRangeNotSatisfiable when header is
+	// malformed or none of the ranges overlaps the content - handler should respond with 416 and
+	// `Content-Range: bytes */<size>` header then.
+	Range(size int64) ([]HTTPRange, error)
+
+	// DPoPProof returns DPoP proof JWT sent in `DPoP` request header (RFC 9449). Returns ErrDPoPProofMissing when
+	// header is missing or empty and ErrDPoPProofInvalid when request contains more than one `DPoP` header.
+	// Proof is not verified.
+	DPoPProof() (string, error)
+
 	// Scheme returns the HTTP protocol scheme, `http` or `https`.
 	Scheme() string
 
@@ -49,6 +76,10 @@ type Context interface {
 	// In case of 404 (route not found) and 405 (method not allowed) RouteInfo returns generic struct for these cases.
 	RouteInfo() RouteInfo
 
+	// Link reverses route with given name to absolute URL (scheme and host of current request + route path with params
+	// replaced by given values). Returns an error when no route or multiple routes with different paths have that name.
+	Link(name string, params ...interface{}) (string, error)
+
 	// Path returns the registered path for the handler.
 	Path() string
 
@@ -65,6 +96,13 @@ type Context interface {
 	// * route `/download/file.:ext` will not match request `/download/file.`
 	PathParamDefault(name string, defaultValue string) string
 
+	// PathParamEnum returns the path parameter for the provided name when its value is one of allowed values.
+	// Returns BindingError (400) naming the invalid value and allowed values otherwise.
+	PathParamEnum(name string, allowed ...string) (string, error)
+
+	// PathParamEnumFold is case-insensitive version of PathParamEnum. Returned value is spelled as in allowed values.
+	PathParamEnumFold(name string, allowed ...string) (string, error)
+
 	// PathParams returns path parameter values.
 	PathParams() PathParams
 
@@ -77,12 +115,32 @@ type Context interface {
 	// QueryParamDefault returns the query param or default value for the provided name.
 	QueryParamDefault(name, defaultValue string) string
 
+	// QueryParamEnum returns the query param for the provided name when its value is one of allowed values.
+	// Returns BindingError (400) naming the invalid value and allowed values otherwise.
+	QueryParamEnum(name string, allowed ...string) (string, error)
+
+	// QueryParamEnumFold is case-insensitive version of QueryParamEnum. Returned value is spelled as in allowed values.
+	QueryParamEnumFold(name string, allowed ...string) (string, error)
+
+	// QueryParamValues returns all values of repeated query param for the provided name (i.e. `?id=1&id=2`) in order
+	// they appear in the query string. Returns empty slice when query has no param by that name.
+	QueryParamValues(name string) []string
+
 	// QueryParams returns the query parameters as `url.Values`.
 	QueryParams() url.Values
 
 	// QueryString returns the URL query string.
 	QueryString() string
 
+	// RequireIfMatch checks `If-Match` request header against current entity-tag of the resource (i.e. `"v42"` with
+	// quotes) for optimistic concurrency control. Returns 412 (Precondition Failed) error when header does not match and
+	// 428 (Precondition Required) error when header is missing (see Echo.IfMatchMissingStatus).
+	RequireIfMatch(currentETag string) error
+
+	// Pagination parses `limit`, `offset` and `cursor` query parameters. Limit given in request is clamped to
+	// bounds given in opts. Returns 400 (bad request) error when limit or offset is not a number.
+	Pagination(opts PaginationOpts) (Pagination, error)
+
 	// FormValue returns the form field value for the provided name.
 	FormValue(name string) string
 
@@ -143,6 +201,10 @@ type Context interface {
 	// JSONBlob sends a JSON blob response with status code.
 	JSONBlob(code int, b []byte) error
 
+	// JSONArrayStream starts a streaming JSON array response with status 200. Elements are written one by one with
+	// JSONArrayWriter.Write and array is terminated with JSONArrayWriter.Close.
+	JSONArrayStream() (*JSONArrayWriter, error)
+
 	// JSONP sends a JSONP response with status code. It uses `callback` to construct
 	// the JSONP payload.
 	JSONP(code int, callback string, i interface{}) error
@@ -160,6 +222,10 @@ type Context interface {
 	// XMLBlob sends an XML blob response with status code.
 	XMLBlob(code int, b []byte) error
 
+	// Protobuf sends a protobuf response with status code. ProtobufSerializer must be registered using
+	// `Echo#ProtobufSerializer`.
+	Protobuf(code int, m interface{}) error
+
 	// Blob sends a blob response with status code and content type.
 	Blob(code int, contentType string, b []byte) error
 
@@ -179,12 +245,33 @@ type Context interface {
 	// Inline sends a response as inline, opening the file in the browser.
 	Inline(file string, name string) error
 
+	// AttachmentWriter prepares response as attachment with given file name and content type, prompting client to save
+	// the file, and returns writer for streaming generated (not from disk) content into response.
+	AttachmentWriter(name string, contentType string) (io.Writer, error)
+
 	// NoContent sends a response with no body and a status code.
 	NoContent(code int) error
 
+	// AllowResponse sends response to OPTIONS request with `Allow` header listing methods Router has for matched path.
+	// When routes for the path declare Route.Summary the response is 200 with OptionsResponse as JSON body, otherwise
+	// it is 204 with no body.
+	AllowResponse() error
+
 	// Redirect redirects the request to a provided URL with status code.
 	Redirect(code int, url string) error
 
+	// RedirectPreserveQuery redirects the request to a provided path with status code. Query string of the current
+	// request is appended to the path when the path has no query string of its own.
+	RedirectPreserveQuery(code int, path string) error
+
+	// AddLink adds link with given relation type and target attributes to `Link` response header (RFC 8288). Links
+	// added with multiple calls are comma-joined into a single header value. Must be called before response is
+	// committed.
+	AddLink(uri string, rel string, params ...LinkParam)
+
+	// PaginationLinks adds `next`, `prev`, `first` and `last` links to `Link` response header. Empty URIs are skipped.
+	PaginationLinks(next, prev, first, last string)
+
 	// Error invokes the registered global HTTP error handler. Generally used by middleware.
 	// A side-effect of calling global error handler is that now Response has been committed (sent to the client) and
 	// middlewares up in chain can not change Response status code or Response body anymore.
@@ -217,6 +304,15 @@ const (
 	// Allow header is mandatory for status 405 (method not found) and useful for OPTIONS method requests.
 	// It is added to context only when Router does not find matching method handler for request.
 	ContextKeyHeaderAllow = "echo_header_allow"
+
+	// ContextKeyRouteSummaries is set by Router together with ContextKeyHeaderAllow when at least one route registered
+	// for matched path has Route.Summary. Value is `map[string]string` of method to summary. The map is a copy owned by
+	// the request so modifying it does not affect the router.
+	ContextKeyRouteSummaries = "echo_route_summaries"
+
+	// ContextKeySkipResponseTransformer is context key to opt out from Echo.ResponseTransformer for the current request.
+	// Set it to `true` before calling Context.JSON to send response body as is.
+	ContextKeySkipResponseTransformer = "echo_skip_response_transformer"
 )
 
 const (
@@ -240,6 +336,9 @@ type DefaultContext struct {
 	// Lifecycle is not handle by Echo and could have excess allocations per served Request
 	currentParams PathParams
 
+	// bodyCounter counts bytes read from the request body for BytesIn
+	bodyCounter requestBodyCounter
+
 	query url.Values
 	store Map
 	echo  *Echo
@@ -264,6 +363,7 @@ func NewDefaultContext(e *Echo, pathParamAllocSize int) *DefaultContext {
 // See `Echo#ServeHTTP()`
 func (c *DefaultContext) Reset(r *http.Request, w http.ResponseWriter) {
 	c.request = r
+	c.countRequestBody()
 	c.response.reset(w)
 	c.query = nil
 	c.store = nil
@@ -313,6 +413,25 @@ func (c *DefaultContext) IsWebSocket() bool {
 	return strings.EqualFold(upgrade, "websocket")
 }
 
+// ContentLength returns request body length declared by client or -1 when length is unknown (i.e. chunked request).
+// Declared length is advisory - client can send less or more data so it must not be trusted for reading the body.
+func (c *DefaultContext) ContentLength() int64 {
+	if c.request == nil || c.request.ContentLength < 0 {
+		return -1
+	}
+
+	return c.request.ContentLength
+}
+
+// ContentLengthOrZero returns request body length declared by client or 0 when length is unknown.
+func (c *DefaultContext) ContentLengthOrZero() int64 {
+	if l := c.ContentLength(); l > 0 {
+		return l
+	}
+
+	return 0
+}
+
 // Scheme returns the HTTP protocol scheme, `http` or `https`.
 func (c *DefaultContext) Scheme() string {
 	// Can't use `r.Request.URL.Scheme`
@@ -389,6 +508,23 @@ func (c *DefaultContext) RouteInfo() RouteInfo {
 	return c.route
 }
 
+// Link reverses route with given name to absolute URL (scheme and host of current request + route path with params
+// replaced by given values). Returns an error when no route or multiple routes with different paths have that name.
+func (c *DefaultContext) Link(name string, params ...interface{}) (string, error) {
+	routes, err := c.echo.findRouter(c.request.Host).Routes().FilterByName(name)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrRouteNotFound, name)
+	}
+
+	for _, r := range routes[1:] {
+		if r.Path() != routes[0].Path() {
+			return "", fmt.Errorf("%w: %s", ErrRouteNameAmbiguous, name)
+		}
+	}
+
+	return c.Scheme() + "://" + c.request.Host + routes[0].Reverse(params...), nil
+}
+
 // SetRouteInfo sets the route info of this request to the context.
 func (c *DefaultContext) SetRouteInfo(ri RouteInfo) {
 	c.route = ri
@@ -460,6 +596,17 @@ func (c *DefaultContext) QueryParamDefault(name, defaultValue string) string {
 	return value
 }
 
+// QueryParamValues returns all values of repeated query param for the provided name (i.e. `?id=1&id=2`) in order
+// they appear in the query string. Returns empty slice when query has no param by that name.
+func (c *DefaultContext) QueryParamValues(name string) []string {
+	if c.query == nil {
+		c.query = c.request.URL.Query()
+	}
+
+	// copy so modifying returned slice does not change cached query
+	return append([]string{}, c.query[name]...)
+}
+
 // QueryParams returns the query parameters as `url.Values`.
 func (c *DefaultContext) QueryParams() url.Values {
 	if c.query == nil {
@@ -629,6 +776,12 @@ func (c *DefaultContext) jsonPBlob(code int, callback string, i interface{}) (er
 }
 
 func (c *DefaultContext) json(code int, i interface{}, indent string) error {
+	if t := c.echo.ResponseTransformer; t != nil {
+		if skip, _ := c.Get(ContextKeySkipResponseTransformer).(bool); !skip {
+			i = t(c, code, i)
+		}
+	}
+
 	c.writeContentType(MIMEApplicationJSONCharsetUTF8)
 	c.response.Status = code
 
@@ -725,6 +878,19 @@ func (c *DefaultContext) XMLBlob(code int, b []byte) (err error) {
 	return
 }
 
+// Protobuf sends a protobuf response with status code. ProtobufSerializer must be registered using
+// `Echo#ProtobufSerializer`.
+func (c *DefaultContext) Protobuf(code int, m interface{}) error {
+	if c.echo.ProtobufSerializer == nil {
+		return ErrProtobufSerializerNotRegistered
+	}
+
+	c.writeContentType(MIMEApplicationProtobuf)
+	c.response.Status = code
+
+	return c.echo.ProtobufSerializer.Serialize(c, m)
+}
+
 // Blob sends a blob response with status code and content type.
 func (c *DefaultContext) Blob(code int, contentType string, b []byte) (err error) {
 	c.writeContentType(contentType)
@@ -800,13 +966,76 @@ func (c *DefaultContext) Inline(file, name string) error {
 	return c.contentDisposition(file, name, "inline")
 }
 
+// AttachmentWriter prepares response as attachment with given file name and content type, prompting client to save
+// the file, and returns writer for streaming generated (not from disk) content into response. Non-ASCII file names
+// are sent as RFC 5987 encoded `filename*` parameter along with ASCII fallback `filename` parameter.
+//
+// Response is committed with status 200 on the first write to returned writer.
+func (c *DefaultContext) AttachmentWriter(name, contentType string) (io.Writer, error) {
+	if c.response.Committed {
+		return nil, errHeaderAlreadyCommitted
+	}
+
+	c.response.Header().Set(HeaderContentDisposition, contentDispositionValue("attachment", name))
+	c.response.Header().Set(HeaderContentType, contentType)
+
+	return c.response, nil
+}
+
 var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
 
 func (c *DefaultContext) contentDisposition(file, name, dispositionType string) error {
-	c.response.Header().Set(HeaderContentDisposition, fmt.Sprintf(`%s; filename="%s"`, dispositionType, quoteEscaper.Replace(name)))
+	c.response.Header().Set(HeaderContentDisposition, contentDispositionValue(dispositionType, name))
 	return c.File(file)
 }
 
+func contentDispositionValue(dispositionType, name string) string {
+	isASCII := true
+
+	for i := 0; i < len(name); i++ {
+		if name[i] >= utf8.RuneSelf {
+			isASCII = false
+			break
+		}
+	}
+
+	if isASCII {
+		return fmt.Sprintf(`%s; filename="%s"`, dispositionType, quoteEscaper.Replace(name))
+	}
+
+	// See RFC 6266 section 4.3: `filename*` is preferred by clients that support it and `filename` acts as fallback
+	fallback := strings.Map(func(r rune) rune {
+		if r >= utf8.RuneSelf {
+			return '_'
+		}
+
+		return r
+	}, name)
+
+	return fmt.Sprintf(`%s; filename="%s"; filename*=UTF-8''%s`, dispositionType, quoteEscaper.Replace(fallback), rfc5987Escape(name))
+}
+
+// rfc5987Escape percent-encodes all bytes of value that are not `attr-char` as defined in RFC 5987 section 3.2.1
+func rfc5987Escape(value string) string {
+	const upperhex = "0123456789ABCDEF"
+
+	b := new(strings.Builder)
+
+	for i := 0; i < len(value); i++ {
+		ch := value[i]
+		if ('a' <= ch && ch <= 'z') || ('A' <= ch && ch <= 'Z') || ('0' <= ch && ch <= '9') || strings.IndexByte("!#$&+-.^_`|~", ch) != -1 {
+			b.WriteByte(ch)
+			continue
+		}
+
+		b.WriteByte('%')
+		b.WriteByte(upperhex[ch>>4])
+		b.WriteByte(upperhex[ch&15])
+	}
+
+	return b.String()
+}
+
 // NoContent sends a response with no body and a status code.
 func (c *DefaultContext) NoContent(code int) error {
 	c.response.WriteHeader(code)
@@ -825,6 +1054,26 @@ func (c *DefaultContext) Redirect(code int, url string) error {
 	return nil
 }
 
+// RedirectPreserveQuery redirects the request to a provided path with status code. Query string of the current
+// request is appended to the path when the path has no query string of its own, i.e. request `/old?page=2` redirected
+// with `c.RedirectPreserveQuery(http.StatusMovedPermanently, "/new")` is sent to `/new?page=2`.
+func (c *DefaultContext) RedirectPreserveQuery(code int, path string) error {
+	if code < 300 || code > 308 {
+		return ErrInvalidRedirectCode
+	}
+
+	if rawQuery := c.request.URL.RawQuery; rawQuery != "" && !strings.Contains(path, "?") {
+		fragment := ""
+		if i := strings.IndexByte(path, '#'); i != -1 {
+			path, fragment = path[:i], path[i:]
+		}
+
+		path = path + "?" + rawQuery + fragment
+	}
+
+	return c.Redirect(code, path)
+}
+
 // Error invokes the registered global HTTP error handler. Generally used by middleware.
 // A side-effect of calling global error handler is that now Response has been committed (sent to the client) and
 // middlewares up in chain can not change Response status code or Response body anymore.
@@ -3,6 +3,7 @@ package echox
 import (
 	"bytes"
 	"crypto/tls"
+	"encoding/binary"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
@@ -436,6 +437,104 @@ func TestContext_JSON_DoesntCommitResponseCodePrematurely(t *testing.T) {
 	}
 }
 
+func TestContext_JSON_ResponseTransformer(t *testing.T) {
+	var testCases = []struct {
+		name       string
+		whenSkip   bool
+		expectBody string
+	}{
+		{
+			name:       "ok, envelope is applied",
+			expectBody: `{"data":{"id":1,"name":"Jon Snow"},"meta":{"status":201}}` + "\n",
+		},
+		{
+			name:       "ok, envelope is skipped with context flag",
+			whenSkip:   true,
+			expectBody: userJSON + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			e.ResponseTransformer = func(c Context, status int, body any) any {
+				return map[string]any{"data": body, "meta": map[string]any{"status": status}}
+			}
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			if tc.whenSkip {
+				c.Set(ContextKeySkipResponseTransformer, true)
+			}
+
+			err := c.JSON(http.StatusCreated, user{1, "Jon Snow"})
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
+
+func TestContext_AttachmentWriter(t *testing.T) {
+	var testCases = []struct {
+		name              string
+		whenName          string
+		expectDisposition string
+	}{
+		{
+			name:              "ascii name",
+			whenName:          "report.csv",
+			expectDisposition: `attachment; filename="report.csv"`,
+		},
+		{
+			name:              "ascii name with quotes",
+			whenName:          `my "report".csv`,
+			expectDisposition: `attachment; filename="my \"report\".csv"`,
+		},
+		{
+			name:              "utf-8 name",
+			whenName:          "résumé 2024.csv",
+			expectDisposition: `attachment; filename="r_sum_ 2024.csv"; filename*=UTF-8''r%C3%A9sum%C3%A9%202024.csv`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+			w, err := c.AttachmentWriter(tc.whenName, "text/csv")
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			_, err = io.Copy(w, strings.NewReader("id,name\n"))
+			assert.NoError(t, err)
+			_, err = fmt.Fprintf(w, "%d,%s\n", 1, "Jon Snow")
+			assert.NoError(t, err)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tc.expectDisposition, rec.Header().Get(HeaderContentDisposition))
+			assert.Equal(t, "text/csv", rec.Header().Get(HeaderContentType))
+			assert.Equal(t, "id,name\n1,Jon Snow\n", rec.Body.String())
+		})
+	}
+}
+
+func TestContext_AttachmentWriter_committed(t *testing.T) {
+	e := New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+	assert.NoError(t, c.NoContent(http.StatusNoContent))
+
+	w, err := c.AttachmentWriter("report.csv", "text/csv")
+	assert.EqualError(t, err, "response already committed")
+	assert.Nil(t, w)
+	assert.Empty(t, rec.Header().Get(HeaderContentDisposition))
+}
+
 func TestContextCookie(t *testing.T) {
 	e := New()
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
@@ -807,6 +906,50 @@ func TestContext_QueryParam(t *testing.T) {
 	}
 }
 
+func TestContext_QueryParamValues(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		givenURL      string
+		whenParamName string
+		expect        []string
+	}{
+		{
+			name:          "repeated key returns all values",
+			givenURL:      "/?id=9&other=1&id=8&id=",
+			whenParamName: "id",
+			expect:        []string{"9", "8", ""},
+		},
+		{
+			name:          "single key returns one value",
+			givenURL:      "/?id=1",
+			whenParamName: "id",
+			expect:        []string{"1"},
+		},
+		{
+			name:          "absent key returns empty slice",
+			givenURL:      "/?nope=1",
+			whenParamName: "id",
+			expect:        []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.givenURL, nil)
+			e := New()
+			c := e.NewContext(req, nil)
+
+			values := c.QueryParamValues(tc.whenParamName)
+			assert.Equal(t, tc.expect, values)
+
+			if len(values) > 0 {
+				values[0] = "changed"
+				assert.Equal(t, tc.expect[0], c.QueryParam(tc.whenParamName))
+			}
+		})
+	}
+}
+
 func TestContext_QueryParamDefault(t *testing.T) {
 	var testCases = []struct {
 		name             string
@@ -903,6 +1046,73 @@ func TestContextRedirect(t *testing.T) {
 	assert.Error(t, c.Redirect(310, "http://labstack.github.io/echo"))
 }
 
+func TestContext_RedirectPreserveQuery(t *testing.T) {
+	var testCases = []struct {
+		name           string
+		whenURL        string
+		whenCode       int
+		whenPath       string
+		expectLocation string
+		expectErr      string
+	}{
+		{
+			name:           "ok, query is preserved",
+			whenURL:        "/old?a=1",
+			whenCode:       http.StatusMovedPermanently,
+			whenPath:       "/new",
+			expectLocation: "/new?a=1",
+		},
+		{
+			name:           "ok, query is preserved before fragment",
+			whenURL:        "/old?a=1&b=2",
+			whenCode:       http.StatusFound,
+			whenPath:       "/new#top",
+			expectLocation: "/new?a=1&b=2#top",
+		},
+		{
+			name:           "ok, target query is left untouched",
+			whenURL:        "/old?a=1",
+			whenCode:       http.StatusTemporaryRedirect,
+			whenPath:       "/new?b=2",
+			expectLocation: "/new?b=2",
+		},
+		{
+			name:           "ok, no query in request",
+			whenURL:        "/old",
+			whenCode:       http.StatusPermanentRedirect,
+			whenPath:       "/new",
+			expectLocation: "/new",
+		},
+		{
+			name:      "nok, invalid code",
+			whenURL:   "/old?a=1",
+			whenCode:  http.StatusOK,
+			whenPath:  "/new",
+			expectErr: "invalid redirect status code",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			req := httptest.NewRequest(http.MethodGet, tc.whenURL, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := c.RedirectPreserveQuery(tc.whenCode, tc.whenPath)
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+				assert.Equal(t, "", rec.Header().Get(HeaderLocation))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.whenCode, rec.Code)
+			assert.Equal(t, tc.expectLocation, rec.Header().Get(HeaderLocation))
+		})
+	}
+}
+
 func TestContextStore(t *testing.T) {
 	var c Context = new(DefaultContext)
 	c.Set("name", "Jon Snow")
@@ -964,6 +1174,49 @@ func TestContext_Request(t *testing.T) {
 	assert.Equal(t, req, c.Request())
 }
 
+func TestContext_ContentLength(t *testing.T) {
+	var testCases = []struct {
+		name              string
+		whenBody          io.Reader
+		whenContentLength int64
+		expect            int64
+		expectOrZero      int64
+	}{
+		{
+			name:              "ok, known length",
+			whenBody:          strings.NewReader("hello"),
+			whenContentLength: 5,
+			expect:            5,
+			expectOrZero:      5,
+		},
+		{
+			name:              "ok, chunked request has unknown length",
+			whenBody:          strings.NewReader("hello"),
+			whenContentLength: -1,
+			expect:            -1,
+			expectOrZero:      0,
+		},
+		{
+			name:              "ok, zero length body",
+			whenBody:          http.NoBody,
+			whenContentLength: 0,
+			expect:            0,
+			expectOrZero:      0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", tc.whenBody)
+			req.ContentLength = tc.whenContentLength
+			c := New().NewContext(req, httptest.NewRecorder())
+
+			assert.Equal(t, tc.expect, c.ContentLength())
+			assert.Equal(t, tc.expectOrZero, c.ContentLengthOrZero())
+		})
+	}
+}
+
 func TestContext_Scheme(t *testing.T) {
 	tests := []struct {
 		c Context
@@ -1022,6 +1275,64 @@ func TestContext_Scheme(t *testing.T) {
 	}
 }
 
+func TestContext_Link(t *testing.T) {
+	var testCases = []struct {
+		name       string
+		whenName   string
+		whenParams []interface{}
+		expect     string
+		expectErr  string
+	}{
+		{
+			name:       "ok, named route with params",
+			whenName:   "user-file",
+			whenParams: []interface{}{123, "avatar.png"},
+			expect:     "https://api.example.com/users/123/files/avatar.png",
+		},
+		{
+			name:     "ok, named route without params",
+			whenName: "users",
+			expect:   "https://api.example.com/users",
+		},
+		{
+			name:      "nok, unknown route name",
+			whenName:  "unknown",
+			expectErr: "route not found: unknown",
+		},
+		{
+			name:      "nok, ambiguous route name",
+			whenName:  "ambiguous",
+			expectErr: "route name is ambiguous: ambiguous",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			_, err := e.AddRoute(Route{Method: http.MethodGet, Path: "/users", Handler: handlerFunc, Name: "users"})
+			assert.NoError(t, err)
+			_, err = e.AddRoute(Route{Method: http.MethodGet, Path: "/users/:id/files/:file", Handler: handlerFunc, Name: "user-file"})
+			assert.NoError(t, err)
+			_, err = e.AddRoute(Route{Method: http.MethodGet, Path: "/a", Handler: handlerFunc, Name: "ambiguous"})
+			assert.NoError(t, err)
+			_, err = e.AddRoute(Route{Method: http.MethodGet, Path: "/b", Handler: handlerFunc, Name: "ambiguous"})
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "https://api.example.com/", nil)
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			link, err := c.Link(tc.whenName, tc.whenParams...)
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expect, link)
+		})
+	}
+}
+
 func TestContext_IsWebSocket(t *testing.T) {
 	tests := []struct {
 		c  Context
@@ -1293,3 +1604,130 @@ func TestContext_FileFS(t *testing.T) {
 		})
 	}
 }
+
+// testProtoMessage is a minimal stand-in for a generated protobuf message with `id` (field 1, varint) and
+// `name` (field 2, bytes) fields.
+type testProtoMessage struct {
+	ID   uint64
+	Name string
+}
+
+func (m *testProtoMessage) Marshal() ([]byte, error) {
+	b := binary.AppendUvarint(nil, 1<<3|0)
+	b = binary.AppendUvarint(b, m.ID)
+	b = binary.AppendUvarint(b, 2<<3|2)
+	b = binary.AppendUvarint(b, uint64(len(m.Name)))
+
+	return append(b, m.Name...), nil
+}
+
+func (m *testProtoMessage) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 {
+			return errors.New("proto: invalid tag")
+		}
+
+		b = b[n:]
+
+		v, n := binary.Uvarint(b)
+		if n <= 0 {
+			return errors.New("proto: invalid varint")
+		}
+
+		b = b[n:]
+
+		switch tag {
+		case 1<<3 | 0:
+			m.ID = v
+		case 2<<3 | 2:
+			if uint64(len(b)) < v {
+				return errors.New("proto: unexpected EOF")
+			}
+
+			m.Name = string(b[:v])
+			b = b[v:]
+		default:
+			return fmt.Errorf("proto: unknown tag %d", tag)
+		}
+	}
+
+	return nil
+}
+
+type testProtobufSerializer struct{}
+
+func (testProtobufSerializer) Serialize(c Context, m interface{}) error {
+	pm, ok := m.(interface{ Marshal() ([]byte, error) })
+	if !ok {
+		return errors.New("not a protobuf message")
+	}
+
+	b, err := pm.Marshal()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Response().Write(b)
+
+	return err
+}
+
+func (testProtobufSerializer) Deserialize(c Context, m interface{}) error {
+	pm, ok := m.(interface{ Unmarshal([]byte) error })
+	if !ok {
+		return errors.New("not a protobuf message")
+	}
+
+	b, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return err
+	}
+
+	return pm.Unmarshal(b)
+}
+
+func TestContext_Protobuf(t *testing.T) {
+	e := New()
+	e.ProtobufSerializer = testProtobufSerializer{}
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+	err := c.Protobuf(http.StatusCreated, &testProtoMessage{ID: 1, Name: "Jon Snow"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, MIMEApplicationProtobuf, rec.Header().Get(HeaderContentType))
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(rec.Body.Bytes()))
+	req.Header.Set(HeaderContentType, MIMEApplicationProtobuf)
+	c = e.NewContext(req, httptest.NewRecorder())
+
+	result := new(testProtoMessage)
+	if assert.NoError(t, c.Bind(result)) {
+		assert.Equal(t, &testProtoMessage{ID: 1, Name: "Jon Snow"}, result)
+	}
+}
+
+func TestContext_Protobuf_DoesntCommitResponseCodePrematurely(t *testing.T) {
+	e := New()
+	e.ProtobufSerializer = testProtobufSerializer{}
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+	err := c.Protobuf(http.StatusCreated, "not a message")
+	assert.EqualError(t, err, "not a protobuf message")
+	assert.False(t, c.Response().Committed)
+}
+
+func TestContext_Protobuf_SerializerNotRegistered(t *testing.T) {
+	e := New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+	err := c.Protobuf(http.StatusOK, &testProtoMessage{ID: 1})
+	assert.ErrorIs(t, err, ErrProtobufSerializerNotRegistered)
+	assert.False(t, c.Response().Committed)
+}
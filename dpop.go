@@ -0,0 +1,26 @@
+package echox
+
+import "net/http"
+
+var (
+	// ErrDPoPProofMissing denotes an error raised when request does not contain DPoP proof
+	ErrDPoPProofMissing = NewHTTPError(http.StatusUnauthorized, "missing DPoP proof")
+	// ErrDPoPProofInvalid denotes an error raised when request DPoP proof is malformed or does not match access token
+	ErrDPoPProofInvalid = NewHTTPError(http.StatusUnauthorized, "invalid DPoP proof")
+)
+
+// DPoPProof returns DPoP proof JWT sent in `DPoP` request header (RFC 9449). Returns ErrDPoPProofMissing when
+// header is missing or empty and ErrDPoPProofInvalid when request contains more than one `DPoP` header.
+// Proof is not verified.
+func (c *DefaultContext) DPoPProof() (string, error) {
+	values := c.request.Header.Values(HeaderDPoP)
+
+	switch {
+	case len(values) == 0 || values[0] == "":
+		return "", ErrDPoPProofMissing
+	case len(values) > 1:
+		return "", ErrDPoPProofInvalid
+	}
+
+	return values[0], nil
+}
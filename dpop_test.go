@@ -0,0 +1,54 @@
+package echox
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContext_DPoPProof(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		whenHeaders []string
+		expect      string
+		expectErr   error
+	}{
+		{
+			name:        "ok",
+			whenHeaders: []string{"eyJ0eXAiOiJkcG9wK2p3dCJ9.e30.sig"},
+			expect:      "eyJ0eXAiOiJkcG9wK2p3dCJ9.e30.sig",
+		},
+		{
+			name:      "nok, missing header",
+			expectErr: ErrDPoPProofMissing,
+		},
+		{
+			name:        "nok, empty header",
+			whenHeaders: []string{""},
+			expectErr:   ErrDPoPProofMissing,
+		},
+		{
+			name:        "nok, multiple headers",
+			whenHeaders: []string{"a.b.c", "d.e.f"},
+			expectErr:   ErrDPoPProofInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for _, h := range tc.whenHeaders {
+				req.Header.Add(HeaderDPoP, h)
+			}
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			proof, err := c.DPoPProof()
+
+			assert.Equal(t, tc.expect, proof)
+			assert.Equal(t, tc.expectErr, err)
+		})
+	}
+}
@@ -41,7 +41,6 @@ package echox
 import (
 	stdContext "context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io/fs"
 	"net/http"
@@ -51,6 +50,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 // Echo is the top-level framework instance.
@@ -84,6 +84,12 @@ type Echo struct {
 	Logger           Logger
 	IPExtractor      IPExtractor
 
+	// ProtobufSerializer is used by Context.Protobuf and DefaultBinder to encode and decode protobuf messages.
+	// Echo does not ship an implementation so that the protobuf library is left for the application to choose.
+	// When nil, Context.Protobuf returns ErrProtobufSerializerNotRegistered and binding a protobuf request body
+	// results in ErrUnsupportedMediaType.
+	ProtobufSerializer ProtobufSerializer
+
 	// Filesystem is file system used by Static and File handlers to access files.
 	// Defaults to os.DirFS(".")
 	//
@@ -92,9 +98,25 @@ type Echo struct {
 	// including `assets/images` as their prefix.
 	Filesystem fs.FS
 
+	// IfMatchMissingStatus is HTTP status code Context.RequireIfMatch responds with when request has no `If-Match` header.
+	// Defaults to 428 (Precondition Required) when 0. Set to 412 (Precondition Failed) to treat missing header as failed
+	// precondition.
+	IfMatchMissingStatus int
+
+	// ResponseTransformer is called by Context.JSON and Context.JSONPretty (also for error responses sent by
+	// DefaultHTTPErrorHandler) with response status and body before body is serialized. Returned value is serialized
+	// instead of the original body. This allows wrapping responses into common envelope
+	// (i.e. `{"data": ..., "meta": ...}`) in one place. Requests can opt out by setting
+	// ContextKeySkipResponseTransformer context value to `true`.
+	// Defaults to nil (no transformation).
+	ResponseTransformer func(c Context, status int, body any) any
+
 	// OnAddRoute is called when Echo adds new route to specific host router. Handler is called for every router
 	// and before route is added to the host router.
 	OnAddRoute func(host string, route Routable) error
+
+	// draining is set by BeginDrain when instance is about to be shut down
+	draining atomic.Bool
 }
 
 // JSONSerializer is the interface that encodes and decodes JSON to and from interfaces.
@@ -103,6 +125,12 @@ type JSONSerializer interface {
 	Deserialize(c Context, i interface{}) error
 }
 
+// ProtobufSerializer is the interface that encodes and decodes protobuf messages to and from interfaces.
+type ProtobufSerializer interface {
+	Serialize(c Context, m interface{}) error
+	Deserialize(c Context, m interface{}) error
+}
+
 // HTTPErrorHandler is a centralized HTTP error handler.
 type HTTPErrorHandler func(c Context, err error)
 
@@ -142,6 +170,7 @@ const (
 	MIMETextHTMLCharsetUTF8              = MIMETextHTML + "; " + charsetUTF8
 	MIMETextPlain                        = "text/plain"
 	MIMETextPlainCharsetUTF8             = MIMETextPlain + "; " + charsetUTF8
+	MIMETextEventStream                  = "text/event-stream"
 	MIMEMultipartForm                    = "multipart/form-data"
 	MIMEOctetStream                      = "application/octet-stream"
 )
@@ -169,12 +198,19 @@ const (
 	HeaderContentDisposition  = "Content-Disposition"
 	HeaderContentEncoding     = "Content-Encoding"
 	HeaderContentLength       = "Content-Length"
+	HeaderContentRange        = "Content-Range"
 	HeaderContentType         = "Content-Type"
 	HeaderCookie              = "Cookie"
 	HeaderSetCookie           = "Set-Cookie"
 	HeaderIfModifiedSince     = "If-Modified-Since"
+	HeaderIfMatch             = "If-Match"
+	HeaderIfNoneMatch         = "If-None-Match"
+	HeaderETag                = "ETag"
 	HeaderLastModified        = "Last-Modified"
 	HeaderLocation            = "Location"
+	HeaderLink                = "Link"
+	HeaderDeprecation         = "Deprecation"
+	HeaderSunset              = "Sunset"
 	HeaderRetryAfter          = "Retry-After"
 	HeaderUpgrade             = "Upgrade"
 	HeaderVary                = "Vary"
@@ -187,9 +223,16 @@ const (
 	HeaderXHTTPMethodOverride = "X-HTTP-Method-Override"
 	HeaderXRealIP             = "X-Real-Ip"
 	HeaderXRequestID          = "X-Request-Id"
+	HeaderIdempotencyKey      = "Idempotency-Key"
+	HeaderRange               = "Range"
+	HeaderDPoP                = "DPoP"
+	HeaderEarlyData           = "Early-Data"
 	HeaderXCorrelationID      = "X-Correlation-Id"
+	HeaderXParentSpan         = "X-Parent-Span"
+	HeaderXSpanID             = "X-Span-Id"
 	HeaderXRequestedWith      = "X-Requested-With"
 	HeaderServer              = "Server"
+	HeaderServerTiming        = "Server-Timing"
 	HeaderOrigin              = "Origin"
 	HeaderCacheControl        = "Cache-Control"
 	HeaderConnection          = "Connection"
@@ -211,6 +254,7 @@ const (
 	HeaderXFrameOptions                   = "X-Frame-Options"
 	HeaderContentSecurityPolicy           = "Content-Security-Policy"
 	HeaderContentSecurityPolicyReportOnly = "Content-Security-Policy-Report-Only"
+	HeaderReportTo                        = "Report-To"
 	HeaderXCSRFToken                      = "X-CSRF-Token" // nolint: gosec
 	HeaderReferrerPolicy                  = "Referrer-Policy"
 )
@@ -324,15 +368,7 @@ func DefaultHTTPErrorHandler(exposeError bool) HTTPErrorHandler {
 			return
 		}
 
-		he := &HTTPError{
-			Code:    http.StatusInternalServerError,
-			Message: http.StatusText(http.StatusInternalServerError),
-		}
-		if errors.As(err, &he) {
-			if he.Internal != nil { // max 2 levels of checks even if internal could have also internal
-				errors.As(he.Internal, &he)
-			}
-		}
+		he := toHTTPError(err)
 
 		// Issue #1426
 		code := he.Code
@@ -365,13 +401,29 @@ func DefaultHTTPErrorHandler(exposeError bool) HTTPErrorHandler {
 	}
 }
 
+// BeginDrain marks Echo instance as draining: it is about to be shut down and should not receive new traffic.
+// Requests are still served normally but readiness probes (see middleware.HealthCheck) respond with 503 so load
+// balancer stops routing traffic to this instance. StartConfig.DrainPeriod calls BeginDrain automatically before
+// graceful shutdown.
+func (e *Echo) BeginDrain() {
+	e.draining.Store(true)
+}
+
+// Draining reports if BeginDrain has been called.
+func (e *Echo) Draining() bool {
+	return e.draining.Load()
+}
+
 // Pre adds middleware to the chain which is run before router tries to find matching route.
-// Meaning middleware is executed even for 404 (not found) cases.
+// Meaning middleware is executed even for 404 (not found) cases. Path parameters and RouteInfo are not yet available
+// in Pre middlewares (c.PathParam returns empty string).
 func (e *Echo) Pre(middleware ...MiddlewareFunc) {
 	e.premiddleware = append(e.premiddleware, middleware...)
 }
 
 // Use adds middleware to the chain which is run after router has found matching route and before route/request handler method is executed.
+// Path parameters of the matched route (c.PathParam, c.PathParams) and c.RouteInfo are available in Use middlewares
+// so they can be used i.e. for authorization or metrics before the handler is executed.
 func (e *Echo) Use(middleware ...MiddlewareFunc) {
 	e.middleware = append(e.middleware, middleware...)
 }
@@ -749,6 +801,14 @@ func WrapMiddleware(m func(http.Handler) http.Handler) MiddlewareFunc {
 	}
 }
 
+// Chain composes given middlewares into a single middleware. Middlewares are applied in the order they are given,
+// first middleware being the outermost one, so `e.Use(Chain(m1, m2))` behaves the same as `e.Use(m1, m2)`.
+func Chain(middleware ...MiddlewareFunc) MiddlewareFunc {
+	return func(next HandlerFunc) HandlerFunc {
+		return applyMiddleware(next, middleware...)
+	}
+}
+
 func (e *Echo) findRouter(host string) Router {
 	if len(e.routers) > 0 {
 		if r, ok := e.routers[host]; ok {
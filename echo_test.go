@@ -447,6 +447,116 @@ func TestEchoMiddleware(t *testing.T) {
 	assert.Equal(t, "OK", b)
 }
 
+func TestEchoMiddleware_pathParamsAvailability(t *testing.T) {
+	e := New()
+
+	var preParam, useParam, groupParam, handlerParam string
+	var useParams PathParams
+	var useRoutePath string
+
+	e.Pre(func(next HandlerFunc) HandlerFunc {
+		return func(c Context) error {
+			// routing has not been done yet
+			preParam = c.PathParam("id")
+			return next(c)
+		}
+	})
+	e.Use(func(next HandlerFunc) HandlerFunc {
+		return func(c Context) error {
+			useParam = c.PathParam("id")
+			useParams = append(PathParams(nil), c.PathParams()...)
+			useRoutePath = c.RouteInfo().Path()
+			return next(c)
+		}
+	})
+
+	g := e.Group("/orgs/:orgID")
+	g.Use(func(next HandlerFunc) HandlerFunc {
+		return func(c Context) error {
+			groupParam = c.PathParam("orgID")
+			return next(c)
+		}
+	})
+	g.GET("/users/:id", func(c Context) error {
+		handlerParam = c.PathParam("id")
+		return c.String(http.StatusOK, "OK")
+	})
+
+	code, body := request(http.MethodGet, "/orgs/acme/users/42", e)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "OK", body)
+	assert.Equal(t, "", preParam)
+	assert.Equal(t, "42", useParam)
+	assert.Equal(t, PathParams{{Name: "orgID", Value: "acme"}, {Name: "id", Value: "42"}}, useParams)
+	assert.Equal(t, "/orgs/:orgID/users/:id", useRoutePath)
+	assert.Equal(t, "acme", groupParam)
+	assert.Equal(t, "42", handlerParam)
+}
+
+func TestChain(t *testing.T) {
+	buf := new(bytes.Buffer)
+	mw := func(name string) MiddlewareFunc {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(c Context) error {
+				buf.WriteString(">" + name)
+				err := next(c)
+				buf.WriteString("<" + name)
+
+				return err
+			}
+		}
+	}
+
+	handler := func(c Context) error {
+		buf.WriteString("h")
+		return c.String(http.StatusOK, "OK")
+	}
+
+	e := New()
+	e.Use(mw("1"), mw("2"), mw("3"))
+	e.GET("/", handler)
+
+	code, body := request(http.MethodGet, "/", e)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "OK", body)
+
+	expect := buf.String()
+	assert.Equal(t, ">1>2>3h<3<2<1", expect)
+
+	buf.Reset()
+
+	e = New()
+	e.Use(Chain(mw("1"), mw("2")), mw("3"))
+	e.GET("/", handler)
+
+	code, body = request(http.MethodGet, "/", e)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "OK", body)
+	assert.Equal(t, expect, buf.String())
+
+	buf.Reset()
+
+	e = New()
+	e.GET("/", handler, Chain(mw("1"), mw("2"), mw("3")))
+
+	code, _ = request(http.MethodGet, "/", e)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, expect, buf.String())
+}
+
+func TestChain_empty(t *testing.T) {
+	e := New()
+	e.Use(Chain())
+	e.GET("/", func(c Context) error {
+		return c.String(http.StatusOK, "OK")
+	})
+
+	code, body := request(http.MethodGet, "/", e)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "OK", body)
+}
+
 func TestEchoMiddlewareError(t *testing.T) {
 	e := New()
 	e.Use(func(next HandlerFunc) HandlerFunc {
@@ -0,0 +1,16 @@
+package echox
+
+// ContextKeyFeatureFlags is context key for request-scoped feature flags (map[string]bool) stored by FeatureFlags
+// middleware. Use FeatureEnabled to check single flag.
+const ContextKeyFeatureFlags = "echo_feature_flags"
+
+// FeatureEnabled returns true when feature flag with given name is enabled for the current request. Flags that are
+// not resolved for the request (i.e. FeatureFlags middleware was not executed) are reported as disabled.
+func FeatureEnabled(c Context, name string) bool {
+	flags, ok := c.Get(ContextKeyFeatureFlags).(map[string]bool)
+	if !ok {
+		return false
+	}
+
+	return flags[name]
+}
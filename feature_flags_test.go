@@ -0,0 +1,22 @@
+package echox
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeatureEnabled(t *testing.T) {
+	e := New()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+
+	assert.False(t, FeatureEnabled(c, "beta"))
+
+	c.Set(ContextKeyFeatureFlags, map[string]bool{"beta": true, "legacy": false})
+
+	assert.True(t, FeatureEnabled(c, "beta"))
+	assert.False(t, FeatureEnabled(c, "legacy"))
+	assert.False(t, FeatureEnabled(c, "unknown"))
+}
@@ -1,31 +1,44 @@
 package echox
 
 import (
+	"encoding/xml"
 	"errors"
 	"fmt"
+	"html"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 // Errors
 var (
-	ErrUnsupportedMediaType        = NewHTTPError(http.StatusUnsupportedMediaType)
-	ErrNotFound                    = NewHTTPError(http.StatusNotFound)
-	ErrUnauthorized                = NewHTTPError(http.StatusUnauthorized)
-	ErrForbidden                   = NewHTTPError(http.StatusForbidden)
-	ErrMethodNotAllowed            = NewHTTPError(http.StatusMethodNotAllowed)
-	ErrStatusRequestEntityTooLarge = NewHTTPError(http.StatusRequestEntityTooLarge)
-	ErrTooManyRequests             = NewHTTPError(http.StatusTooManyRequests)
-	ErrBadRequest                  = NewHTTPError(http.StatusBadRequest)
-	ErrBadGateway                  = NewHTTPError(http.StatusBadGateway)
-	ErrInternalServerError         = NewHTTPError(http.StatusInternalServerError)
-	ErrRequestTimeout              = NewHTTPError(http.StatusRequestTimeout)
-	ErrServiceUnavailable          = NewHTTPError(http.StatusServiceUnavailable)
-	ErrValidatorNotRegistered      = errors.New("validator not registered")
-	ErrRendererNotRegistered       = errors.New("renderer not registered")
-	ErrInvalidRedirectCode         = errors.New("invalid redirect status code")
-	ErrCookieNotFound              = errors.New("cookie not found")
-	ErrInvalidCertOrKeyType        = errors.New("invalid cert or key type, must be string or []byte")
-	ErrInvalidListenerNetwork      = errors.New("invalid listener network")
+	ErrUnsupportedMediaType            = NewHTTPError(http.StatusUnsupportedMediaType)
+	ErrNotFound                        = NewHTTPError(http.StatusNotFound)
+	ErrUnauthorized                    = NewHTTPError(http.StatusUnauthorized)
+	ErrForbidden                       = NewHTTPError(http.StatusForbidden)
+	ErrMethodNotAllowed                = NewHTTPError(http.StatusMethodNotAllowed)
+	ErrStatusRequestEntityTooLarge     = NewHTTPError(http.StatusRequestEntityTooLarge)
+	ErrTooManyRequests                 = NewHTTPError(http.StatusTooManyRequests)
+	ErrBadRequest                      = NewHTTPError(http.StatusBadRequest)
+	ErrBadGateway                      = NewHTTPError(http.StatusBadGateway)
+	ErrInternalServerError             = NewHTTPError(http.StatusInternalServerError)
+	ErrRequestTimeout                  = NewHTTPError(http.StatusRequestTimeout)
+	ErrServiceUnavailable              = NewHTTPError(http.StatusServiceUnavailable)
+	ErrRequestHeaderFieldsTooLarge     = NewHTTPError(http.StatusRequestHeaderFieldsTooLarge)
+	ErrPreconditionFailed              = NewHTTPError(http.StatusPreconditionFailed)
+	ErrPreconditionRequired            = NewHTTPError(http.StatusPreconditionRequired)
+	ErrTooEarly                        = NewHTTPError(http.StatusTooEarly)
+	ErrRangeNotSatisfiable             = NewHTTPError(http.StatusRequestedRangeNotSatisfiable)
+	ErrNotAcceptable                   = NewHTTPError(http.StatusNotAcceptable)
+	ErrValidatorNotRegistered          = errors.New("validator not registered")
+	ErrRendererNotRegistered           = errors.New("renderer not registered")
+	ErrProtobufSerializerNotRegistered = errors.New("protobuf serializer not registered")
+	ErrInvalidRedirectCode             = errors.New("invalid redirect status code")
+	ErrCookieNotFound                  = errors.New("cookie not found")
+	ErrInvalidCertOrKeyType            = errors.New("invalid cert or key type, must be string or []byte")
+	ErrInvalidListenerNetwork          = errors.New("invalid listener network")
+	ErrRouteNotFound                   = errors.New("route not found")
+	ErrRouteNameAmbiguous              = errors.New("route name is ambiguous")
 )
 
 // HTTPError represents an error that occurred while handling a request.
@@ -75,3 +88,181 @@ func (he *HTTPError) WithInternal(err error) *HTTPError {
 func (he *HTTPError) Unwrap() error {
 	return he.Internal
 }
+
+// toHTTPError converts err to HTTPError. Errors that are not HTTPErrors are converted to "500 - Internal Server Error".
+func toHTTPError(err error) *HTTPError {
+	he := &HTTPError{
+		Code:    http.StatusInternalServerError,
+		Message: http.StatusText(http.StatusInternalServerError),
+	}
+	if errors.As(err, &he) {
+		if he.Internal != nil { // max 2 levels of checks even if internal could have also internal
+			errors.As(he.Internal, &he)
+		}
+	}
+
+	return he
+}
+
+// NegotiatingHTTPErrorHandler creates new HTTP error handler that renders error in format requested by client `Accept`
+// header: JSON (same as DefaultHTTPErrorHandler), XML, HTML or plain text. JSON is used when client accepts any
+// format or none of the supported formats. `exposeError` parameter decides if response will contain also error
+// message or not.
+//
+// Example:
+//
+//	e.HTTPErrorHandler = echox.NegotiatingHTTPErrorHandler(false)
+func NegotiatingHTTPErrorHandler(exposeError bool) HTTPErrorHandler {
+	jsonHandler := DefaultHTTPErrorHandler(exposeError)
+
+	return func(c Context, err error) {
+		if c.Response().Committed {
+			return
+		}
+
+		format := negotiateErrorFormat(c.Request().Header.Get(HeaderAccept))
+		if format == MIMEApplicationJSON || c.Request().Method == http.MethodHead {
+			jsonHandler(c, err)
+			return
+		}
+
+		he := toHTTPError(err)
+		message := httpErrorMessageText(he)
+
+		errText := ""
+		if exposeError {
+			errText = err.Error()
+		}
+
+		var cErr error
+
+		switch format {
+		case MIMEApplicationXML:
+			cErr = c.XML(he.Code, httpErrorXML{Message: message, Error: errText})
+		case MIMETextHTML:
+			cErr = c.HTML(he.Code, httpErrorHTML(he.Code, message, errText))
+		default:
+			if errText != "" {
+				message += "\n" + errText
+			}
+
+			cErr = c.String(he.Code, message)
+		}
+
+		if cErr != nil {
+			c.Echo().Logger.Error(err) // truly rare case. ala client already disconnected
+		}
+	}
+}
+
+type httpErrorXML struct {
+	XMLName xml.Name `xml:"error"`
+	Message string   `xml:"message"`
+	Error   string   `xml:"error,omitempty"`
+}
+
+func httpErrorHTML(code int, message string, errText string) string {
+	sb := strings.Builder{}
+	sb.WriteString("<!DOCTYPE html>\n<html><head><title>")
+	sb.WriteString(strconv.Itoa(code) + " " + html.EscapeString(http.StatusText(code)))
+	sb.WriteString("</title></head><body><h1>")
+	sb.WriteString(html.EscapeString(message))
+	sb.WriteString("</h1>")
+
+	if errText != "" {
+		sb.WriteString("<pre>" + html.EscapeString(errText) + "</pre>")
+	}
+
+	sb.WriteString("</body></html>\n")
+
+	return sb.String()
+}
+
+func httpErrorMessageText(he *HTTPError) string {
+	switch m := he.Message.(type) {
+	case string:
+		return m
+	case error:
+		return m.Error()
+	case fmt.Stringer:
+		return m.String()
+	}
+
+	return http.StatusText(he.Code)
+}
+
+// errorFormats are formats NegotiatingHTTPErrorHandler can render errors in, in order of server preference
+var errorFormats = []struct {
+	format string
+	mime   string
+}{
+	{format: MIMEApplicationJSON, mime: MIMEApplicationJSON},
+	{format: MIMETextHTML, mime: MIMETextHTML},
+	{format: MIMEApplicationXML, mime: MIMEApplicationXML},
+	{format: MIMEApplicationXML, mime: MIMETextXML},
+	{format: MIMETextPlain, mime: MIMETextPlain},
+}
+
+// negotiateErrorFormat picks error response format from `Accept` header value. Formats are ranked by quality value
+// and then by specificity of the media range they matched so `text/html, */*` results in HTML.
+func negotiateErrorFormat(accept string) string {
+	if accept == "" {
+		return MIMEApplicationJSON
+	}
+
+	best := MIMEApplicationJSON
+	bestQ, bestSpecificity := -1.0, -1
+
+	for _, f := range errorFormats {
+		q, specificity := acceptQuality(accept, f.mime)
+		if q <= 0 {
+			continue
+		}
+
+		if q > bestQ || (q == bestQ && specificity > bestSpecificity) {
+			best, bestQ, bestSpecificity = f.format, q, specificity
+		}
+	}
+
+	return best
+}
+
+// acceptQuality returns quality value and specificity (0 for `*/*`, 1 for `type/*`, 2 for exact match) of the most
+// specific media range in `Accept` header matching given mime type. Quality is 0 when nothing matches.
+func acceptQuality(accept string, mime string) (float64, int) {
+	mimeType, _, _ := strings.Cut(mime, "/")
+
+	q, specificity := 0.0, -1
+	for _, part := range strings.Split(accept, ",") {
+		mediaRange, params, _ := strings.Cut(part, ";")
+		mediaRange = strings.ToLower(strings.TrimSpace(mediaRange))
+
+		s := -1
+		switch {
+		case mediaRange == mime:
+			s = 2
+		case mediaRange == mimeType+"/*":
+			s = 1
+		case mediaRange == "*/*":
+			s = 0
+		}
+
+		if s <= specificity {
+			continue
+		}
+
+		specificity = s
+		q = 1.0
+
+		for _, param := range strings.Split(params, ";") {
+			k, v, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(k, "q") {
+				if pq, err := strconv.ParseFloat(v, 64); err == nil {
+					q = pq
+				}
+			}
+		}
+	}
+
+	return q, specificity
+}
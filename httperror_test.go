@@ -1,8 +1,10 @@
 package echox
 
 import (
+	"encoding/xml"
 	"errors"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -51,3 +53,134 @@ func TestHTTPError_Unwrap(t *testing.T) {
 		assert.Equal(t, "internal error", errors.Unwrap(err).Error())
 	})
 }
+
+func TestNegotiatingHTTPErrorHandler(t *testing.T) {
+	var testCases = []struct {
+		name              string
+		givenExposeError  bool
+		whenMethod        string
+		whenAccept        string
+		whenError         error
+		expectStatus      int
+		expectContentType string
+		expectBody        string
+	}{
+		{
+			name:              "ok, no accept header falls back to JSON",
+			whenError:         ErrNotFound,
+			expectStatus:      http.StatusNotFound,
+			expectContentType: MIMEApplicationJSONCharsetUTF8,
+			expectBody:        `{"message":"Not Found"}` + "\n",
+		},
+		{
+			name:              "ok, any format falls back to JSON",
+			whenAccept:        "*/*",
+			whenError:         ErrNotFound,
+			expectStatus:      http.StatusNotFound,
+			expectContentType: MIMEApplicationJSONCharsetUTF8,
+			expectBody:        `{"message":"Not Found"}` + "\n",
+		},
+		{
+			name:              "ok, unsupported format falls back to JSON",
+			whenAccept:        "image/png",
+			whenError:         ErrNotFound,
+			expectStatus:      http.StatusNotFound,
+			expectContentType: MIMEApplicationJSONCharsetUTF8,
+			expectBody:        `{"message":"Not Found"}` + "\n",
+		},
+		{
+			name:              "ok, browser gets HTML",
+			whenAccept:        "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+			whenError:         NewHTTPError(http.StatusForbidden, "<script>"),
+			expectStatus:      http.StatusForbidden,
+			expectContentType: MIMETextHTMLCharsetUTF8,
+			expectBody:        "<!DOCTYPE html>\n<html><head><title>403 Forbidden</title></head><body><h1>&lt;script&gt;</h1></body></html>\n",
+		},
+		{
+			name:              "ok, HTML with exposed error",
+			givenExposeError:  true,
+			whenAccept:        "text/html",
+			whenError:         errors.New("db down"),
+			expectStatus:      http.StatusInternalServerError,
+			expectContentType: MIMETextHTMLCharsetUTF8,
+			expectBody:        "<!DOCTYPE html>\n<html><head><title>500 Internal Server Error</title></head><body><h1>Internal Server Error</h1><pre>db down</pre></body></html>\n",
+		},
+		{
+			name:              "ok, XML",
+			whenAccept:        "application/xml",
+			whenError:         ErrUnauthorized,
+			expectStatus:      http.StatusUnauthorized,
+			expectContentType: MIMEApplicationXMLCharsetUTF8,
+			expectBody:        xml.Header + "<error><message>Unauthorized</message></error>",
+		},
+		{
+			name:              "ok, text/xml with exposed error",
+			givenExposeError:  true,
+			whenAccept:        "text/xml",
+			whenError:         ErrUnauthorized,
+			expectStatus:      http.StatusUnauthorized,
+			expectContentType: MIMEApplicationXMLCharsetUTF8,
+			expectBody:        xml.Header + "<error><message>Unauthorized</message><error>code=401, message=Unauthorized</error></error>",
+		},
+		{
+			name:              "ok, plain text",
+			whenAccept:        "text/plain",
+			whenError:         NewHTTPError(http.StatusTeapot, "my_error"),
+			expectStatus:      http.StatusTeapot,
+			expectContentType: MIMETextPlainCharsetUTF8,
+			expectBody:        "my_error",
+		},
+		{
+			name:              "ok, highest quality wins",
+			whenAccept:        "application/json;q=0.5, text/plain;q=0.9",
+			whenError:         NewHTTPError(http.StatusTeapot, "my_error"),
+			expectStatus:      http.StatusTeapot,
+			expectContentType: MIMETextPlainCharsetUTF8,
+			expectBody:        "my_error",
+		},
+		{
+			name:              "ok, JSON explicitly preferred",
+			whenAccept:        "text/html;q=0.1, application/json",
+			whenError:         NewHTTPError(http.StatusTeapot, "my_error"),
+			expectStatus:      http.StatusTeapot,
+			expectContentType: MIMEApplicationJSONCharsetUTF8,
+			expectBody:        `{"message":"my_error"}` + "\n",
+		},
+		{
+			name:              "ok, HEAD request has no body",
+			whenMethod:        http.MethodHead,
+			whenAccept:        "text/html",
+			whenError:         ErrNotFound,
+			expectStatus:      http.StatusNotFound,
+			expectContentType: "",
+			expectBody:        "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			e.HTTPErrorHandler = NegotiatingHTTPErrorHandler(tc.givenExposeError)
+			e.Any("/", func(c Context) error {
+				return tc.whenError
+			})
+
+			method := http.MethodGet
+			if tc.whenMethod != "" {
+				method = tc.whenMethod
+			}
+
+			req := httptest.NewRequest(method, "/", nil)
+			if tc.whenAccept != "" {
+				req.Header.Set(HeaderAccept, tc.whenAccept)
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectStatus, rec.Code)
+			assert.Equal(t, tc.expectContentType, rec.Header().Get(HeaderContentType))
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
@@ -7,7 +7,15 @@ import (
 )
 
 // DefaultJSONSerializer implements JSON encoding using encoding/json.
-type DefaultJSONSerializer struct{}
+type DefaultJSONSerializer struct {
+	// UseNumber makes Deserialize decode numbers into `interface{}` values (i.e. `map[string]any`) as json.Number
+	// instead of float64 so large integers keep their precision. Numbers bound to typed struct fields are not affected.
+	//
+	// Example:
+	//
+	//	e.JSONSerializer = &echox.DefaultJSONSerializer{UseNumber: true}
+	UseNumber bool
+}
 
 // Serialize converts an interface into a json and writes it to the response.
 // You can optionally use the indent parameter to produce pretty JSONs.
@@ -22,7 +30,12 @@ func (d DefaultJSONSerializer) Serialize(c Context, i interface{}, indent string
 
 // Deserialize reads a JSON from a request body and converts it into an interface.
 func (d DefaultJSONSerializer) Deserialize(c Context, i interface{}) error {
-	err := json.NewDecoder(c.Request().Body).Decode(i)
+	dec := json.NewDecoder(c.Request().Body)
+	if d.UseNumber {
+		dec.UseNumber()
+	}
+
+	err := dec.Decode(i)
 	if ute, ok := err.(*json.UnmarshalTypeError); ok {
 		return NewHTTPErrorWithInternal(
 			http.StatusBadRequest,
@@ -0,0 +1,100 @@
+package echox
+
+import (
+	"errors"
+	"net/http"
+)
+
+// ErrJSONArrayWriterClosed is returned when element is written to already closed JSONArrayWriter.
+var ErrJSONArrayWriterClosed = errors.New("json array writer is closed")
+
+// JSONArrayWriter writes elements of single JSON array to the response one at a time without buffering the whole
+// array in memory. Every element is flushed to the client as soon as it is written.
+//
+// Unlike NDJSON the resulting response body is single valid JSON array.
+type JSONArrayWriter struct {
+	c      Context
+	count  int
+	closed bool
+}
+
+// JSONArrayStream starts a streaming JSON array response with status 200 and writes the opening `[`. Elements are
+// written with JSONArrayWriter.Write and the array must be terminated with JSONArrayWriter.Close.
+//
+// Example:
+//
+//	w, err := c.JSONArrayStream()
+//	if err != nil {
+//		return err
+//	}
+//	for rows.Next() {
+//		if err := w.Write(row); err != nil {
+//			return err
+//		}
+//	}
+//	return w.Close()
+func (c *DefaultContext) JSONArrayStream() (*JSONArrayWriter, error) {
+	c.writeContentType(MIMEApplicationJSONCharsetUTF8)
+	c.response.WriteHeader(http.StatusOK)
+
+	if _, err := c.response.Write([]byte("[")); err != nil {
+		return nil, err
+	}
+
+	return &JSONArrayWriter{c: c}, nil
+}
+
+// Write serializes v as next element of the array and flushes it to the client. Returns request context error when
+// request context is cancelled (i.e. client has disconnected) so handler can stop producing elements.
+func (w *JSONArrayWriter) Write(v interface{}) error {
+	if w.closed {
+		return ErrJSONArrayWriterClosed
+	}
+
+	if err := w.c.Request().Context().Err(); err != nil {
+		return err
+	}
+
+	res := w.c.Response()
+	if w.count > 0 {
+		if _, err := res.Write([]byte(",")); err != nil {
+			return err
+		}
+	}
+
+	if err := w.c.Echo().JSONSerializer.Serialize(w.c, v, ""); err != nil {
+		return err
+	}
+
+	w.count++
+
+	if _, ok := res.Writer.(http.Flusher); ok {
+		res.Flush()
+	}
+
+	return nil
+}
+
+// Count returns number of elements written so far.
+func (w *JSONArrayWriter) Count() int {
+	return w.count
+}
+
+// Close writes the closing `]` of the array. Calling Close more than once has no effect.
+func (w *JSONArrayWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+
+	w.closed = true
+
+	if _, err := w.c.Response().Write([]byte("]")); err != nil {
+		return err
+	}
+
+	if _, ok := w.c.Response().Writer.(http.Flusher); ok {
+		w.c.Response().Flush()
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package echox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContext_JSONArrayStream(t *testing.T) {
+	e := New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	w, err := c.JSONArrayStream()
+	assert.NoError(t, err)
+
+	for _, u := range []user{{1, "Jon Snow"}, {2, "Arya Stark"}, {3, "Sansa Stark"}} {
+		assert.NoError(t, w.Write(u))
+	}
+	assert.NoError(t, w.Close())
+	assert.NoError(t, w.Close())
+
+	assert.Equal(t, 3, w.Count())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, MIMEApplicationJSONCharsetUTF8, rec.Header().Get(HeaderContentType))
+	assert.True(t, rec.Flushed)
+
+	var result []user
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, []user{{1, "Jon Snow"}, {2, "Arya Stark"}, {3, "Sansa Stark"}}, result)
+
+	assert.ErrorIs(t, w.Write(user{4, "Bran Stark"}), ErrJSONArrayWriterClosed)
+}
+
+func TestContext_JSONArrayStream_empty(t *testing.T) {
+	e := New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	w, err := c.JSONArrayStream()
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+
+	assert.Equal(t, "[]", rec.Body.String())
+}
+
+func TestContext_JSONArrayStream_contextCancelled(t *testing.T) {
+	e := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	w, err := c.JSONArrayStream()
+	assert.NoError(t, err)
+
+	var writeErr error
+	for i := 1; i <= 10; i++ {
+		if i == 3 {
+			cancel() // client disconnects mid-stream
+		}
+		if writeErr = w.Write(user{i, "Jon Snow"}); writeErr != nil {
+			break
+		}
+	}
+
+	assert.True(t, errors.Is(writeErr, context.Canceled))
+	assert.Equal(t, 2, w.Count())
+	assert.Equal(t, `[{"id":1,"name":"Jon Snow"}`+"\n"+`,{"id":2,"name":"Jon Snow"}`+"\n", rec.Body.String())
+}
@@ -1,6 +1,7 @@
 package echox
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -97,3 +98,47 @@ func TestDefaultJSONCodec_Decode(t *testing.T) {
 	assert.IsType(t, &HTTPError{}, err)
 	assert.EqualError(t, err, "code=400, message=Unmarshal type error: expected=string, got=number, field=id, offset=7, internal=json: cannot unmarshal number into Go struct field .id of type string")
 }
+
+func TestDefaultJSONCodec_DecodeUseNumber(t *testing.T) {
+	const body = `{"id":9007199254740993,"name":"Jon Snow"}`
+
+	e := New()
+	e.JSONSerializer = &DefaultJSONSerializer{UseNumber: true}
+
+	// dynamic payload keeps exact precision
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set(HeaderContentType, MIMEApplicationJSON)
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	m := map[string]any{}
+	err := c.Bind(&m)
+	if assert.NoError(t, err) {
+		assert.Equal(t, json.Number("9007199254740993"), m["id"])
+		assert.Equal(t, "Jon Snow", m["name"])
+	}
+
+	// struct binding is not affected
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set(HeaderContentType, MIMEApplicationJSON)
+	c = e.NewContext(req, httptest.NewRecorder())
+
+	var s struct {
+		ID int64 `json:"id"`
+	}
+	err = c.Bind(&s)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(9007199254740993), s.ID)
+	}
+
+	// default serializer decodes into float64
+	e.JSONSerializer = &DefaultJSONSerializer{}
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set(HeaderContentType, MIMEApplicationJSON)
+	c = e.NewContext(req, httptest.NewRecorder())
+
+	m = map[string]any{}
+	err = c.Bind(&m)
+	if assert.NoError(t, err) {
+		assert.IsType(t, float64(0), m["id"])
+	}
+}
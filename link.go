@@ -0,0 +1,91 @@
+package echox
+
+import "strings"
+
+// LinkParam is target attribute of link added with Context.AddLink (i.e. `type="text/html"` or `title="Next page"`).
+type LinkParam struct {
+	Key   string
+	Value string
+}
+
+// AddLink adds link with given relation type and target attributes to `Link` response header (RFC 8288). Links
+// added with multiple calls are comma-joined into a single header value. Must be called before response is
+// committed.
+func (c *DefaultContext) AddLink(uri string, rel string, params ...LinkParam) {
+	var sb strings.Builder
+
+	sb.WriteByte('<')
+	sb.WriteString(escapeLinkURI(uri))
+	sb.WriteString(`>; rel=`)
+	sb.WriteString(quoteLinkParam(rel))
+
+	for _, p := range params {
+		sb.WriteString("; ")
+		sb.WriteString(p.Key)
+		sb.WriteByte('=')
+		sb.WriteString(quoteLinkParam(p.Value))
+	}
+
+	header := c.response.Header()
+	links := append(header.Values(HeaderLink), sb.String())
+	header.Set(HeaderLink, strings.Join(links, ", "))
+}
+
+// PaginationLinks adds `next`, `prev`, `first` and `last` links to `Link` response header. Empty URIs are skipped.
+func (c *DefaultContext) PaginationLinks(next, prev, first, last string) {
+	links := [...]struct{ uri, rel string }{
+		{next, "next"},
+		{prev, "prev"},
+		{first, "first"},
+		{last, "last"},
+	}
+
+	for _, l := range links {
+		if l.uri != "" {
+			c.AddLink(l.uri, l.rel)
+		}
+	}
+}
+
+// escapeLinkURI percent-encodes characters that are not allowed in URI-Reference so link target can not break out
+// of `<...>` brackets. Existing percent-encodings are kept as is.
+func escapeLinkURI(uri string) string {
+	const hex = "0123456789ABCDEF"
+
+	var sb strings.Builder
+
+	for i := 0; i < len(uri); i++ {
+		b := uri[i]
+		if b <= ' ' || b >= 0x7f || b == '<' || b == '>' || b == '"' || b == '\\' || b == '^' || b == '`' ||
+			b == '{' || b == '|' || b == '}' {
+			sb.WriteByte('%')
+			sb.WriteByte(hex[b>>4])
+			sb.WriteByte(hex[b&0x0f])
+
+			continue
+		}
+
+		sb.WriteByte(b)
+	}
+
+	return sb.String()
+}
+
+// quoteLinkParam returns value as quoted-string (RFC 9110) escaping quotes and backslashes.
+func quoteLinkParam(value string) string {
+	var sb strings.Builder
+
+	sb.WriteByte('"')
+
+	for i := 0; i < len(value); i++ {
+		if value[i] == '"' || value[i] == '\\' {
+			sb.WriteByte('\\')
+		}
+
+		sb.WriteByte(value[i])
+	}
+
+	sb.WriteByte('"')
+
+	return sb.String()
+}
@@ -0,0 +1,76 @@
+package echox
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContext_AddLink(t *testing.T) {
+	var testCases = []struct {
+		name       string
+		whenLinks  func(c Context)
+		expectLink string
+	}{
+		{
+			name: "ok, single link",
+			whenLinks: func(c Context) {
+				c.AddLink("https://api.example.com/users?page=2", "next")
+			},
+			expectLink: `<https://api.example.com/users?page=2>; rel="next"`,
+		},
+		{
+			name: "ok, multiple links are comma-joined",
+			whenLinks: func(c Context) {
+				c.AddLink("/users?page=3", "next")
+				c.AddLink("/users?page=1", "prev")
+			},
+			expectLink: `</users?page=3>; rel="next", </users?page=1>; rel="prev"`,
+		},
+		{
+			name: "ok, target attributes",
+			whenLinks: func(c Context) {
+				c.AddLink("/docs", "help", LinkParam{Key: "type", Value: "text/html"}, LinkParam{Key: "title", Value: `say "hi"`})
+			},
+			expectLink: `</docs>; rel="help"; type="text/html"; title="say \"hi\""`,
+		},
+		{
+			name: "ok, uri is escaped",
+			whenLinks: func(c Context) {
+				c.AddLink("/search?q=a b>c", "next")
+			},
+			expectLink: `</search?q=a%20b%3Ec>; rel="next"`,
+		},
+		{
+			name: "ok, pagination links",
+			whenLinks: func(c Context) {
+				c.PaginationLinks("/users?page=3", "/users?page=1", "/users?page=1", "/users?page=10")
+			},
+			expectLink: `</users?page=3>; rel="next", </users?page=1>; rel="prev", </users?page=1>; rel="first", </users?page=10>; rel="last"`,
+		},
+		{
+			name: "ok, pagination links skip empty",
+			whenLinks: func(c Context) {
+				c.PaginationLinks("/users?page=2", "", "/users?page=1", "")
+			},
+			expectLink: `</users?page=2>; rel="next", </users?page=1>; rel="first"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			req := httptest.NewRequest(http.MethodGet, "/users", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			tc.whenLinks(c)
+			err := c.NoContent(http.StatusOK)
+
+			assert.NoError(t, err)
+			assert.Equal(t, []string{tc.expectLink}, rec.Header().Values(HeaderLink))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"errors"
+	"mime"
+	"strings"
+
+	"github.com/theopenlane/echox"
+)
+
+// AcceptContentTypeConfig defines the config for AcceptContentType middleware.
+type AcceptContentTypeConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// ContentTypes are media types request body is allowed to have (i.e. `application/json`). Entries in form of
+	// `application/*` match all subtypes and `*/*` matches any media type. Parameters (i.e. `charset`) are ignored.
+	// Required.
+	ContentTypes []string
+}
+
+// AcceptContentType returns an AcceptContentType middleware that rejects requests with body which `Content-Type` is
+// not one of given media types with "415 - Unsupported Media Type". Requests without body are not checked.
+func AcceptContentType(types ...string) echox.MiddlewareFunc {
+	return AcceptContentTypeWithConfig(AcceptContentTypeConfig{ContentTypes: types})
+}
+
+// AcceptContentTypeWithConfig returns an AcceptContentType middleware with config or panics on invalid configuration.
+// See: `AcceptContentType()`.
+func AcceptContentTypeWithConfig(config AcceptContentTypeConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts AcceptContentTypeConfig to middleware or returns an error for invalid configuration
+func (config AcceptContentTypeConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if config.Skipper == nil {
+		config.Skipper = DefaultSkipper
+	}
+
+	if len(config.ContentTypes) == 0 {
+		return nil, errors.New("echo accept content type middleware requires content types")
+	}
+
+	allowed := make([]string, 0, len(config.ContentTypes))
+	for _, ct := range config.ContentTypes {
+		mediaType, _, err := mime.ParseMediaType(ct)
+		if err != nil || !strings.Contains(mediaType, "/") {
+			return nil, errors.New("echo accept content type middleware has invalid content type: " + ct)
+		}
+
+		allowed = append(allowed, mediaType)
+	}
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			req := c.Request()
+			// ContentLength is -1 when length is unknown (i.e. chunked body)
+			if req.ContentLength == 0 {
+				return next(c)
+			}
+
+			mediaType, _, err := mime.ParseMediaType(req.Header.Get(echox.HeaderContentType))
+			if err != nil || !matchesMediaRange(mediaType, allowed) {
+				return echox.ErrUnsupportedMediaType
+			}
+
+			return next(c)
+		}
+	}, nil
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
+)
+
+func TestAcceptContentType(t *testing.T) {
+	var testCases = []struct {
+		name            string
+		givenTypes      []string
+		whenMethod      string
+		whenContentType string
+		whenBody        io.Reader
+		whenChunked     bool
+		expectErr       string
+	}{
+		{
+			name:            "ok, accepted content type",
+			givenTypes:      []string{echox.MIMEApplicationJSON},
+			whenMethod:      http.MethodPost,
+			whenContentType: echox.MIMEApplicationJSONCharsetUTF8,
+			whenBody:        strings.NewReader(`{}`),
+		},
+		{
+			name:            "nok, rejected content type",
+			givenTypes:      []string{echox.MIMEApplicationJSON},
+			whenMethod:      http.MethodPost,
+			whenContentType: "text/xml",
+			whenBody:        strings.NewReader(`<a/>`),
+			expectErr:       "code=415, message=Unsupported Media Type",
+		},
+		{
+			name:       "nok, body without content type",
+			givenTypes: []string{echox.MIMEApplicationJSON},
+			whenMethod: http.MethodPut,
+			whenBody:   strings.NewReader(`{}`),
+			expectErr:  "code=415, message=Unsupported Media Type",
+		},
+		{
+			name:            "nok, chunked body with rejected content type",
+			givenTypes:      []string{echox.MIMEApplicationJSON},
+			whenMethod:      http.MethodPost,
+			whenContentType: "text/xml",
+			whenBody:        strings.NewReader(`<a/>`),
+			whenChunked:     true,
+			expectErr:       "code=415, message=Unsupported Media Type",
+		},
+		{
+			name:            "ok, wildcard subtype",
+			givenTypes:      []string{"application/*"},
+			whenMethod:      http.MethodPost,
+			whenContentType: "application/merge-patch+json",
+			whenBody:        strings.NewReader(`{}`),
+		},
+		{
+			name:            "nok, wildcard subtype does not match other type",
+			givenTypes:      []string{"application/*"},
+			whenMethod:      http.MethodPost,
+			whenContentType: "text/plain",
+			whenBody:        strings.NewReader(`text`),
+			expectErr:       "code=415, message=Unsupported Media Type",
+		},
+		{
+			name:            "ok, request without body is not checked",
+			givenTypes:      []string{echox.MIMEApplicationJSON},
+			whenMethod:      http.MethodDelete,
+			whenContentType: "text/xml",
+		},
+		{
+			name:       "ok, GET without body",
+			givenTypes: []string{echox.MIMEApplicationJSON},
+			whenMethod: http.MethodGet,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			req := httptest.NewRequest(tc.whenMethod, "/", tc.whenBody)
+			if tc.whenContentType != "" {
+				req.Header.Set(echox.HeaderContentType, tc.whenContentType)
+			}
+			if tc.whenChunked {
+				req.ContentLength = -1
+			}
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			err := AcceptContentType(tc.givenTypes...)(func(c echox.Context) error {
+				return nil
+			})(c)
+
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAcceptContentTypeWithConfig_invalidConfig(t *testing.T) {
+	_, err := AcceptContentTypeConfig{}.ToMiddleware()
+	assert.EqualError(t, err, "echo accept content type middleware requires content types")
+
+	_, err = AcceptContentTypeConfig{ContentTypes: []string{"json"}}.ToMiddleware()
+	assert.EqualError(t, err, "echo accept content type middleware has invalid content type: json")
+}
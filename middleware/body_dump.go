@@ -7,6 +7,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"strings"
 
 	"github.com/theopenlane/echox"
 )
@@ -19,20 +20,64 @@ type BodyDumpConfig struct {
 	// Handler receives request and response payload.
 	// Required.
 	Handler BodyDumpHandler
+
+	// ContentTypeFilter decides if a body with given Content-Type header value is dumped. Request body is checked
+	// against the request Content-Type and response body against the response Content-Type at the time the response
+	// is first written. Bodies that are filtered out are not read into memory or buffered at all and Handler receives
+	// nil for them. Useful to skip multipart uploads, file downloads and other streaming payloads.
+	// Optional. Default value nil (all bodies are dumped).
+	ContentTypeFilter func(contentType string) bool
+
+	// MaxBodySize limits how many bytes of request and response body are captured and passed to Handler. Full body
+	// is still forwarded to the request handler and to the client. When captured body is cut short, BodyDumpTruncation
+	// is stored in context under ContextKeyBodyDumpTruncated before Handler is called.
+	// Optional. Default value 0 (no limit).
+	MaxBodySize int
+
+	// Redactor is called with Content-Type header value and captured request or response body before they are passed
+	// to Handler. It returns body with sensitive data (passwords, tokens) masked. Request handler and client always
+	// receive the original body. Redactor receives a copy of captured bytes and is not called for bodies that were not
+	// captured.
+	// Optional. Default value nil (bodies are passed to Handler as is).
+	Redactor func(contentType string, body []byte) []byte
 }
 
 // BodyDumpHandler receives the request and response payload.
 type BodyDumpHandler func(c echox.Context, reqBody []byte, resBody []byte)
 
+// ContextKeyBodyDumpTruncated is context key for BodyDumpTruncation value set by BodyDump middleware when
+// captured request or response body exceeded BodyDumpConfig.MaxBodySize.
+const ContextKeyBodyDumpTruncated = "body_dump_truncated"
+
+// BodyDumpTruncation describes which of the bodies passed to BodyDumpHandler were truncated.
+type BodyDumpTruncation struct {
+	Request  bool
+	Response bool
+}
+
 type bodyDumpResponseWriter struct {
-	io.Writer
 	http.ResponseWriter
+	contentTypeFilter func(contentType string) bool
+	maxBodySize       int
+	checkedHeader     bool
+	streaming         bool
+	truncated         bool
+	buffer            *bytes.Buffer
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
 }
 
 // BodyDump returns a BodyDump middleware.
 //
 // BodyDump middleware captures the request and response payload and calls the
 // registered handler.
+//
+// Streaming responses are passed through without buffering: `text/event-stream` response body is not captured at
+// all and when handler flushes the response, capturing stops and Handler receives only the part of the response body
+// written before the first flush.
 func BodyDump(handler BodyDumpHandler) echox.MiddlewareFunc {
 	return BodyDumpWithConfig(BodyDumpConfig{Handler: handler})
 }
@@ -53,6 +98,10 @@ func (config BodyDumpConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 		config.Skipper = DefaultSkipper
 	}
 
+	if config.MaxBodySize < 0 {
+		return nil, errors.New("echo body-dump middleware max body size can not be negative")
+	}
+
 	return func(next echox.HandlerFunc) echox.HandlerFunc {
 		return func(c echox.Context) error {
 			if config.Skipper(c) {
@@ -60,38 +109,117 @@ func (config BodyDumpConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 			}
 
 			// Request
-			reqBody := []byte{}
-			if c.Request().Body != nil {
-				reqBody, _ = io.ReadAll(c.Request().Body)
+			var reqBody []byte
+
+			reqTruncated := false
+
+			req := c.Request()
+			if config.ContentTypeFilter == nil || config.ContentTypeFilter(req.Header.Get(echox.HeaderContentType)) {
+				reqBody = []byte{}
+
+				switch {
+				case req.Body == nil:
+					req.Body = io.NopCloser(bytes.NewBuffer(reqBody)) // Reset
+				case config.MaxBodySize > 0:
+					reqBody, _ = io.ReadAll(io.LimitReader(req.Body, int64(config.MaxBodySize)+1))
+					// replay captured bytes before the rest of the (unread) original body
+					req.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(reqBody), req.Body), Closer: req.Body}
+
+					if len(reqBody) > config.MaxBodySize {
+						reqBody = reqBody[:config.MaxBodySize]
+						reqTruncated = true
+					}
+				default:
+					reqBody, _ = io.ReadAll(req.Body)
+					req.Body = io.NopCloser(bytes.NewBuffer(reqBody)) // Reset
+				}
 			}
 
-			c.Request().Body = io.NopCloser(bytes.NewBuffer(reqBody)) // Reset
-
 			// Response
-			resBody := new(bytes.Buffer)
-			mw := io.MultiWriter(c.Response().Writer, resBody)
-			writer := &bodyDumpResponseWriter{Writer: mw, ResponseWriter: c.Response().Writer}
+			writer := &bodyDumpResponseWriter{
+				ResponseWriter:    c.Response().Writer,
+				contentTypeFilter: config.ContentTypeFilter,
+				maxBodySize:       config.MaxBodySize,
+			}
 			c.Response().Writer = writer
 
 			err := next(c)
 
 			// Callback
-			config.Handler(c, reqBody, resBody.Bytes())
+			var resBody []byte
+			if writer.buffer != nil {
+				resBody = writer.buffer.Bytes()
+			}
+
+			if reqTruncated || writer.truncated {
+				c.Set(ContextKeyBodyDumpTruncated, BodyDumpTruncation{Request: reqTruncated, Response: writer.truncated})
+			}
+
+			if config.Redactor != nil {
+				if reqBody != nil {
+					reqBody = config.Redactor(req.Header.Get(echox.HeaderContentType), bytes.Clone(reqBody))
+				}
+
+				if resBody != nil {
+					resBody = config.Redactor(c.Response().Header().Get(echox.HeaderContentType), bytes.Clone(resBody))
+				}
+			}
+
+			config.Handler(c, reqBody, resBody)
 
 			return err
 		}
 	}, nil
 }
 
+// checkHeader decides, once response headers are final, if the response body needs to be buffered
+func (w *bodyDumpResponseWriter) checkHeader() {
+	if w.checkedHeader {
+		return
+	}
+
+	w.checkedHeader = true
+
+	contentType := w.Header().Get(echox.HeaderContentType)
+	if strings.HasPrefix(contentType, echox.MIMETextEventStream) {
+		w.streaming = true
+		return
+	}
+
+	if w.contentTypeFilter == nil || w.contentTypeFilter(contentType) {
+		w.buffer = new(bytes.Buffer)
+	}
+}
+
 func (w *bodyDumpResponseWriter) WriteHeader(code int) {
+	w.checkHeader()
 	w.ResponseWriter.WriteHeader(code)
 }
 
 func (w *bodyDumpResponseWriter) Write(b []byte) (int, error) {
-	return w.Writer.Write(b)
+	w.checkHeader()
+
+	n, err := w.ResponseWriter.Write(b)
+	if w.buffer != nil && !w.streaming {
+		w.capture(b[:n])
+	}
+
+	return n, err
+}
+
+func (w *bodyDumpResponseWriter) capture(b []byte) {
+	if w.maxBodySize > 0 {
+		if remaining := w.maxBodySize - w.buffer.Len(); len(b) > remaining {
+			b = b[:remaining]
+			w.truncated = true
+		}
+	}
+
+	w.buffer.Write(b)
 }
 
 func (w *bodyDumpResponseWriter) Flush() {
+	w.streaming = true
 	w.ResponseWriter.(http.Flusher).Flush()
 }
 
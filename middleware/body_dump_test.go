@@ -2,9 +2,11 @@ package middleware
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -113,3 +115,276 @@ func TestBodyDump_panic(t *testing.T) {
 		BodyDump(func(c echox.Context, reqBody, resBody []byte) {})
 	})
 }
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+func TestBodyDump_ContentTypeFilter(t *testing.T) {
+	onlyJSON := func(contentType string) bool {
+		return strings.HasPrefix(contentType, echox.MIMEApplicationJSON)
+	}
+
+	t.Run("dumps matching content types", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"id":1}`))
+		req.Header.Set(echox.HeaderContentType, echox.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := echox.New().NewContext(req, rec)
+
+		var reqBody, resBody []byte
+		mw, err := BodyDumpConfig{
+			ContentTypeFilter: onlyJSON,
+			Handler: func(c echox.Context, req, res []byte) {
+				reqBody = req
+				resBody = res
+			},
+		}.ToMiddleware()
+		assert.NoError(t, err)
+
+		err = mw(func(c echox.Context) error {
+			return c.JSONBlob(http.StatusOK, []byte(`{"ok":true}`))
+		})(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, `{"id":1}`, string(reqBody))
+		assert.Equal(t, `{"ok":true}`, string(resBody))
+		assert.Equal(t, `{"ok":true}`, rec.Body.String())
+	})
+
+	t.Run("does not buffer large octet-stream upload", func(t *testing.T) {
+		const uploadSize = 50 * MB
+
+		upload := io.NopCloser(io.LimitReader(zeroReader{}, uploadSize))
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Body = upload
+		req.Header.Set(echox.HeaderContentType, echox.MIMEOctetStream)
+		rec := httptest.NewRecorder()
+		c := echox.New().NewContext(req, rec)
+
+		isCalled := false
+		var reqBody, resBody []byte
+		mw, err := BodyDumpConfig{
+			ContentTypeFilter: onlyJSON,
+			Handler: func(c echox.Context, req, res []byte) {
+				isCalled = true
+				reqBody = req
+				resBody = res
+			},
+		}.ToMiddleware()
+		assert.NoError(t, err)
+
+		var writer *bodyDumpResponseWriter
+		err = mw(func(c echox.Context) error {
+			assert.Equal(t, upload, c.Request().Body) // body was not read and replaced with in-memory copy
+
+			n, err := io.Copy(io.Discard, c.Request().Body)
+			if err != nil {
+				return err
+			}
+
+			writer = c.Response().Writer.(*bodyDumpResponseWriter)
+
+			return c.Blob(http.StatusOK, echox.MIMEOctetStream, []byte(strconv.FormatInt(n, 10)))
+		})(c)
+
+		assert.NoError(t, err)
+		assert.True(t, isCalled)
+		assert.Nil(t, reqBody)
+		assert.Nil(t, resBody)
+		assert.Nil(t, writer.buffer)
+		assert.Equal(t, strconv.FormatInt(uploadSize, 10), rec.Body.String())
+	})
+}
+
+func TestBodyDump_MaxBodySize(t *testing.T) {
+	var testCases = []struct {
+		name            string
+		givenMaxSize    int
+		whenBody        string
+		expectReqBody   string
+		expectResBody   string
+		expectTruncated interface{}
+	}{
+		{
+			name:            "body under the cap",
+			givenMaxSize:    100,
+			whenBody:        "Hello, World!",
+			expectReqBody:   "Hello, World!",
+			expectResBody:   "Hello, World!",
+			expectTruncated: nil,
+		},
+		{
+			name:            "body exactly at the cap",
+			givenMaxSize:    13,
+			whenBody:        "Hello, World!",
+			expectReqBody:   "Hello, World!",
+			expectResBody:   "Hello, World!",
+			expectTruncated: nil,
+		},
+		{
+			name:            "body over the cap",
+			givenMaxSize:    5,
+			whenBody:        "Hello, World!",
+			expectReqBody:   "Hello",
+			expectResBody:   "Hello",
+			expectTruncated: BodyDumpTruncation{Request: true, Response: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.whenBody))
+			rec := httptest.NewRecorder()
+			c := echox.New().NewContext(req, rec)
+
+			var reqBody, resBody string
+			var truncated interface{}
+			mw, err := BodyDumpConfig{
+				MaxBodySize: tc.givenMaxSize,
+				Handler: func(c echox.Context, req, res []byte) {
+					reqBody = string(req)
+					resBody = string(res)
+					truncated = c.Get(ContextKeyBodyDumpTruncated)
+				},
+			}.ToMiddleware()
+			assert.NoError(t, err)
+
+			handlerBody := ""
+			err = mw(func(c echox.Context) error {
+				body, err := io.ReadAll(c.Request().Body)
+				if err != nil {
+					return err
+				}
+
+				handlerBody = string(body)
+
+				// write response in two parts to check that capture spans over multiple writes
+				if _, err := c.Response().Write(body[:2]); err != nil {
+					return err
+				}
+				_, err = c.Response().Write(body[2:])
+
+				return err
+			})(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.whenBody, handlerBody)
+			assert.Equal(t, tc.whenBody, rec.Body.String())
+			assert.Equal(t, tc.expectReqBody, reqBody)
+			assert.Equal(t, tc.expectResBody, resBody)
+			assert.Equal(t, tc.expectTruncated, truncated)
+		})
+	}
+}
+
+func TestBodyDump_MaxBodySizeNegative(t *testing.T) {
+	mw, err := BodyDumpConfig{
+		MaxBodySize: -1,
+		Handler:     func(c echox.Context, reqBody, resBody []byte) {},
+	}.ToMiddleware()
+
+	assert.EqualError(t, err, "echo body-dump middleware max body size can not be negative")
+	assert.Nil(t, mw)
+}
+
+func TestBodyDump_Redactor(t *testing.T) {
+	e := echox.New()
+	body := `{"user":"jon","password":"secret"}`
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set(echox.HeaderContentType, echox.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	h := func(c echox.Context) error {
+		b, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return err
+		}
+
+		return c.JSONBlob(http.StatusOK, b)
+	}
+
+	var contentTypes []string
+	requestBody := ""
+	responseBody := ""
+	mw, err := BodyDumpConfig{
+		Handler: func(c echox.Context, reqBody, resBody []byte) {
+			requestBody = string(reqBody)
+			responseBody = string(resBody)
+		},
+		Redactor: func(contentType string, body []byte) []byte {
+			contentTypes = append(contentTypes, contentType)
+			return []byte(strings.ReplaceAll(string(body), `"secret"`, `"***"`))
+		},
+	}.ToMiddleware()
+	assert.NoError(t, err)
+
+	if assert.NoError(t, mw(h)(c)) {
+		expect := `{"user":"jon","password":"***"}`
+		assert.Equal(t, expect, requestBody)
+		assert.Equal(t, expect, responseBody)
+		assert.Equal(t, []string{echox.MIMEApplicationJSON, echox.MIMEApplicationJSONCharsetUTF8}, contentTypes)
+		assert.Equal(t, body, rec.Body.String())
+	}
+}
+
+func TestBodyDump_streaming(t *testing.T) {
+	var testCases = []struct {
+		name            string
+		whenContentType string
+		expectResBody   string
+	}{
+		{
+			name:            "event-stream response is not captured",
+			whenContentType: echox.MIMETextEventStream,
+			expectResBody:   "",
+		},
+		{
+			name:            "capturing stops when handler flushes",
+			whenContentType: echox.MIMETextPlainCharsetUTF8,
+			expectResBody:   "data: 1\n\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := echox.New().NewContext(req, rec)
+
+			var resBody []byte
+			mw, err := BodyDumpConfig{
+				Handler: func(c echox.Context, req, res []byte) {
+					resBody = res
+				},
+			}.ToMiddleware()
+			assert.NoError(t, err)
+
+			err = mw(func(c echox.Context) error {
+				res := c.Response()
+				res.Header().Set(echox.HeaderContentType, tc.whenContentType)
+				res.WriteHeader(http.StatusOK)
+
+				for i := 1; i <= 3; i++ {
+					if _, err := fmt.Fprintf(res, "data: %d\n\n", i); err != nil {
+						return err
+					}
+
+					res.Flush()
+
+					// event has reached the client before handler has finished
+					assert.True(t, rec.Flushed)
+					assert.True(t, strings.HasSuffix(rec.Body.String(), fmt.Sprintf("data: %d\n\n", i)))
+				}
+
+				return nil
+			})(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, "data: 1\n\ndata: 2\n\ndata: 3\n\n", rec.Body.String())
+			assert.Equal(t, tc.expectResBody, string(resBody))
+		})
+	}
+}
@@ -12,7 +12,8 @@ type BodyLimitConfig struct {
 	// Skipper defines a function to skip middleware.
 	Skipper Skipper
 
-	// LimitBytes is maximum allowed size in bytes for a request body
+	// LimitBytes is maximum allowed size in bytes for a request body. Routes can override the limit with
+	// `echox.Route.BodyLimit` when middleware is added with `e.Use` (after routing is done).
 	LimitBytes int64
 }
 
@@ -59,14 +60,20 @@ func (config BodyLimitConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 
 			req := c.Request()
 
+			limit := config.LimitBytes
+			if ri, ok := c.RouteInfo().(echox.BodyLimitRouteInfo); ok && ri.BodyLimit() > 0 {
+				limit = ri.BodyLimit()
+			}
+
 			// Based on content length
-			if req.ContentLength > config.LimitBytes {
+			if req.ContentLength > limit {
 				return echox.ErrStatusRequestEntityTooLarge
 			}
 
 			// Based on content read
 			r := pool.Get().(*limitedReader)
 			r.Reset(req.Body)
+			r.LimitBytes = limit
 
 			defer pool.Put(r)
 			req.Body = r
@@ -169,3 +169,79 @@ func TestBodyLimit(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rec.Code)
 	assert.Equal(t, hw, rec.Body.Bytes())
 }
+
+func TestBodyLimit_routeOverride(t *testing.T) {
+	e := echox.New()
+	e.Use(BodyLimit(1024))
+
+	handler := func(c echox.Context) error {
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, string(body[:5]))
+	}
+	_, err := e.AddRoute(echox.Route{
+		Method:    http.MethodPost,
+		Path:      "/upload",
+		Handler:   handler,
+		BodyLimit: 10 * 1024,
+	})
+	assert.NoError(t, err)
+	e.POST("/comments", handler)
+
+	var testCases = []struct {
+		name         string
+		whenURL      string
+		whenSize     int
+		whenChunked  bool
+		expectStatus int
+	}{
+		{
+			name:         "ok, upload route accepts body larger than global limit",
+			whenURL:      "/upload",
+			whenSize:     5 * 1024,
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "ok, upload route limit is enforced also for read content",
+			whenURL:      "/upload",
+			whenSize:     5 * 1024,
+			whenChunked:  true,
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "nok, upload route rejects body larger than route limit",
+			whenURL:      "/upload",
+			whenSize:     11 * 1024,
+			expectStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:         "nok, sibling route rejects same body size",
+			whenURL:      "/comments",
+			whenSize:     5 * 1024,
+			expectStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:         "nok, sibling route rejects same body size read content",
+			whenURL:      "/comments",
+			whenSize:     5 * 1024,
+			whenChunked:  true,
+			expectStatus: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tc.whenURL, bytes.NewReader(bytes.Repeat([]byte("a"), tc.whenSize)))
+			if tc.whenChunked {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"github.com/theopenlane/echox"
+)
+
+// BufferResponseConfig defines the config for BufferResponse middleware.
+type BufferResponseConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// MaxBuffer is maximum number of response body bytes kept in memory. When response grows over the limit buffered
+	// part is written to the client and rest of the response is streamed through - errors returned after that can not
+	// replace the response anymore.
+	// Optional. Default value 0 (no limit).
+	MaxBuffer int
+}
+
+// BufferResponse returns a BufferResponse middleware that keeps response in memory until handler returns. Response is
+// written to the client only when handler returns no error. When handler returns an error the buffered response
+// (status, headers and body) is discarded so HTTPErrorHandler can send a clean error response instead of half written
+// one.
+//
+// Response.Before hooks registered before the discarded response was started are called again for the error response.
+// Add Recover middleware before BufferResponse so panics are handled the same way.
+func BufferResponse() echox.MiddlewareFunc {
+	return BufferResponseWithConfig(BufferResponseConfig{})
+}
+
+// BufferResponseWithConfig returns a BufferResponse middleware with config or panics on invalid configuration.
+// See: `BufferResponse()`.
+func BufferResponseWithConfig(config BufferResponseConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts BufferResponseConfig to middleware or returns an error for invalid configuration
+func (config BufferResponseConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if config.Skipper == nil {
+		config.Skipper = DefaultSkipper
+	}
+
+	if config.MaxBuffer < 0 {
+		return nil, errors.New("echo buffer response middleware max buffer can not be negative")
+	}
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			res := c.Response()
+			rw := res.Writer
+
+			bw := &bufferResponseWriter{
+				ResponseWriter: rw,
+				header:         rw.Header().Clone(),
+				maxBuffer:      config.MaxBuffer,
+			}
+			res.Writer = bw
+
+			flushed := false
+			defer func() {
+				res.Writer = rw
+
+				if !flushed && !bw.streaming {
+					// discard buffered response so error handler can write a new one
+					res.Committed = false
+					res.Status = http.StatusOK
+					res.Size = 0
+				}
+			}()
+
+			err := next(c)
+			if err != nil && !bw.streaming {
+				return err
+			}
+
+			if wErr := bw.flush(); wErr != nil && err == nil {
+				err = wErr
+			}
+
+			flushed = true
+
+			return err
+		}
+	}, nil
+}
+
+type bufferResponseWriter struct {
+	http.ResponseWriter
+	header    http.Header
+	buffer    bytes.Buffer
+	code      int
+	maxBuffer int
+	// streaming is set when buffered response was written to the client and the rest is written directly
+	streaming bool
+}
+
+func (w *bufferResponseWriter) Header() http.Header {
+	if w.streaming {
+		return w.ResponseWriter.Header()
+	}
+
+	return w.header
+}
+
+func (w *bufferResponseWriter) WriteHeader(code int) {
+	if w.streaming {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+
+	w.code = code
+}
+
+func (w *bufferResponseWriter) Write(b []byte) (int, error) {
+	if !w.streaming && w.maxBuffer > 0 && w.buffer.Len()+len(b) > w.maxBuffer {
+		if err := w.flush(); err != nil {
+			return 0, err
+		}
+	}
+
+	if w.streaming {
+		return w.ResponseWriter.Write(b)
+	}
+
+	return w.buffer.Write(b)
+}
+
+func (w *bufferResponseWriter) Flush() {
+	// explicit flush means handler wants data to reach the client now
+	if err := w.flush(); err != nil {
+		return
+	}
+
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// flush writes buffered headers, status and body to the client and switches writer to streaming mode.
+func (w *bufferResponseWriter) flush() error {
+	if w.streaming {
+		return nil
+	}
+
+	w.streaming = true
+
+	header := w.ResponseWriter.Header()
+	for k := range header {
+		delete(header, k)
+	}
+
+	for k, v := range w.header {
+		header[k] = v
+	}
+
+	if w.code != 0 {
+		w.ResponseWriter.WriteHeader(w.code)
+	}
+
+	if w.buffer.Len() == 0 {
+		return nil
+	}
+
+	_, err := w.buffer.WriteTo(w.ResponseWriter)
+
+	return err
+}
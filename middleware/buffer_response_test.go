@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
+)
+
+func TestBufferResponse(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		givenConfig   BufferResponseConfig
+		whenHandler   echox.HandlerFunc
+		expectStatus  int
+		expectBody    string
+		expectHeaders map[string]string
+	}{
+		{
+			name: "ok, handler writes and errors, client sees only error page",
+			whenHandler: func(c echox.Context) error {
+				c.Response().Header().Set("X-Partial", "true")
+				c.Response().Header().Set(echox.HeaderContentType, echox.MIMETextPlain)
+				c.Response().WriteHeader(http.StatusOK)
+				_, _ = c.Response().Write([]byte("partial resp"))
+				return errors.New("failed halfway")
+			},
+			expectStatus:  http.StatusInternalServerError,
+			expectBody:    `{"message":"Internal Server Error"}` + "\n",
+			expectHeaders: map[string]string{"X-Partial": "", echox.HeaderContentType: echox.MIMEApplicationJSONCharsetUTF8},
+		},
+		{
+			name: "ok, successful response is written",
+			whenHandler: func(c echox.Context) error {
+				c.Response().Header().Set("X-Custom", "value")
+				return c.String(http.StatusCreated, "created")
+			},
+			expectStatus:  http.StatusCreated,
+			expectBody:    "created",
+			expectHeaders: map[string]string{"X-Custom": "value"},
+		},
+		{
+			name: "ok, no content response",
+			whenHandler: func(c echox.Context) error {
+				return c.NoContent(http.StatusNoContent)
+			},
+			expectStatus: http.StatusNoContent,
+		},
+		{
+			name:        "ok, response over MaxBuffer is streamed and can not be replaced",
+			givenConfig: BufferResponseConfig{MaxBuffer: 10},
+			whenHandler: func(c echox.Context) error {
+				_, _ = c.Response().Write([]byte(strings.Repeat("a", 8)))
+				_, _ = c.Response().Write([]byte(strings.Repeat("b", 8)))
+				return errors.New("failed after streaming")
+			},
+			expectStatus: http.StatusOK,
+			expectBody:   strings.Repeat("a", 8) + strings.Repeat("b", 8),
+		},
+		{
+			name:        "ok, response under MaxBuffer is discarded on error",
+			givenConfig: BufferResponseConfig{MaxBuffer: 100},
+			whenHandler: func(c echox.Context) error {
+				_, _ = c.Response().Write([]byte("partial"))
+				return echox.ErrBadRequest
+			},
+			expectStatus: http.StatusBadRequest,
+			expectBody:   `{"message":"Bad Request"}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			e.Use(BufferResponseWithConfig(tc.givenConfig))
+			e.GET("/", tc.whenHandler)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectStatus, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+			for k, v := range tc.expectHeaders {
+				assert.Equal(t, v, rec.Header().Get(k), k)
+			}
+		})
+	}
+}
+
+func TestBufferResponseWithConfig_negativeMaxBuffer(t *testing.T) {
+	mw, err := BufferResponseConfig{MaxBuffer: -1}.ToMiddleware()
+
+	assert.EqualError(t, err, "echo buffer response middleware max buffer can not be negative")
+	assert.Nil(t, mw)
+}
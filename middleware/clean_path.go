@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"errors"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/theopenlane/echox"
+)
+
+// CleanPathConfig is the middleware config for normalizing request path.
+type CleanPathConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// Status code to be used when redirecting the request to the cleaned path.
+	// Optional, but when provided the request is redirected using this code (i.e. http.StatusPermanentRedirect),
+	// otherwise request path is rewritten in place before routing.
+	// Valid status codes: [300...308]
+	RedirectCode int
+}
+
+// CleanPath returns a root level (before router) middleware which normalizes request `URL#Path` by collapsing
+// repeated slashes and resolving `.` and `..` segments (i.e. `/api//users/./42` becomes `/api/users/42`). Path
+// can not be resolved above root - `/../a` becomes `/a`. Trailing slash is preserved.
+//
+// Usage `Echo#Pre(CleanPath())`
+func CleanPath() echox.MiddlewareFunc {
+	return CleanPathWithConfig(CleanPathConfig{})
+}
+
+// CleanPathWithConfig returns a CleanPath middleware with config or panics on invalid configuration.
+func CleanPathWithConfig(config CleanPathConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts CleanPathConfig to middleware or returns an error for invalid configuration
+func (config CleanPathConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if config.Skipper == nil {
+		config.Skipper = DefaultSkipper
+	}
+
+	if config.RedirectCode != 0 && !isRedirectCode(config.RedirectCode) {
+		return nil, errors.New("echo clean path middleware redirect code must be one of 301, 302, 303, 307 or 308")
+	}
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			req := c.Request()
+			u := req.URL
+
+			// cleaning escaped path keeps encoded characters (i.e. `%2F`) as they are
+			escaped := u.EscapedPath()
+			cleaned := cleanURLPath(escaped)
+			if cleaned == escaped {
+				return next(c)
+			}
+
+			unescaped, err := url.PathUnescape(cleaned)
+			if err != nil {
+				return next(c)
+			}
+
+			uri := cleaned
+			if qs := c.QueryString(); qs != "" {
+				uri += "?" + qs
+			}
+
+			// Redirect
+			if config.RedirectCode != 0 {
+				return c.Redirect(config.RedirectCode, sanitizeURI(uri))
+			}
+
+			// Forward
+			req.RequestURI = uri
+			u.Path = unescaped
+			u.RawPath = ""
+			if unescaped != cleaned {
+				u.RawPath = cleaned
+			}
+
+			return next(c)
+		}
+	}, nil
+}
+
+// cleanURLPath collapses repeated slashes and resolves `.` and `..` segments of the path keeping trailing slash.
+func cleanURLPath(p string) string {
+	if p == "" {
+		return "/"
+	}
+
+	cleaned := path.Clean("/" + p) // rooted path can not be resolved above root
+	if cleaned != "/" && strings.HasSuffix(p, "/") {
+		cleaned += "/"
+	}
+
+	return cleaned
+}
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
+)
+
+func TestCleanPath(t *testing.T) {
+	var testCases = []struct {
+		name             string
+		givenConfig      CleanPathConfig
+		whenURL          string
+		expectPath       string
+		expectRequestURI string
+		expectStatus     int
+		expectLocation   string
+	}{
+		{
+			name:             "ok, dot dot segment and double slash are resolved",
+			whenURL:          "//a/b/../c",
+			expectPath:       "/a/c",
+			expectRequestURI: "/a/c",
+			expectStatus:     http.StatusOK,
+		},
+		{
+			name:             "ok, repeated slashes are collapsed and query is kept",
+			whenURL:          "/api//users///42?x=1",
+			expectPath:       "/api/users/42",
+			expectRequestURI: "/api/users/42?x=1",
+			expectStatus:     http.StatusOK,
+		},
+		{
+			name:             "ok, trailing slash is preserved",
+			whenURL:          "/a/./b//",
+			expectPath:       "/a/b/",
+			expectRequestURI: "/a/b/",
+			expectStatus:     http.StatusOK,
+		},
+		{
+			name:             "ok, traversal above root stops at root",
+			whenURL:          "/../../etc/passwd",
+			expectPath:       "/etc/passwd",
+			expectRequestURI: "/etc/passwd",
+			expectStatus:     http.StatusOK,
+		},
+		{
+			name:             "ok, encoded slash is kept",
+			whenURL:          "/a//b%2Fc",
+			expectPath:       "/a/b/c",
+			expectRequestURI: "/a/b%2Fc",
+			expectStatus:     http.StatusOK,
+		},
+		{
+			name:             "ok, clean path is not changed",
+			whenURL:          "/a/b",
+			expectPath:       "/a/b",
+			expectRequestURI: "/a/b",
+			expectStatus:     http.StatusOK,
+		},
+		{
+			name:           "ok, redirect to cleaned path",
+			givenConfig:    CleanPathConfig{RedirectCode: http.StatusPermanentRedirect},
+			whenURL:        "//a/b/../c?x=1",
+			expectPath:     "//a/b/../c",
+			expectStatus:   http.StatusPermanentRedirect,
+			expectLocation: "/a/c?x=1",
+		},
+		{
+			name:           "ok, redirect does not create open redirect",
+			givenConfig:    CleanPathConfig{RedirectCode: http.StatusPermanentRedirect},
+			whenURL:        `/.//\example.com`,
+			expectPath:     `/.//\example.com`,
+			expectStatus:   http.StatusPermanentRedirect,
+			expectLocation: "/%5Cexample.com",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+
+			mw, err := tc.givenConfig.ToMiddleware()
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, tc.whenURL, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err = mw(func(c echox.Context) error {
+				return c.NoContent(http.StatusOK)
+			})(c)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectStatus, rec.Code)
+			assert.Equal(t, tc.expectPath, req.URL.Path)
+			assert.Equal(t, tc.expectLocation, rec.Header().Get(echox.HeaderLocation))
+			if tc.expectRequestURI != "" {
+				assert.Equal(t, tc.expectRequestURI, req.RequestURI)
+			}
+		})
+	}
+}
+
+func TestCleanPath_beforeRouting(t *testing.T) {
+	e := echox.New()
+	e.Pre(CleanPath())
+	e.GET("/a/c", func(c echox.Context) error {
+		return c.String(http.StatusOK, "OK")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "//a/b/../c", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "OK", rec.Body.String())
+}
+
+func TestCleanPathWithConfig_invalidRedirectCode(t *testing.T) {
+	for _, code := range []int{http.StatusOK, http.StatusNotModified, http.StatusUseProxy, 306} {
+		mw, err := CleanPathConfig{RedirectCode: code}.ToMiddleware()
+
+		assert.EqualError(t, err, "echo clean path middleware redirect code must be one of 301, 302, 303, 307 or 308")
+		assert.Nil(t, mw)
+	}
+}
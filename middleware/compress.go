@@ -39,6 +39,33 @@ type GzipConfig struct {
 	// See also:
 	// https://webmasters.stackexchange.com/questions/31750/what-is-recommended-minimum-object-size-for-gzip-performance-benefits
 	MinLength int
+
+	// SkipContentTypes are media types of responses that are sent uncompressed because their content is already
+	// compressed (images, video, archives). Entries in form of `video/*` match all subtypes. Content type is taken
+	// from `Content-Type` header set by handler or detected from the first written bytes.
+	// Optional. Default value DefaultGzipSkipContentTypes. Set to empty non-nil slice to compress all responses.
+	SkipContentTypes []string
+}
+
+// DefaultGzipSkipContentTypes are media types of already compressed content that Gzip middleware does not compress
+// by default.
+var DefaultGzipSkipContentTypes = []string{
+	"image/jpeg",
+	"image/png",
+	"image/gif",
+	"image/webp",
+	"image/avif",
+	"video/*",
+	"audio/*",
+	"font/woff",
+	"font/woff2",
+	"application/zip",
+	"application/gzip",
+	"application/x-gzip",
+	"application/x-bzip2",
+	"application/x-7z-compressed",
+	"application/x-rar-compressed",
+	"application/zstd",
 }
 
 type gzipResponseWriter struct {
@@ -50,6 +77,9 @@ type gzipResponseWriter struct {
 	minLengthExceeded bool
 	buffer            *bytes.Buffer
 	code              int
+	skipContentTypes  []string
+	// passthrough is set when response content type is not compressed and body is written directly to response
+	passthrough bool
 }
 
 // Gzip returns a middleware which compresses HTTP response using gzip compression scheme.
@@ -80,6 +110,10 @@ func (config GzipConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 		config.MinLength = 0
 	}
 
+	if config.SkipContentTypes == nil {
+		config.SkipContentTypes = DefaultGzipSkipContentTypes
+	}
+
 	pool := gzipCompressPool(config)
 	bpool := bufferPool()
 
@@ -106,12 +140,23 @@ func (config GzipConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 				buf := bpool.Get().(*bytes.Buffer)
 				buf.Reset()
 
-				grw := &gzipResponseWriter{Writer: w, ResponseWriter: rw, minLength: config.MinLength, buffer: buf}
+				grw := &gzipResponseWriter{
+					Writer:           w,
+					ResponseWriter:   rw,
+					minLength:        config.MinLength,
+					buffer:           buf,
+					skipContentTypes: config.SkipContentTypes,
+				}
 				defer func() {
 					// There are different reasons for cases when we have not yet written response to the client and now need to do so.
 					// a) handler response had only response code and no response body (ala 404 or redirects etc). Response code need to be written now.
 					// b) body is shorter than our minimum length threshold and being buffered currently and needs to be written
-					if !grw.wroteBody {
+					if grw.passthrough {
+						// Response with skipped content type was already written uncompressed
+						res.Writer = rw
+
+						w.Reset(io.Discard)
+					} else if !grw.wroteBody {
 						if res.Header().Get(echox.HeaderContentEncoding) == gzipScheme {
 							res.Header().Del(echox.HeaderContentEncoding)
 						}
@@ -151,10 +196,19 @@ func (config GzipConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 }
 
 func (w *gzipResponseWriter) WriteHeader(code int) {
-	w.Header().Del(echox.HeaderContentLength) // Issue #444
-
 	w.wroteHeader = true
 
+	if isGzipSkippedContentType(w.Header().Get(echox.HeaderContentType), w.skipContentTypes) {
+		// Response will not be compressed so header can be written as is (including Content-Length)
+		w.passthrough = true
+		w.code = code
+		w.ResponseWriter.WriteHeader(code)
+
+		return
+	}
+
+	w.Header().Del(echox.HeaderContentLength) // Issue #444
+
 	// Delay writing of the header until we know if we'll actually compress the response
 	w.code = code
 }
@@ -164,8 +218,20 @@ func (w *gzipResponseWriter) Write(b []byte) (int, error) {
 		w.Header().Set(echox.HeaderContentType, http.DetectContentType(b))
 	}
 
+	if !w.wroteBody && !w.passthrough && isGzipSkippedContentType(w.Header().Get(echox.HeaderContentType), w.skipContentTypes) {
+		w.passthrough = true
+
+		if w.wroteHeader {
+			w.ResponseWriter.WriteHeader(w.code)
+		}
+	}
+
 	w.wroteBody = true
 
+	if w.passthrough {
+		return w.ResponseWriter.Write(b)
+	}
+
 	if !w.minLengthExceeded {
 		n, err := w.buffer.Write(b)
 
@@ -189,6 +255,14 @@ func (w *gzipResponseWriter) Write(b []byte) (int, error) {
 }
 
 func (w *gzipResponseWriter) Flush() {
+	if w.passthrough {
+		if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+			flusher.Flush()
+		}
+
+		return
+	}
+
 	if !w.minLengthExceeded {
 		// Enforce compression because we will not know how much more data will come
 		w.minLengthExceeded = true
@@ -220,6 +294,17 @@ func (w *gzipResponseWriter) Push(target string, opts *http.PushOptions) error {
 	return http.ErrNotSupported
 }
 
+// isGzipSkippedContentType checks if media type of content type is one of skipped media types
+func isGzipSkippedContentType(contentType string, skipContentTypes []string) bool {
+	if len(skipContentTypes) == 0 || contentType == "" {
+		return false
+	}
+
+	mediaType, _, _ := strings.Cut(contentType, ";")
+
+	return matchesMediaRange(strings.ToLower(strings.TrimSpace(mediaType)), skipContentTypes)
+}
+
 func gzipCompressPool(config GzipConfig) sync.Pool {
 	return sync.Pool{
 		New: func() interface{} {
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strconv"
 	"testing"
 	"time"
 
@@ -202,7 +203,7 @@ func TestGzipWithStatic(t *testing.T) {
 	e := echox.New()
 	e.Filesystem = os.DirFS("../")
 
-	e.Use(Gzip())
+	e.Use(GzipWithConfig(GzipConfig{SkipContentTypes: []string{}}))
 	e.Static("/test", "_fixture/images")
 
 	req := httptest.NewRequest(http.MethodGet, "/test/walle.png", nil)
@@ -258,6 +259,107 @@ func TestGzipWithMinLength(t *testing.T) {
 	}
 }
 
+func TestGzip_SkipContentTypes(t *testing.T) {
+	jpeg := append([]byte{0xFF, 0xD8, 0xFF, 0xE0}, bytes.Repeat([]byte{0x01}, 100)...)
+
+	var testCases = []struct {
+		name           string
+		givenConfig    GzipConfig
+		whenHandler    echox.HandlerFunc
+		expectEncoding string
+		expectBody     []byte
+	}{
+		{
+			name:        "ok, JPEG response is not compressed",
+			givenConfig: GzipConfig{MinLength: 10},
+			whenHandler: func(c echox.Context) error {
+				return c.Blob(http.StatusOK, "image/jpeg", jpeg)
+			},
+			expectBody: jpeg,
+		},
+		{
+			name:        "ok, detected JPEG content type is not compressed",
+			givenConfig: GzipConfig{MinLength: 10},
+			whenHandler: func(c echox.Context) error {
+				_, err := c.Response().Write(jpeg)
+				return err
+			},
+			expectBody: jpeg,
+		},
+		{
+			name:        "ok, wildcard content type is not compressed",
+			givenConfig: GzipConfig{MinLength: 10},
+			whenHandler: func(c echox.Context) error {
+				return c.Blob(http.StatusOK, "video/mp4; codecs=avc1", jpeg)
+			},
+			expectBody: jpeg,
+		},
+		{
+			name:        "ok, JSON response above MinLength is compressed",
+			givenConfig: GzipConfig{MinLength: 10},
+			whenHandler: func(c echox.Context) error {
+				return c.JSONBlob(http.StatusOK, []byte(`{"name":"Jon Snow","id":1}`))
+			},
+			expectEncoding: gzipScheme,
+			expectBody:     []byte(`{"name":"Jon Snow","id":1}`),
+		},
+		{
+			name:        "ok, JPEG is compressed when skip list is empty",
+			givenConfig: GzipConfig{MinLength: 10, SkipContentTypes: []string{}},
+			whenHandler: func(c echox.Context) error {
+				return c.Blob(http.StatusOK, "image/jpeg", jpeg)
+			},
+			expectEncoding: gzipScheme,
+			expectBody:     jpeg,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			e.Use(GzipWithConfig(tc.givenConfig))
+			e.GET("/", tc.whenHandler)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(echox.HeaderAcceptEncoding, gzipScheme)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tc.expectEncoding, rec.Header().Get(echox.HeaderContentEncoding))
+
+			body := rec.Body.Bytes()
+			if tc.expectEncoding == gzipScheme {
+				r, err := gzip.NewReader(rec.Body)
+				assert.NoError(t, err)
+				body, err = io.ReadAll(r)
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectBody, body)
+		})
+	}
+}
+
+func TestGzipWithStatic_skippedContentType(t *testing.T) {
+	e := echox.New()
+	e.Filesystem = os.DirFS("../")
+
+	e.Use(Gzip())
+	e.Static("/test", "_fixture/images")
+
+	req := httptest.NewRequest(http.MethodGet, "/test/walle.png", nil)
+	req.Header.Set(echox.HeaderAcceptEncoding, gzipScheme)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	want, err := os.ReadFile("../_fixture/images/walle.png")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "", rec.Header().Get(echox.HeaderContentEncoding))
+	assert.Equal(t, strconv.Itoa(len(want)), rec.Header().Get(echox.HeaderContentLength))
+	assert.Equal(t, want, rec.Body.Bytes())
+}
+
 func TestGzipWithMinLengthTooShort(t *testing.T) {
 	e := echox.New()
 	// Minimal response length
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/theopenlane/echox"
+)
+
+// ConcurrencyLimitConfig defines the config for ConcurrencyLimit middleware.
+type ConcurrencyLimitConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// Max is maximum number of requests that are served concurrently.
+	// Required.
+	Max int
+
+	// MaxWait is how long request waits for a free slot when Max requests are already in flight. When no slot is freed
+	// in that time (or request context is cancelled) echox.ErrServiceUnavailable is returned.
+	// Optional. Default value 0 (request is rejected immediately).
+	MaxWait time.Duration
+
+	// InFlightFunc is called with current number of in-flight requests every time request acquires or releases a slot.
+	// Useful for exporting metrics. It is called concurrently from multiple goroutines.
+	// Optional. Default value nil.
+	InFlightFunc func(inFlight int)
+}
+
+// ConcurrencyLimit returns a ConcurrencyLimit middleware that limits number of concurrently served requests to max.
+// Requests over the limit are rejected with "503 - Service Unavailable".
+//
+// Slot is released in a deferred call so panicking handlers do not leak slots. Add Recover middleware before
+// ConcurrencyLimit so panics are turned into errors.
+func ConcurrencyLimit(max int) echox.MiddlewareFunc {
+	return ConcurrencyLimitWithConfig(ConcurrencyLimitConfig{Max: max})
+}
+
+// ConcurrencyLimitWithConfig returns a ConcurrencyLimit middleware with config or panics on invalid configuration.
+// See: `ConcurrencyLimit()`.
+func ConcurrencyLimitWithConfig(config ConcurrencyLimitConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts ConcurrencyLimitConfig to middleware or returns an error for invalid configuration
+func (config ConcurrencyLimitConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if config.Skipper == nil {
+		config.Skipper = DefaultSkipper
+	}
+
+	if config.Max <= 0 {
+		return nil, errors.New("echo concurrency limit middleware max must be greater than zero")
+	}
+
+	if config.MaxWait < 0 {
+		return nil, errors.New("echo concurrency limit middleware max wait can not be negative")
+	}
+
+	slots := make(chan struct{}, config.Max)
+
+	var inFlight atomic.Int64
+
+	acquire := func(c echox.Context) bool {
+		select {
+		case slots <- struct{}{}:
+			return true
+		default:
+		}
+
+		if config.MaxWait == 0 {
+			return false
+		}
+
+		timer := time.NewTimer(config.MaxWait)
+		defer timer.Stop()
+
+		select {
+		case slots <- struct{}{}:
+			return true
+		case <-timer.C:
+			return false
+		case <-c.Request().Context().Done():
+			return false
+		}
+	}
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			if !acquire(c) {
+				return echox.ErrServiceUnavailable
+			}
+
+			n := inFlight.Add(1)
+			if config.InFlightFunc != nil {
+				config.InFlightFunc(int(n))
+			}
+
+			defer func() {
+				n := inFlight.Add(-1)
+				<-slots
+
+				if config.InFlightFunc != nil {
+					config.InFlightFunc(int(n))
+				}
+			}()
+
+			return next(c)
+		}
+	}, nil
+}
@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
+)
+
+func TestConcurrencyLimit(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		givenConfig ConcurrencyLimitConfig
+		expectErr   string
+	}{
+		{
+			name:        "nok, saturated limit rejects immediately",
+			givenConfig: ConcurrencyLimitConfig{Max: 2},
+			expectErr:   "code=503, message=Service Unavailable",
+		},
+		{
+			name:        "nok, saturated limit rejects after max wait",
+			givenConfig: ConcurrencyLimitConfig{Max: 2, MaxWait: 20 * time.Millisecond},
+			expectErr:   "code=503, message=Service Unavailable",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			release := make(chan struct{})
+			started := make(chan struct{})
+
+			mw, err := tc.givenConfig.ToMiddleware()
+			assert.NoError(t, err)
+
+			h := mw(func(c echox.Context) error {
+				started <- struct{}{}
+				<-release
+				return c.String(http.StatusOK, "OK")
+			})
+
+			e := echox.New()
+			wg := sync.WaitGroup{}
+			for i := 0; i < tc.givenConfig.Max; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+					assert.NoError(t, h(c))
+				}()
+				<-started
+			}
+
+			for i := 0; i < 3; i++ {
+				c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+				assert.EqualError(t, h(c), tc.expectErr)
+			}
+
+			close(release)
+			wg.Wait()
+
+			// slots are freed after in-flight requests finish
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+			go func() { <-started }()
+			assert.NoError(t, h(c))
+			assert.Equal(t, http.StatusOK, rec.Code)
+		})
+	}
+}
+
+func TestConcurrencyLimit_waitsForSlot(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+
+	h := ConcurrencyLimitWithConfig(ConcurrencyLimitConfig{Max: 1, MaxWait: time.Second})(func(c echox.Context) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	})
+
+	e := echox.New()
+	errs := make(chan error, 1)
+	go func() {
+		errs <- h(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder()))
+	}()
+	<-started
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+	}()
+
+	assert.NoError(t, h(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())))
+	assert.NoError(t, <-errs)
+}
+
+func TestConcurrencyLimit_contextCancelledWhileWaiting(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+
+	h := ConcurrencyLimitWithConfig(ConcurrencyLimitConfig{Max: 1, MaxWait: time.Minute})(func(c echox.Context) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	})
+
+	e := echox.New()
+	go h(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())) // nolint: errcheck
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+
+	assert.EqualError(t, h(e.NewContext(req, httptest.NewRecorder())), "code=503, message=Service Unavailable")
+}
+
+func TestConcurrencyLimit_releasesSlotOnPanic(t *testing.T) {
+	var inFlight []int
+	var mu sync.Mutex
+
+	e := echox.New()
+	e.Use(Recover())
+	e.Use(ConcurrencyLimitWithConfig(ConcurrencyLimitConfig{
+		Max: 1,
+		InFlightFunc: func(n int) {
+			mu.Lock()
+			inFlight = append(inFlight, n)
+			mu.Unlock()
+		},
+	}))
+	e.GET("/panic", func(c echox.Context) error {
+		panic("boom")
+	})
+	e.GET("/ok", func(c echox.Context) error {
+		return c.String(http.StatusOK, "OK")
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ok", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	assert.Equal(t, []int{1, 0, 1, 0}, inFlight)
+}
+
+func TestConcurrencyLimitWithConfig_invalidConfig(t *testing.T) {
+	_, err := ConcurrencyLimitConfig{}.ToMiddleware()
+	assert.EqualError(t, err, "echo concurrency limit middleware max must be greater than zero")
+
+	_, err = ConcurrencyLimitConfig{Max: 1, MaxWait: -1}.ToMiddleware()
+	assert.EqualError(t, err, "echo concurrency limit middleware max wait can not be negative")
+}
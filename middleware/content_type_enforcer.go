@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/theopenlane/echox"
+)
+
+// ContentTypeEnforcerConfig defines the config for ContentTypeEnforcer middleware.
+type ContentTypeEnforcerConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// Strict instructs middleware to return an error when response `Content-Type` does not match media types route
+	// declares with `Route.Produces`. When false the mismatch is only logged with `Echo.Logger`.
+	// Optional. Default value false.
+	Strict bool
+}
+
+// ContentTypeEnforcer returns a ContentTypeEnforcer middleware that logs responses with `Content-Type` not matching
+// media types declared by route with `Route.Produces`.
+func ContentTypeEnforcer() echox.MiddlewareFunc {
+	return ContentTypeEnforcerWithConfig(ContentTypeEnforcerConfig{})
+}
+
+// ContentTypeEnforcerWithConfig returns a ContentTypeEnforcer middleware with config.
+//
+// Middleware checks `Content-Type` of successful (2xx, except 204) responses against media types matched route declares
+// with `Route.Produces`. Routes without declared media types are not checked. As response is already committed when
+// the mismatch is detected this middleware is meant to catch handler bugs during development and in tests.
+func ContentTypeEnforcerWithConfig(config ContentTypeEnforcerConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts ContentTypeEnforcerConfig to middleware or returns an error for invalid configuration
+func (config ContentTypeEnforcerConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if config.Skipper == nil {
+		config.Skipper = DefaultSkipper
+	}
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			ri := c.RouteInfo()
+
+			pri, ok := ri.(echox.ProducesRouteInfo)
+			if !ok {
+				return next(c)
+			}
+
+			produces := pri.Produces()
+			if len(produces) == 0 {
+				return next(c)
+			}
+
+			var mismatchErr error
+
+			res := c.Response()
+			res.Before(func() {
+				if res.Status < http.StatusOK || res.Status >= http.StatusMultipleChoices || res.Status == http.StatusNoContent {
+					return
+				}
+
+				contentType := res.Header().Get(echox.HeaderContentType)
+				if !matchesMediaType(contentType, produces) {
+					mismatchErr = fmt.Errorf("response content type %q does not match media types %v declared by route %s %s",
+						contentType, produces, ri.Method(), ri.Path())
+				}
+			})
+
+			err := next(c)
+
+			if mismatchErr != nil {
+				if config.Strict {
+					return echox.ErrInternalServerError.WithInternal(mismatchErr)
+				}
+
+				c.Echo().Logger.Error(mismatchErr)
+			}
+
+			return err
+		}
+	}, nil
+}
+
+func matchesMediaType(contentType string, produces []string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, p := range produces {
+		if pt, _, err := mime.ParseMediaType(p); err == nil && pt == mediaType {
+			return true
+		}
+	}
+
+	return false
+}
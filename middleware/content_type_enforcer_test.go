@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
+)
+
+func TestContentTypeEnforcerWithConfig(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		givenConfig ContentTypeEnforcerConfig
+		whenRoute   echox.Route
+		expectErr   string
+		expectLog   string
+	}{
+		{
+			name:        "ok, route declaring JSON returns JSON",
+			givenConfig: ContentTypeEnforcerConfig{Strict: true},
+			whenRoute: echox.Route{
+				Produces: []string{echox.MIMEApplicationJSON},
+				Handler: func(c echox.Context) error {
+					return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+				},
+			},
+		},
+		{
+			name:        "ok, route without declared media types is not checked",
+			givenConfig: ContentTypeEnforcerConfig{Strict: true},
+			whenRoute: echox.Route{
+				Handler: func(c echox.Context) error {
+					return c.String(http.StatusOK, "ok")
+				},
+			},
+		},
+		{
+			name:        "ok, error responses are not checked",
+			givenConfig: ContentTypeEnforcerConfig{Strict: true},
+			whenRoute: echox.Route{
+				Produces: []string{echox.MIMEApplicationXML},
+				Handler: func(c echox.Context) error {
+					return c.String(http.StatusBadRequest, "bad request")
+				},
+			},
+		},
+		{
+			name:        "nok, route declaring JSON returns text in strict mode",
+			givenConfig: ContentTypeEnforcerConfig{Strict: true},
+			whenRoute: echox.Route{
+				Produces: []string{echox.MIMEApplicationJSON},
+				Handler: func(c echox.Context) error {
+					return c.String(http.StatusOK, "ok")
+				},
+			},
+			expectErr: `code=500, message=Internal Server Error, internal=response content type "text/plain; charset=UTF-8" does not match media types [application/json] declared by route GET /test`,
+		},
+		{
+			name: "ok, route declaring JSON returns text is logged in non-strict mode",
+			whenRoute: echox.Route{
+				Produces: []string{echox.MIMEApplicationJSON},
+				Handler: func(c echox.Context) error {
+					return c.String(http.StatusOK, "ok")
+				},
+			},
+			expectLog: `response content type "text/plain; charset=UTF-8" does not match media types [application/json] declared by route GET /test`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			buf := new(bytes.Buffer)
+			e.Logger = &testLogger{output: buf}
+
+			var err error
+			e.Use(func(next echox.HandlerFunc) echox.HandlerFunc {
+				return func(c echox.Context) error {
+					err = next(c)
+					return err
+				}
+			})
+			e.Use(ContentTypeEnforcerWithConfig(tc.givenConfig))
+
+			route := tc.whenRoute
+			route.Method = http.MethodGet
+			route.Path = "/test"
+			_, rErr := e.AddRoute(route)
+			assert.NoError(t, rErr)
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tc.expectLog != "" {
+				assert.Contains(t, buf.String(), tc.expectLog)
+			} else {
+				assert.NotContains(t, buf.String(), "does not match media types")
+			}
+		})
+	}
+}
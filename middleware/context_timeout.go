@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 	"errors"
+	"strconv"
 	"time"
 
 	"github.com/theopenlane/echox"
@@ -18,6 +19,17 @@ type ContextTimeoutConfig struct {
 
 	// Timeout configures a timeout for the middleware
 	Timeout time.Duration
+
+	// TimeoutFunc returns timeout for the current request, overriding static Timeout. Returning 0 disables the timeout
+	// for that request. Useful to drive timeouts from route information (i.e. `c.RouteInfo()`, `c.Path()`).
+	// Optional. When set, Timeout can be left 0.
+	TimeoutFunc func(c echox.Context) time.Duration
+
+	// SetResponseHeader instructs middleware to add `Server-Timing: total;dur=<milliseconds>` header with time elapsed
+	// since middleware started to the response. Header is added just before the response is committed so it is present
+	// also on error responses (i.e. when deadline was exceeded).
+	// Optional. Default value false.
+	SetResponseHeader bool
 }
 
 // ContextTimeout returns a middleware which returns error (503 Service Unavailable error) to client
@@ -33,7 +45,7 @@ func ContextTimeoutWithConfig(config ContextTimeoutConfig) echox.MiddlewareFunc
 
 // ToMiddleware converts Config to middleware.
 func (config ContextTimeoutConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
-	if config.Timeout == 0 {
+	if config.Timeout == 0 && config.TimeoutFunc == nil {
 		return nil, errors.New("timeout must be set")
 	}
 
@@ -57,7 +69,24 @@ func (config ContextTimeoutConfig) ToMiddleware() (echox.MiddlewareFunc, error)
 				return next(c)
 			}
 
-			timeoutContext, cancel := context.WithTimeout(c.Request().Context(), config.Timeout)
+			if config.SetResponseHeader {
+				start := time.Now()
+				res := c.Response()
+				res.Before(func() {
+					res.Header().Add(echox.HeaderServerTiming, "total;dur="+strconv.FormatFloat(float64(time.Since(start))/float64(time.Millisecond), 'f', 3, 64))
+				})
+			}
+
+			timeout := config.Timeout
+			if config.TimeoutFunc != nil {
+				timeout = config.TimeoutFunc(c)
+			}
+
+			if timeout <= 0 {
+				return next(c)
+			}
+
+			timeoutContext, cancel := context.WithTimeout(c.Request().Context(), timeout)
 			defer cancel()
 
 			c.SetRequest(c.Request().WithContext(timeoutContext))
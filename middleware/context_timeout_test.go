@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -219,6 +220,151 @@ func TestContextTimeoutCanHandleContextDeadlineOnNextHandler(t *testing.T) {
 	assert.Equal(t, "Timeout! change me", err.(*echox.HTTPError).Message)
 }
 
+func TestContextTimeoutWithTimeoutFunc(t *testing.T) {
+	t.Parallel()
+
+	e := echox.New()
+	e.Use(ContextTimeoutWithConfig(ContextTimeoutConfig{
+		TimeoutFunc: func(c echox.Context) time.Duration {
+			switch c.Path() {
+			case "/search":
+				return 30 * time.Second
+			case "/stream":
+				return 0
+			}
+
+			return 2 * time.Second
+		},
+	}))
+
+	handler := func(c echox.Context) error {
+		deadline, ok := c.Request().Context().Deadline()
+		if !ok {
+			return c.String(http.StatusOK, "no deadline")
+		}
+
+		return c.String(http.StatusOK, time.Until(deadline).Round(time.Second).String())
+	}
+	e.GET("/search", handler)
+	e.GET("/stream", handler)
+	e.GET("/users", handler)
+
+	var testCases = []struct {
+		whenURL    string
+		expectBody string
+	}{
+		{whenURL: "/search", expectBody: "30s"},
+		{whenURL: "/stream", expectBody: "no deadline"},
+		{whenURL: "/users", expectBody: "2s"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.whenURL, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.whenURL, nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
+
+func TestContextTimeoutWithTimeoutFuncOverridesTimeout(t *testing.T) {
+	t.Parallel()
+
+	m := ContextTimeoutWithConfig(ContextTimeoutConfig{
+		Timeout: 10 * time.Millisecond,
+		TimeoutFunc: func(c echox.Context) time.Duration {
+			return 0
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := echox.New().NewContext(req, rec)
+
+	err := m(func(c echox.Context) error {
+		if err := sleepWithContext(c.Request().Context(), 20*time.Millisecond); err != nil {
+			return err
+		}
+
+		return c.String(http.StatusOK, "Hello, World!")
+	})(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello, World!", rec.Body.String())
+}
+
+func TestContextTimeoutSetResponseHeader(t *testing.T) {
+	t.Parallel()
+
+	var testCases = []struct {
+		name         string
+		whenSleep    time.Duration
+		expectStatus int
+		expectMinDur float64
+	}{
+		{
+			name:         "ok, header is set on successful response",
+			whenSleep:    0,
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "ok, header is set on deadline exceeded error response",
+			whenSleep:    100 * time.Millisecond,
+			expectStatus: http.StatusServiceUnavailable,
+			expectMinDur: 20,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			e.Use(ContextTimeoutWithConfig(ContextTimeoutConfig{
+				Timeout:           20 * time.Millisecond,
+				SetResponseHeader: true,
+			}))
+			e.GET("/", func(c echox.Context) error {
+				if err := sleepWithContext(c.Request().Context(), tc.whenSleep); err != nil {
+					return err
+				}
+
+				return c.String(http.StatusOK, "Hello, World!")
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectStatus, rec.Code)
+
+			header := rec.Header().Get(echox.HeaderServerTiming)
+			if assert.True(t, strings.HasPrefix(header, "total;dur="), header) {
+				dur, err := strconv.ParseFloat(strings.TrimPrefix(header, "total;dur="), 64)
+				assert.NoError(t, err)
+				assert.GreaterOrEqual(t, dur, tc.expectMinDur)
+			}
+		})
+	}
+}
+
+func TestContextTimeoutSetResponseHeaderDisabledByDefault(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := echox.New().NewContext(req, rec)
+
+	err := ContextTimeout(time.Second)(func(c echox.Context) error {
+		return c.String(http.StatusOK, "Hello, World!")
+	})(c)
+
+	assert.NoError(t, err)
+	assert.Empty(t, rec.Header().Get(echox.HeaderServerTiming))
+}
+
 func sleepWithContext(ctx context.Context, d time.Duration) error {
 	timer := time.NewTimer(d)
 
@@ -40,6 +40,32 @@ type CORSConfig struct {
 	// Optional.
 	AllowOriginFunc func(origin string) (bool, error)
 
+	// SubdomainWildcardSingleLabel makes `*` wildcard in AllowOrigins and MethodOrigins match exactly one domain label
+	// so `https://*.example.com` allows `https://a.example.com` but not `https://a.b.example.com`. By default wildcard
+	// matches any number of labels.
+	//
+	// Optional. Default value false.
+	SubdomainWildcardSingleLabel bool
+
+	// MethodOrigins overrides AllowOrigins for specific HTTP methods (i.e. allow any origin for `GET` but only
+	// first-party origins for `POST`). Method is taken from request for simple requests and from
+	// `Access-Control-Request-Method` header for preflight requests. Methods not in the map use AllowOrigins.
+	// Origins support same wildcards as AllowOrigins. Ignored when AllowOriginFunc or AllowOriginVaryFunc is set.
+	//
+	// Optional.
+	MethodOrigins map[string][]string
+
+	// AllowOriginVaryFunc is a custom function to validate the origin and decide per origin whether credentials are
+	// allowed and which headers are exposed. When set, it supersedes AllowOriginFunc, AllowOrigins, AllowCredentials
+	// and ExposeHeaders. If an error is returned, it is returned by the handler (same as with AllowOriginFunc).
+	//
+	// Security: use extreme caution when handling the origin, and carefully
+	// validate any logic. Remember that attackers may register hostile domain names.
+	// See https://blog.portswigger.net/2016/10/exploiting-cors-misconfigurations-for.html
+	//
+	// Optional.
+	AllowOriginVaryFunc func(origin string) (CORSOriginResult, error)
+
 	// AllowMethods determines the value of the Access-Control-Allow-Methods
 	// response header.  This header specified the list of methods allowed when
 	// accessing the resource.  This is used in response to a preflight request.
@@ -103,6 +129,24 @@ type CORSConfig struct {
 	//
 	// See also: https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Access-Control-Max-Age
 	MaxAge int
+
+	// PreflightContinue passes allowed preflight requests to the next handler after CORS response headers are set,
+	// instead of responding with 204 (No Content) from the middleware. Use it when OPTIONS route handler (or router
+	// default OPTIONS handler) should write the response. Preflight requests from disallowed origins are still
+	// answered by the middleware.
+	//
+	// Optional. Default value false.
+	PreflightContinue bool
+}
+
+// CORSOriginResult is the result of CORSConfig.AllowOriginVaryFunc for a specific origin.
+type CORSOriginResult struct {
+	// Allow is true when the origin is allowed.
+	Allow bool
+	// AllowCredentials determines if `Access-Control-Allow-Credentials: true` header is sent for the origin.
+	AllowCredentials bool
+	// ExposeHeaders determines the value of `Access-Control-Expose-Headers` header sent for the origin.
+	ExposeHeaders []string
 }
 
 // DefaultCORSConfig is the default CORS middleware config.
@@ -150,14 +194,11 @@ func (config CORSConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 		config.AllowMethods = DefaultCORSConfig.AllowMethods
 	}
 
-	allowOriginPatterns := []string{}
+	defaultOrigins := newCORSOrigins(config.AllowOrigins, config.SubdomainWildcardSingleLabel)
 
-	for _, origin := range config.AllowOrigins {
-		pattern := regexp.QuoteMeta(origin)
-		pattern = strings.ReplaceAll(pattern, "\\*", ".*")
-		pattern = strings.ReplaceAll(pattern, "\\?", ".")
-		pattern = "^" + pattern + "$"
-		allowOriginPatterns = append(allowOriginPatterns, pattern)
+	methodOrigins := make(map[string]corsOrigins, len(config.MethodOrigins))
+	for method, origins := range config.MethodOrigins {
+		methodOrigins[strings.ToUpper(method)] = newCORSOrigins(origins, config.SubdomainWildcardSingleLabel)
 	}
 
 	allowMethods := strings.Join(config.AllowMethods, ",")
@@ -207,7 +248,22 @@ func (config CORSConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 				return c.NoContent(http.StatusNoContent)
 			}
 
-			if config.AllowOriginFunc != nil {
+			allowCredentials := config.AllowCredentials
+			originExposeHeaders := exposeHeaders
+
+			if config.AllowOriginVaryFunc != nil {
+				result, err := config.AllowOriginVaryFunc(origin)
+				if err != nil {
+					return err
+				}
+
+				if result.Allow {
+					allowOrigin = origin
+				}
+
+				allowCredentials = result.AllowCredentials
+				originExposeHeaders = strings.Join(result.ExposeHeaders, ",")
+			} else if config.AllowOriginFunc != nil {
 				allowed, err := config.AllowOriginFunc(origin)
 				if err != nil {
 					return err
@@ -217,8 +273,18 @@ func (config CORSConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 					allowOrigin = origin
 				}
 			} else {
+				method := req.Method
+				if preflight {
+					method = req.Header.Get(echox.HeaderAccessControlRequestMethod)
+				}
+
+				allowed := defaultOrigins
+				if mo, ok := methodOrigins[strings.ToUpper(method)]; ok {
+					allowed = mo
+				}
+
 				// Check allowed origins
-				for _, o := range config.AllowOrigins {
+				for _, o := range allowed.origins {
 					if o == "*" && config.AllowCredentials && config.UnsafeWildcardOriginWithAllowCredentials {
 						allowOrigin = origin
 						break
@@ -229,7 +295,7 @@ func (config CORSConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 						break
 					}
 
-					if matchSubdomain(origin, o) {
+					if matchSubdomainLabels(origin, o, config.SubdomainWildcardSingleLabel) {
 						allowOrigin = origin
 						break
 					}
@@ -245,7 +311,7 @@ func (config CORSConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 				}
 
 				if checkPatterns {
-					for _, re := range allowOriginPatterns {
+					for _, re := range allowed.patterns {
 						if match, _ := regexp.MatchString(re, origin); match {
 							allowOrigin = origin
 							break
@@ -266,14 +332,14 @@ func (config CORSConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 
 			res.Header().Set(echox.HeaderAccessControlAllowOrigin, allowOrigin)
 
-			if config.AllowCredentials {
+			if allowCredentials {
 				res.Header().Set(echox.HeaderAccessControlAllowCredentials, "true")
 			}
 
 			// Simple request
 			if !preflight {
-				if exposeHeaders != "" {
-					res.Header().Set(echox.HeaderAccessControlExposeHeaders, exposeHeaders)
+				if originExposeHeaders != "" {
+					res.Header().Set(echox.HeaderAccessControlExposeHeaders, originExposeHeaders)
 				}
 
 				return next(c)
@@ -302,7 +368,38 @@ func (config CORSConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 				res.Header().Set(echox.HeaderAccessControlMaxAge, maxAge)
 			}
 
+			if config.PreflightContinue {
+				return next(c)
+			}
+
 			return c.NoContent(http.StatusNoContent)
 		}
 	}, nil
 }
+
+// corsOrigins holds allowed origins and their wildcard patterns converted to regular expressions
+type corsOrigins struct {
+	origins  []string
+	patterns []string
+}
+
+// newCORSOrigins converts origins to regular expression patterns. With singleLabel `*` wildcard does not match dots
+// so it can not span multiple domain labels.
+func newCORSOrigins(origins []string, singleLabel bool) corsOrigins {
+	patterns := make([]string, 0, len(origins))
+
+	wildcard := ".*"
+	if singleLabel {
+		wildcard = "[^.]*"
+	}
+
+	for _, origin := range origins {
+		pattern := regexp.QuoteMeta(origin)
+		pattern = strings.ReplaceAll(pattern, "\\*", wildcard)
+		pattern = strings.ReplaceAll(pattern, "\\?", ".")
+		pattern = "^" + pattern + "$"
+		patterns = append(patterns, pattern)
+	}
+
+	return corsOrigins{origins: origins, patterns: patterns}
+}
@@ -344,6 +344,79 @@ func Test_allowOriginSubdomain(t *testing.T) {
 	}
 }
 
+func Test_allowOriginSubdomainSingleLabel(t *testing.T) {
+	tests := []struct {
+		name, domain, pattern string
+		expected              bool
+	}{
+		{
+			name:     "one label matches",
+			domain:   "http://aaa.example.com",
+			pattern:  "http://*.example.com",
+			expected: true,
+		},
+		{
+			name:     "two labels do not match",
+			domain:   "http://bbb.aaa.example.com",
+			pattern:  "http://*.example.com",
+			expected: false,
+		},
+		{
+			name:     "one label with nested pattern matches",
+			domain:   "http://bbb.aaa.example.com",
+			pattern:  "http://*.aaa.example.com",
+			expected: true,
+		},
+		{
+			name:     "one label with port matches",
+			domain:   "http://aaa.example.com:8080",
+			pattern:  "http://*.example.com:8080",
+			expected: true,
+		},
+		{
+			name:     "partial label wildcard matches one label",
+			domain:   "https://prod-preview--aaa.bbb.com",
+			pattern:  "https://*--aaa.bbb.com",
+			expected: true,
+		},
+		{
+			name:     "partial label wildcard does not match two labels",
+			domain:   "https://x.prod-preview--aaa.bbb.com",
+			pattern:  "https://*--aaa.bbb.com",
+			expected: false,
+		},
+		{
+			name:     "apex domain does not match",
+			domain:   "http://example.com",
+			pattern:  "http://*.example.com",
+			expected: false,
+		},
+	}
+
+	e := echox.New()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			req.Header.Set(echox.HeaderOrigin, tt.domain)
+			cors := CORSWithConfig(CORSConfig{
+				AllowOrigins:                 []string{tt.pattern},
+				SubdomainWildcardSingleLabel: true,
+			})
+			h := cors(func(c echox.Context) error { return echox.ErrNotFound })
+			h(c)
+
+			if tt.expected {
+				assert.Equal(t, tt.domain, rec.Header().Get(echox.HeaderAccessControlAllowOrigin))
+			} else {
+				assert.NotContains(t, rec.Header(), echox.HeaderAccessControlAllowOrigin)
+			}
+		})
+	}
+}
+
 func TestCORSWithConfig_AllowMethods(t *testing.T) {
 	var testCases = []struct {
 		name            string
@@ -630,3 +703,225 @@ func Test_allowOriginFunc(t *testing.T) {
 		}
 	}
 }
+
+func Test_allowOriginVaryFunc(t *testing.T) {
+	partners := map[string]CORSOriginResult{
+		"https://trusted.example.com": {Allow: true, AllowCredentials: true, ExposeHeaders: []string{"X-Total-Count", "X-Request-Id"}},
+		"https://public.example.com":  {Allow: true},
+	}
+	allowOriginVaryFunc := func(origin string) (CORSOriginResult, error) {
+		if origin == "https://broken.example.com" {
+			return CORSOriginResult{}, errors.New("this is a test error")
+		}
+
+		return partners[origin], nil
+	}
+
+	var testCases = []struct {
+		name                string
+		whenOrigin          string
+		expectAllowOrigin   string
+		expectAllowCreds    string
+		expectExposeHeaders string
+		expectErr           string
+	}{
+		{
+			name:                "ok, origin allowed with credentials and exposed headers",
+			whenOrigin:          "https://trusted.example.com",
+			expectAllowOrigin:   "https://trusted.example.com",
+			expectAllowCreds:    "true",
+			expectExposeHeaders: "X-Total-Count,X-Request-Id",
+		},
+		{
+			name:              "ok, origin allowed without credentials",
+			whenOrigin:        "https://public.example.com",
+			expectAllowOrigin: "https://public.example.com",
+		},
+		{
+			name:       "nok, origin not allowed",
+			whenOrigin: "https://evil.example.com",
+			expectErr:  "code=401, message=Unauthorized",
+		},
+		{
+			name:       "nok, error is returned",
+			whenOrigin: "https://broken.example.com",
+			expectErr:  "this is a test error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(echox.HeaderOrigin, tc.whenOrigin)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			cors, err := CORSConfig{
+				AllowOriginVaryFunc: allowOriginVaryFunc,
+				// superseded by AllowOriginVaryFunc
+				AllowOriginFunc:  func(origin string) (bool, error) { return true, nil },
+				AllowCredentials: true,
+				ExposeHeaders:    []string{"X-Ignored"},
+			}.ToMiddleware()
+			assert.NoError(t, err)
+
+			err = cors(func(c echox.Context) error {
+				return c.String(http.StatusOK, "OK")
+			})(c)
+
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expectAllowOrigin, rec.Header().Get(echox.HeaderAccessControlAllowOrigin))
+			assert.Equal(t, tc.expectAllowCreds, rec.Header().Get(echox.HeaderAccessControlAllowCredentials))
+			assert.Equal(t, tc.expectExposeHeaders, rec.Header().Get(echox.HeaderAccessControlExposeHeaders))
+		})
+	}
+}
+
+func TestCORSWithConfig_MethodOrigins(t *testing.T) {
+	var testCases = []struct {
+		name              string
+		whenMethod        string
+		whenRequestMethod string
+		whenOrigin        string
+		expectAllowOrigin string
+		expectErr         string
+	}{
+		{
+			name:              "ok, GET from partner origin",
+			whenMethod:        http.MethodGet,
+			whenOrigin:        "https://partner.com",
+			expectAllowOrigin: "*",
+		},
+		{
+			name:       "nok, POST from partner origin",
+			whenMethod: http.MethodPost,
+			whenOrigin: "https://partner.com",
+			expectErr:  "code=401, message=Unauthorized",
+		},
+		{
+			name:              "ok, POST from first-party origin",
+			whenMethod:        http.MethodPost,
+			whenOrigin:        "https://app.example.com",
+			expectAllowOrigin: "https://app.example.com",
+		},
+		{
+			name:              "ok, preflight for POST from first-party origin",
+			whenMethod:        http.MethodOptions,
+			whenRequestMethod: http.MethodPost,
+			whenOrigin:        "https://app.example.com",
+			expectAllowOrigin: "https://app.example.com",
+		},
+		{
+			name:              "nok, preflight for POST from partner origin",
+			whenMethod:        http.MethodOptions,
+			whenRequestMethod: http.MethodPost,
+			whenOrigin:        "https://partner.com",
+			expectAllowOrigin: "",
+		},
+		{
+			name:              "ok, preflight for GET from partner origin",
+			whenMethod:        http.MethodOptions,
+			whenRequestMethod: http.MethodGet,
+			whenOrigin:        "https://partner.com",
+			expectAllowOrigin: "*",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			mw := CORSWithConfig(CORSConfig{
+				AllowOrigins: []string{"*"},
+				MethodOrigins: map[string][]string{
+					"post": {"https://*.example.com"},
+				},
+			})
+
+			req := httptest.NewRequest(tc.whenMethod, "/", nil)
+			req.Header.Set(echox.HeaderOrigin, tc.whenOrigin)
+			if tc.whenRequestMethod != "" {
+				req.Header.Set(echox.HeaderAccessControlRequestMethod, tc.whenRequestMethod)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := mw(func(c echox.Context) error {
+				return c.String(http.StatusOK, "OK")
+			})(c)
+
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectAllowOrigin, rec.Header().Get(echox.HeaderAccessControlAllowOrigin))
+		})
+	}
+}
+
+func TestCORSWithConfig_PreflightContinue(t *testing.T) {
+	var testCases = []struct {
+		name              string
+		givenContinue     bool
+		whenOrigin        string
+		expectStatus      int
+		expectBody        string
+		expectAllowOrigin string
+	}{
+		{
+			name:              "ok, preflight is passed to user defined OPTIONS route",
+			givenContinue:     true,
+			whenOrigin:        "https://example.com",
+			expectStatus:      http.StatusOK,
+			expectBody:        "custom options",
+			expectAllowOrigin: "https://example.com",
+		},
+		{
+			name:              "ok, without PreflightContinue middleware responds with 204",
+			whenOrigin:        "https://example.com",
+			expectStatus:      http.StatusNoContent,
+			expectAllowOrigin: "https://example.com",
+		},
+		{
+			name:          "ok, disallowed origin is answered by middleware",
+			givenContinue: true,
+			whenOrigin:    "https://evil.com",
+			expectStatus:  http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			e.Use(CORSWithConfig(CORSConfig{
+				AllowOrigins:      []string{"https://example.com"},
+				PreflightContinue: tc.givenContinue,
+			}))
+			e.OPTIONS("/users", func(c echox.Context) error {
+				return c.String(http.StatusOK, "custom options")
+			})
+			e.GET("/users", func(c echox.Context) error {
+				return c.String(http.StatusOK, "users")
+			})
+
+			req := httptest.NewRequest(http.MethodOptions, "/users", nil)
+			req.Header.Set(echox.HeaderOrigin, tc.whenOrigin)
+			req.Header.Set(echox.HeaderAccessControlRequestMethod, http.MethodGet)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectStatus, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+			assert.Equal(t, tc.expectAllowOrigin, rec.Header().Get(echox.HeaderAccessControlAllowOrigin))
+			if tc.expectAllowOrigin != "" {
+				assert.NotEmpty(t, rec.Header().Get(echox.HeaderAccessControlAllowMethods))
+			}
+		})
+	}
+}
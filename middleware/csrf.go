@@ -2,7 +2,10 @@ package middleware
 
 import (
 	"crypto/subtle"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/theopenlane/echox"
@@ -13,9 +16,10 @@ type CSRFConfig struct {
 	// Skipper defines a function to skip middleware.
 	Skipper Skipper
 
-	// TokenLength is the length of the generated token.
-	TokenLength uint8
+	// TokenLength is the length of the generated token. Must be at least MinCSRFTokenLength so tokens can not be
+	// guessed by brute force.
 	// Optional. Default value 32.
+	TokenLength uint8
 
 	// TokenLookup is a string in the form of "<source>:<name>" or "<source>:<name>,<source>:<name>" that is used
 	// to extract token from the request.
@@ -28,6 +32,14 @@ type CSRFConfig struct {
 	// - "header:X-CSRF-Token,query:csrf"
 	TokenLookup string `yaml:"token_lookup"`
 
+	// RequireAllSources changes how multiple TokenLookup sources are used. By default sources are tried in order and
+	// the first source with a matching token passes the check. With RequireAllSources every source must contain a
+	// matching token (i.e. "header:X-CSRF-Token,cookie:csrf_double"). Sources are checked in order and the first
+	// failing source decides the error: missing value results in "400 - Bad Request" with extraction error as internal
+	// and present but non-matching value results in ErrCSRFInvalid ("403 - Forbidden").
+	// Optional. Default value false.
+	RequireAllSources bool
+
 	// Generator defines a function to generate token.
 	// Optional. Defaults tp randomString(TokenLength).
 	Generator func() string
@@ -37,6 +49,8 @@ type CSRFConfig struct {
 	ContextKey string
 
 	// Name of the CSRF cookie. This cookie will store CSRF token.
+	// Names with `__Secure-` prefix require CookieSecure and names with `__Host-` prefix additionally require
+	// CookiePath "/" and no domain (see https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie#cookie_prefixes).
 	// Optional. Default value "csrf".
 	CookieName string
 
@@ -44,10 +58,20 @@ type CSRFConfig struct {
 	// Optional. Default value none.
 	CookieDomain string
 
+	// CookieDomainFunc returns domain of the CSRF cookie for the request (i.e. computed from request host in
+	// multi-tenant setups). When set, it supersedes CookieDomain. Empty result means that cookie has no domain.
+	// Optional.
+	CookieDomainFunc func(c echox.Context) string
+
 	// Path of the CSRF cookie.
 	// Optional. Default value none.
 	CookiePath string
 
+	// CookiePathFunc returns path of the CSRF cookie for the request. When set, it supersedes CookiePath. Empty
+	// result means that cookie has no path.
+	// Optional.
+	CookiePathFunc func(c echox.Context) string
+
 	// Max age (in seconds) of the CSRF cookie.
 	// Optional. Default value 86400 (24hr).
 	CookieMaxAge int
@@ -64,6 +88,15 @@ type CSRFConfig struct {
 	// Optional. Default value SameSiteDefaultMode.
 	CookieSameSite http.SameSite
 
+	// RotateOnEachRequest issues a new token (and cookie) on every safe method (GET, HEAD, OPTIONS, TRACE) request.
+	// The token it replaces is kept in `<CookieName>_prev` cookie and is still accepted on following unsafe requests,
+	// so form rendered before the rotation can be submitted.
+	// Tradeoff: only one previous token is kept. When page is rendered and then two or more safe requests are made
+	// before the form is submitted (i.e. other tabs, prefetching, XHR polling), the rendered token is no longer
+	// accepted and the submission fails with ErrCSRFInvalid.
+	// Optional. Default value false.
+	RotateOnEachRequest bool
+
 	// ErrorHandler defines a function which is executed for returning custom errors.
 	ErrorHandler func(c echox.Context, err error) error
 }
@@ -71,6 +104,24 @@ type CSRFConfig struct {
 // ErrCSRFInvalid is returned when CSRF check fails
 var ErrCSRFInvalid = echox.NewHTTPError(http.StatusForbidden, "invalid csrf token")
 
+// CSRFTokenState tells how CSRF middleware obtained the token of the current request.
+type CSRFTokenState string
+
+const (
+	// CSRFTokenGenerated means that request had no CSRF cookie and new token was generated.
+	CSRFTokenGenerated CSRFTokenState = "generated"
+	// CSRFTokenReused means that token from CSRF cookie was reused without validation because request method is safe.
+	CSRFTokenReused CSRFTokenState = "reused"
+	// CSRFTokenValidated means that token sent by client was validated against token from CSRF cookie.
+	CSRFTokenValidated CSRFTokenState = "validated"
+)
+
+// ContextKeyCSRFTokenState is context key CSRF middleware stores CSRFTokenState of the current request into.
+const ContextKeyCSRFTokenState = "csrf_token_state"
+
+// MinCSRFTokenLength is the minimum allowed CSRFConfig.TokenLength.
+const MinCSRFTokenLength = 16
+
 // DefaultCSRFConfig is the default CSRF middleware config.
 var DefaultCSRFConfig = CSRFConfig{
 	Skipper:        DefaultSkipper,
@@ -104,6 +155,10 @@ func (config CSRFConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 		config.TokenLength = DefaultCSRFConfig.TokenLength
 	}
 
+	if config.TokenLength < MinCSRFTokenLength {
+		return nil, fmt.Errorf("echo csrf middleware token length can not be less than %d", MinCSRFTokenLength)
+	}
+
 	if config.Generator == nil {
 		config.Generator = createRandomStringGenerator(config.TokenLength)
 	}
@@ -128,6 +183,10 @@ func (config CSRFConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 		config.CookieSecure = true
 	}
 
+	if err := validateCSRFCookiePrefix(config); err != nil {
+		return nil, err
+	}
+
 	extractors, cErr := createExtractors(config.TokenLookup)
 	if cErr != nil {
 		return nil, cErr
@@ -140,16 +199,47 @@ func (config CSRFConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 			}
 
 			token := ""
+			state := CSRFTokenReused
 			if k, err := c.Cookie(config.CookieName); err != nil {
 				token = config.Generator() // Generate token
+				state = CSRFTokenGenerated
 			} else {
 				token = k.Value // Reuse token
 			}
 
+			// tokens are all tokens that client token is accepted against
+			tokens := []string{token}
+			if config.RotateOnEachRequest {
+				if k, err := c.Cookie(config.CookieName + csrfPreviousCookieSuffix); err == nil && k.Value != "" {
+					tokens = append(tokens, k.Value)
+				}
+			}
+
+			previousToken := ""
+
 			switch c.Request().Method {
 			case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+				if config.RotateOnEachRequest && state == CSRFTokenReused {
+					previousToken = token
+					token = config.Generator()
+					state = CSRFTokenGenerated
+				}
 			default:
+				state = CSRFTokenValidated
+
 				// Validate token only for requests which are not defined as 'safe' by RFC7231
+				if config.RequireAllSources {
+					if err := validateCSRFTokenAllSources(c, extractors, tokens); err != nil {
+						if config.ErrorHandler != nil {
+							return config.ErrorHandler(c, err)
+						}
+
+						return err
+					}
+
+					break
+				}
+
 				var lastExtractorErr error
 
 				var lastTokenErr error
@@ -163,7 +253,7 @@ func (config CSRFConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 					}
 
 					for _, clientToken := range clientTokens {
-						if validateCSRFToken(token, clientToken) {
+						if validateCSRFTokens(tokens, clientToken) {
 							lastTokenErr = nil
 							lastExtractorErr = nil
 							break outer
@@ -189,29 +279,15 @@ func (config CSRFConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 			}
 
 			// Set CSRF cookie
-			cookie := new(http.Cookie)
-			cookie.Name = config.CookieName
-			cookie.Value = token
-
-			if config.CookiePath != "" {
-				cookie.Path = config.CookiePath
-			}
-
-			if config.CookieDomain != "" {
-				cookie.Domain = config.CookieDomain
-			}
+			c.SetCookie(newCSRFCookie(c, config, config.CookieName, token))
 
-			if config.CookieSameSite != http.SameSiteDefaultMode {
-				cookie.SameSite = config.CookieSameSite
+			if previousToken != "" {
+				c.SetCookie(newCSRFCookie(c, config, config.CookieName+csrfPreviousCookieSuffix, previousToken))
 			}
 
-			cookie.Expires = time.Now().Add(time.Duration(config.CookieMaxAge) * time.Second)
-			cookie.Secure = config.CookieSecure
-			cookie.HttpOnly = config.CookieHTTPOnly
-			c.SetCookie(cookie)
-
 			// Store token in the context
 			c.Set(config.ContextKey, token)
+			c.Set(ContextKeyCSRFTokenState, state)
 
 			// Protect clients from caching the response
 			c.Response().Header().Add(echox.HeaderVary, echox.HeaderCookie)
@@ -221,6 +297,99 @@ func (config CSRFConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 	}, nil
 }
 
+// csrfPreviousCookieSuffix is added to CSRFConfig.CookieName to get name of the cookie holding previous token
+// when CSRFConfig.RotateOnEachRequest is enabled.
+const csrfPreviousCookieSuffix = "_prev"
+
+// validateCSRFCookiePrefix checks that cookie attributes satisfy requirements of `__Secure-` and `__Host-` cookie
+// name prefixes. Browsers reject cookies with these prefixes when requirements are not met.
+func validateCSRFCookiePrefix(config CSRFConfig) error {
+	isHost := strings.HasPrefix(config.CookieName, "__Host-")
+	if !isHost && !strings.HasPrefix(config.CookieName, "__Secure-") {
+		return nil
+	}
+
+	if !config.CookieSecure {
+		return errors.New("echo csrf middleware cookie with __Secure- or __Host- prefix requires CookieSecure")
+	}
+
+	if !isHost {
+		return nil
+	}
+
+	if config.CookiePath != "/" || config.CookiePathFunc != nil {
+		return errors.New("echo csrf middleware cookie with __Host- prefix requires CookiePath to be /")
+	}
+
+	if config.CookieDomain != "" || config.CookieDomainFunc != nil {
+		return errors.New("echo csrf middleware cookie with __Host- prefix can not have domain")
+	}
+
+	return nil
+}
+
+func newCSRFCookie(c echox.Context, config CSRFConfig, name string, token string) *http.Cookie {
+	cookie := new(http.Cookie)
+	cookie.Name = name
+	cookie.Value = token
+
+	cookie.Path = config.CookiePath
+	if config.CookiePathFunc != nil {
+		cookie.Path = config.CookiePathFunc(c)
+	}
+
+	cookie.Domain = config.CookieDomain
+	if config.CookieDomainFunc != nil {
+		cookie.Domain = config.CookieDomainFunc(c)
+	}
+
+	if config.CookieSameSite != http.SameSiteDefaultMode {
+		cookie.SameSite = config.CookieSameSite
+	}
+
+	cookie.Expires = time.Now().Add(time.Duration(config.CookieMaxAge) * time.Second)
+	cookie.Secure = config.CookieSecure
+	cookie.HttpOnly = config.CookieHTTPOnly
+
+	return cookie
+}
+
+// validateCSRFTokenAllSources checks that every extractor yields a token matching one of the accepted tokens
+func validateCSRFTokenAllSources(c echox.Context, extractors []ValuesExtractor, tokens []string) error {
+	for _, extractor := range extractors {
+		clientTokens, _, err := extractor(c)
+		if err != nil {
+			return echox.ErrBadRequest.WithInternal(err)
+		}
+
+		matched := false
+
+		for _, clientToken := range clientTokens {
+			if validateCSRFTokens(tokens, clientToken) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return ErrCSRFInvalid
+		}
+	}
+
+	return nil
+}
+
 func validateCSRFToken(token, clientToken string) bool {
 	return subtle.ConstantTimeCompare([]byte(token), []byte(clientToken)) == 1
 }
+
+// validateCSRFTokens checks if client token matches any of the accepted tokens
+func validateCSRFTokens(tokens []string, clientToken string) bool {
+	for _, token := range tokens {
+		if validateCSRFToken(token, clientToken) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -225,6 +225,83 @@ func TestCSRF_tokenExtractors(t *testing.T) {
 	}
 }
 
+func TestCSRF_requireAllSources(t *testing.T) {
+	var testCases = []struct {
+		name         string
+		givenHeader  string
+		givenCookie  string
+		whenRequired bool
+		expectError  string
+	}{
+		{
+			name:         "ok, token in both sources",
+			givenHeader:  "token",
+			givenCookie:  "token",
+			whenRequired: true,
+		},
+		{
+			name:         "nok, token missing from cookie",
+			givenHeader:  "token",
+			whenRequired: true,
+			expectError:  "code=400, message=Bad Request, internal=missing value in cookies",
+		},
+		{
+			name:         "nok, token missing from header",
+			givenCookie:  "token",
+			whenRequired: true,
+			expectError:  "code=400, message=Bad Request, internal=missing value in request header",
+		},
+		{
+			name:         "nok, invalid token in cookie",
+			givenHeader:  "token",
+			givenCookie:  "invalid",
+			whenRequired: true,
+			expectError:  "code=403, message=invalid csrf token",
+		},
+		{
+			name:         "nok, invalid token in header is reported before missing cookie",
+			givenHeader:  "invalid",
+			whenRequired: true,
+			expectError:  "code=403, message=invalid csrf token",
+		},
+		{
+			name:        "ok, without RequireAllSources one source is enough",
+			givenHeader: "token",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tc.givenHeader != "" {
+				req.Header.Set(echox.HeaderXCSRFToken, tc.givenHeader)
+			}
+			req.AddCookie(&http.Cookie{Name: "_csrf", Value: "token"})
+			if tc.givenCookie != "" {
+				req.AddCookie(&http.Cookie{Name: "csrf_double", Value: tc.givenCookie})
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			csrf := CSRFWithConfig(CSRFConfig{
+				TokenLookup:       "header:" + echox.HeaderXCSRFToken + ",cookie:csrf_double",
+				RequireAllSources: tc.whenRequired,
+			})
+
+			err := csrf(func(c echox.Context) error {
+				return c.String(http.StatusOK, "test")
+			})(c)
+
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestCSRF(t *testing.T) {
 	e := echox.New()
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
@@ -298,6 +375,75 @@ func TestCSRFSetSameSiteMode(t *testing.T) {
 	assert.Regexp(t, "SameSite=Strict", rec.Header()["Set-Cookie"])
 }
 
+func TestCSRFConfig_CookieDomainAndPathFunc(t *testing.T) {
+	var testCases = []struct {
+		name         string
+		givenConfig  CSRFConfig
+		whenHost     string
+		expectCookie string
+		expectAbsent string
+	}{
+		{
+			name: "ok, static domain and path",
+			givenConfig: CSRFConfig{
+				CookieDomain: "example.com",
+				CookiePath:   "/app",
+			},
+			whenHost:     "acme.example.com",
+			expectCookie: "Path=/app; Domain=example.com;",
+		},
+		{
+			name: "ok, functions supersede static values",
+			givenConfig: CSRFConfig{
+				CookieDomain: "example.com",
+				CookiePath:   "/app",
+				CookieDomainFunc: func(c echox.Context) string {
+					return c.Request().Host
+				},
+				CookiePathFunc: func(c echox.Context) string {
+					return "/" + strings.Split(c.Request().Host, ".")[0]
+				},
+			},
+			whenHost:     "acme.example.com",
+			expectCookie: "Path=/acme; Domain=acme.example.com;",
+		},
+		{
+			name: "ok, empty function result omits domain",
+			givenConfig: CSRFConfig{
+				CookieDomain: "example.com",
+				CookieDomainFunc: func(c echox.Context) string {
+					return ""
+				},
+			},
+			whenHost:     "acme.example.com",
+			expectAbsent: "Domain=",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Host = tc.whenHost
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			h := CSRFWithConfig(tc.givenConfig)(func(c echox.Context) error {
+				return c.String(http.StatusOK, "test")
+			})
+
+			assert.NoError(t, h(c))
+			cookie := rec.Header().Get(echox.HeaderSetCookie)
+			if tc.expectCookie != "" {
+				assert.Contains(t, cookie, tc.expectCookie)
+			}
+			if tc.expectAbsent != "" {
+				assert.NotContains(t, cookie, tc.expectAbsent)
+			}
+		})
+	}
+}
+
 func TestCSRFWithoutSameSiteMode(t *testing.T) {
 	e := echox.New()
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
@@ -420,3 +566,227 @@ func TestCSRFErrorHandling(t *testing.T) {
 	assert.Equal(t, http.StatusTeapot, res.Code)
 	assert.Equal(t, "{\"message\":\"error_handler_executed\"}\n", res.Body.String())
 }
+
+func TestCSRFConfig_TokenLength(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		givenLength uint8
+		expectErr   string
+	}{
+		{
+			name:        "ok, zero defaults to 32",
+			givenLength: 0,
+		},
+		{
+			name:        "ok, minimum length",
+			givenLength: 16,
+		},
+		{
+			name:        "nok, too short",
+			givenLength: 15,
+			expectErr:   "echo csrf middleware token length can not be less than 16",
+		},
+		{
+			name:        "nok, single character",
+			givenLength: 1,
+			expectErr:   "echo csrf middleware token length can not be less than 16",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mw, err := CSRFConfig{TokenLength: tc.givenLength}.ToMiddleware()
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+				assert.Nil(t, mw)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, mw)
+		})
+	}
+}
+
+func TestCSRF_tokenStateInContext(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		whenMethod  string
+		whenCookie  string
+		whenHeader  string
+		expectState any
+		expectErr   string
+	}{
+		{
+			name:        "ok, token is generated without cookie",
+			whenMethod:  http.MethodGet,
+			expectState: CSRFTokenGenerated,
+		},
+		{
+			name:        "ok, token from cookie is reused for safe method",
+			whenMethod:  http.MethodGet,
+			whenCookie:  "_csrf=token_from_cookie",
+			expectState: CSRFTokenReused,
+		},
+		{
+			name:        "ok, token is validated for unsafe method",
+			whenMethod:  http.MethodPost,
+			whenCookie:  "_csrf=token_from_cookie",
+			whenHeader:  "token_from_cookie",
+			expectState: CSRFTokenValidated,
+		},
+		{
+			name:       "nok, invalid token does not set state",
+			whenMethod: http.MethodPost,
+			whenCookie: "_csrf=token_from_cookie",
+			whenHeader: "invalid",
+			expectErr:  "code=403, message=invalid csrf token",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			req := httptest.NewRequest(tc.whenMethod, "/", nil)
+			if tc.whenCookie != "" {
+				req.Header.Set(echox.HeaderCookie, tc.whenCookie)
+			}
+			if tc.whenHeader != "" {
+				req.Header.Set(echox.HeaderXCSRFToken, tc.whenHeader)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := CSRF()(func(c echox.Context) error {
+				return c.String(http.StatusOK, "test")
+			})(c)
+
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectState, c.Get(ContextKeyCSRFTokenState))
+		})
+	}
+}
+
+func TestCSRF_RotateOnEachRequest(t *testing.T) {
+	e := echox.New()
+	e.Use(CSRFWithConfig(CSRFConfig{RotateOnEachRequest: true}))
+	e.Any("/", func(c echox.Context) error {
+		return c.String(http.StatusOK, c.Get("csrf").(string))
+	})
+
+	cookies := map[string]string{}
+	serve := func(method string, clientToken string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/", nil)
+		for name, value := range cookies {
+			req.AddCookie(&http.Cookie{Name: name, Value: value})
+		}
+		if clientToken != "" {
+			req.Header.Set(echox.HeaderXCSRFToken, clientToken)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		for _, cookie := range rec.Result().Cookies() {
+			cookies[cookie.Name] = cookie.Value
+		}
+
+		return rec
+	}
+
+	// first GET generates token
+	rec := serve(http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	renderedToken := rec.Body.String()
+	assert.Equal(t, renderedToken, cookies["_csrf"])
+	assert.Empty(t, cookies["_csrf_prev"])
+
+	// second GET rotates token and keeps rendered one as previous
+	rec = serve(http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rotatedToken := rec.Body.String()
+	assert.NotEqual(t, renderedToken, rotatedToken)
+	assert.Equal(t, rotatedToken, cookies["_csrf"])
+	assert.Equal(t, renderedToken, cookies["_csrf_prev"])
+
+	// form rendered before rotation can still be submitted
+	rec = serve(http.MethodPost, renderedToken)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// and so can the current token
+	rec = serve(http.MethodPost, rotatedToken)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// after one more rotation the first token is not accepted anymore
+	rec = serve(http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve(http.MethodPost, renderedToken)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestCSRFConfig_cookiePrefix(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		givenConfig CSRFConfig
+		expectErr   string
+	}{
+		{
+			name:        "ok, __Host- cookie with required attributes",
+			givenConfig: CSRFConfig{CookieName: "__Host-csrf", CookieSecure: true, CookiePath: "/"},
+		},
+		{
+			name:        "nok, __Host- cookie with domain",
+			givenConfig: CSRFConfig{CookieName: "__Host-csrf", CookieSecure: true, CookiePath: "/", CookieDomain: "example.com"},
+			expectErr:   "echo csrf middleware cookie with __Host- prefix can not have domain",
+		},
+		{
+			name: "nok, __Host- cookie with domain func",
+			givenConfig: CSRFConfig{
+				CookieName:       "__Host-csrf",
+				CookieSecure:     true,
+				CookiePath:       "/",
+				CookieDomainFunc: func(c echox.Context) string { return "" },
+			},
+			expectErr: "echo csrf middleware cookie with __Host- prefix can not have domain",
+		},
+		{
+			name:        "nok, __Host- cookie without path",
+			givenConfig: CSRFConfig{CookieName: "__Host-csrf", CookieSecure: true},
+			expectErr:   "echo csrf middleware cookie with __Host- prefix requires CookiePath to be /",
+		},
+		{
+			name:        "nok, __Host- cookie without secure",
+			givenConfig: CSRFConfig{CookieName: "__Host-csrf", CookiePath: "/"},
+			expectErr:   "echo csrf middleware cookie with __Secure- or __Host- prefix requires CookieSecure",
+		},
+		{
+			name:        "ok, __Host- cookie is secure because of SameSite=None",
+			givenConfig: CSRFConfig{CookieName: "__Host-csrf", CookiePath: "/", CookieSameSite: http.SameSiteNoneMode},
+		},
+		{
+			name:        "ok, __Secure- cookie with domain",
+			givenConfig: CSRFConfig{CookieName: "__Secure-csrf", CookieSecure: true, CookieDomain: "example.com"},
+		},
+		{
+			name:        "nok, __Secure- cookie without secure",
+			givenConfig: CSRFConfig{CookieName: "__Secure-csrf"},
+			expectErr:   "echo csrf middleware cookie with __Secure- or __Host- prefix requires CookieSecure",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mw, err := tc.givenConfig.ToMiddleware()
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+				assert.Nil(t, mw)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, mw)
+		})
+	}
+}
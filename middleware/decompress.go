@@ -1,9 +1,12 @@
 package middleware
 
 import (
+	"bufio"
+	"bytes"
 	"compress/gzip"
 	"io"
 	"net/http"
+	"strings"
 	"sync"
 
 	"github.com/theopenlane/echox"
@@ -16,11 +19,26 @@ type DecompressConfig struct {
 
 	// GzipDecompressPool defines an interface to provide the sync.Pool used to create/store Gzip readers
 	GzipDecompressPool Decompressor
+
+	// OnDecompress is called after the handler has finished with request which body was decompressed without errors.
+	// Arguments are the content encoding, number of compressed bytes read from the request body and number of
+	// decompressed bytes read by the handler. Useful for exporting metrics.
+	// Optional. Default value nil, in which case bytes are not counted.
+	OnDecompress func(c echox.Context, encoding string, compressed, decompressed int64)
+
+	// Detector returns content encoding of request body that has no `Content-Encoding` header (i.e. clients that send
+	// compressed body without labeling it). Returned encoding is handled as if it was sent in the header, empty result
+	// leaves body as is. Detector may replace request body to peek into it (see SniffGzipEncoding).
+	// Optional. Default value nil (bodies without `Content-Encoding` header are not decompressed).
+	Detector func(c echox.Context) string
 }
 
 // GZIPEncoding content-encoding header if set to "gzip", decompress body contents.
 const GZIPEncoding string = "gzip"
 
+// IdentityEncoding content-encoding header value meaning body is not encoded. Body is passed through as is.
+const IdentityEncoding string = "identity"
+
 // Decompressor is used to get the sync.Pool used by the middleware to get Gzip readers
 type Decompressor interface {
 	gzipDecompressPool() sync.Pool
@@ -35,6 +53,10 @@ func (d *DefaultGzipDecompressPool) gzipDecompressPool() sync.Pool {
 }
 
 // Decompress decompresses request body based if content encoding type is set to "gzip" with default config
+//
+// Request body is not buffered. It is replaced with a gzip reader that decompresses data on demand while the handler
+// reads the body, so large uploads (i.e. multipart/form-data parsed with c.MultipartForm or c.Request().MultipartReader)
+// are streamed and never fully materialized in memory by the middleware.
 func Decompress() echox.MiddlewareFunc {
 	return DecompressWithConfig(DecompressConfig{})
 }
@@ -62,7 +84,16 @@ func (config DecompressConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 				return next(c)
 			}
 
-			if c.Request().Header.Get(echox.HeaderContentEncoding) != GZIPEncoding {
+			encoding := c.Request().Header.Get(echox.HeaderContentEncoding)
+			if encoding == "" && config.Detector != nil {
+				encoding = config.Detector(c)
+			}
+
+			if encoding == "" || strings.EqualFold(encoding, IdentityEncoding) {
+				return next(c) // body is not encoded
+			}
+
+			if encoding != GZIPEncoding {
 				return next(c)
 			}
 
@@ -78,7 +109,15 @@ func (config DecompressConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 			b := c.Request().Body
 			defer b.Close()
 
-			if err := gr.Reset(b); err != nil {
+			var src io.Reader = b
+
+			var compressed *countingReader
+			if config.OnDecompress != nil {
+				compressed = &countingReader{reader: b}
+				src = compressed
+			}
+
+			if err := gr.Reset(src); err != nil {
 				if err == io.EOF { // ignore if body is empty
 					return next(c)
 				}
@@ -89,9 +128,62 @@ func (config DecompressConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 			// only Close gzip reader if it was set to a proper gzip source otherwise it will panic on close.
 			defer gr.Close()
 
-			c.Request().Body = gr
+			if config.OnDecompress == nil {
+				c.Request().Body = gr
+
+				return next(c)
+			}
+
+			decompressed := &countingReader{reader: gr}
+			c.Request().Body = readCloser{Reader: decompressed, Closer: gr}
+
+			err := next(c)
+
+			if compressed.err == nil && decompressed.err == nil {
+				config.OnDecompress(c, GZIPEncoding, compressed.n, decompressed.n)
+			}
 
-			return next(c)
+			return err
 		}
 	}, nil
 }
+
+// gzipMagic are the first bytes of gzip stream (RFC 1952)
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// SniffGzipEncoding is DecompressConfig.Detector that returns GZIPEncoding when request body starts with gzip magic
+// bytes. Request body is replaced with a buffered reader so peeked bytes are not lost.
+func SniffGzipEncoding(c echox.Context) string {
+	req := c.Request()
+	if req.Body == nil || req.Body == http.NoBody {
+		return ""
+	}
+
+	br := bufio.NewReader(req.Body)
+	req.Body = readCloser{Reader: br, Closer: req.Body}
+
+	magic, _ := br.Peek(len(gzipMagic))
+	if bytes.Equal(magic, gzipMagic) {
+		return GZIPEncoding
+	}
+
+	return ""
+}
+
+// countingReader counts bytes read from the underlying reader and remembers the first read error other than io.EOF
+type countingReader struct {
+	reader io.Reader
+	n      int64
+	err    error
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.n += int64(n)
+
+	if err != nil && err != io.EOF && r.err == nil {
+		r.err = err
+	}
+
+	return n, err
+}
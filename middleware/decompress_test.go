@@ -5,6 +5,7 @@ import (
 	"compress/gzip"
 	"errors"
 	"io"
+	"math/rand"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -42,6 +43,74 @@ func TestDecompress(t *testing.T) {
 	assert.Equal(t, body, string(b))
 }
 
+func TestDecompressWithConfig_OnDecompress(t *testing.T) {
+	body := strings.Repeat(`{"name": "echo"}`, 100)
+	gz, err := gzipString(body)
+	assert.NoError(t, err)
+
+	var testCases = []struct {
+		name         string
+		whenBody     []byte
+		whenEncoding string
+		expectCalled bool
+	}{
+		{
+			name:         "ok, called after successful decode",
+			whenBody:     gz,
+			whenEncoding: GZIPEncoding,
+			expectCalled: true,
+		},
+		{
+			name:         "ok, not called without content encoding",
+			whenBody:     []byte(body),
+			whenEncoding: "",
+			expectCalled: false,
+		},
+		{
+			name:         "ok, not called when body is corrupted",
+			whenBody:     gz[:len(gz)/2],
+			whenEncoding: GZIPEncoding,
+			expectCalled: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tc.whenBody))
+			if tc.whenEncoding != "" {
+				req.Header.Set(echox.HeaderContentEncoding, tc.whenEncoding)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			called := false
+			var encoding string
+			var compressed, decompressed int64
+			mw := DecompressWithConfig(DecompressConfig{
+				OnDecompress: func(c echox.Context, enc string, compressedSize, decompressedSize int64) {
+					called = true
+					encoding = enc
+					compressed = compressedSize
+					decompressed = decompressedSize
+				},
+			})
+
+			_ = mw(func(c echox.Context) error {
+				_, err := io.ReadAll(c.Request().Body)
+				return err
+			})(c)
+
+			assert.Equal(t, tc.expectCalled, called)
+			if tc.expectCalled {
+				assert.Equal(t, GZIPEncoding, encoding)
+				assert.Equal(t, int64(len(gz)), compressed)
+				assert.Equal(t, int64(len(body)), decompressed)
+			}
+		})
+	}
+}
+
 func TestDecompress_skippedIfNoHeader(t *testing.T) {
 	e := echox.New()
 	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("test"))
@@ -59,6 +128,51 @@ func TestDecompress_skippedIfNoHeader(t *testing.T) {
 	assert.Equal(t, "test", rec.Body.String())
 }
 
+func TestDecompress_identityEncodingPassedThrough(t *testing.T) {
+	var testCases = []struct {
+		name         string
+		whenEncoding string
+	}{
+		{
+			name:         "ok, identity",
+			whenEncoding: IdentityEncoding,
+		},
+		{
+			name:         "ok, identity in upper case",
+			whenEncoding: "IDENTITY",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			body := `{"name": "echo"}`
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+			req.Header.Set(echox.HeaderContentEncoding, tc.whenEncoding)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			onDecompressCalled := false
+			h := DecompressWithConfig(DecompressConfig{
+				OnDecompress: func(c echox.Context, encoding string, compressed, decompressed int64) {
+					onDecompressCalled = true
+				},
+			})(func(c echox.Context) error {
+				b, err := io.ReadAll(c.Request().Body)
+				if err != nil {
+					return err
+				}
+				return c.String(http.StatusOK, string(b))
+			})
+
+			err := h(c)
+			assert.NoError(t, err)
+			assert.Equal(t, body, rec.Body.String())
+			assert.False(t, onDecompressCalled)
+		})
+	}
+}
+
 func TestDecompressWithConfig_DefaultConfig_noDecode(t *testing.T) {
 	e := echox.New()
 	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("test"))
@@ -257,3 +371,104 @@ func gzipString(body string) ([]byte, error) {
 
 	return buf.Bytes(), nil
 }
+
+func TestDecompress_streamsLargeBody(t *testing.T) {
+	// random data does not compress so compressed body is roughly as large as uncompressed one
+	raw := make([]byte, 4*MB)
+	rand.New(rand.NewSource(1)).Read(raw)
+
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	_, err := w.Write(raw)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+
+	compressedSize := int64(gz.Len())
+	consumed := &countingReader{reader: &gz}
+
+	e := echox.New()
+	req := httptest.NewRequest(http.MethodPost, "/", io.NopCloser(consumed))
+	req.Header.Set(echox.HeaderContentEncoding, GZIPEncoding)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	var consumedAfterFirstChunk int64
+	h := Decompress()(func(c echox.Context) error {
+		chunk := make([]byte, 32*1024)
+		if _, err := io.ReadFull(c.Request().Body, chunk); err != nil {
+			return err
+		}
+		assert.Equal(t, raw[:len(chunk)], chunk)
+		consumedAfterFirstChunk = consumed.n
+
+		rest, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, raw[len(chunk):], rest)
+
+		return nil
+	})
+
+	assert.NoError(t, h(c))
+	assert.Less(t, consumedAfterFirstChunk, int64(256*1024))
+	assert.Equal(t, compressedSize, consumed.n)
+}
+
+func TestDecompressWithConfig_Detector(t *testing.T) {
+	gz, err := gzipString(`{"name":"jon"}`)
+	assert.NoError(t, err)
+
+	var testCases = []struct {
+		name          string
+		givenDetector func(c echox.Context) string
+		whenBody      []byte
+		expectBody    string
+	}{
+		{
+			name:          "ok, gzipped body without header is decompressed",
+			givenDetector: SniffGzipEncoding,
+			whenBody:      gz,
+			expectBody:    `{"name":"jon"}`,
+		},
+		{
+			name:          "ok, plain body is passed through",
+			givenDetector: SniffGzipEncoding,
+			whenBody:      []byte(`{"name":"jon"}`),
+			expectBody:    `{"name":"jon"}`,
+		},
+		{
+			name:          "ok, body shorter than magic bytes is passed through",
+			givenDetector: SniffGzipEncoding,
+			whenBody:      []byte{0x1f},
+			expectBody:    string([]byte{0x1f}),
+		},
+		{
+			name:       "ok, without detector gzipped body is not decompressed",
+			whenBody:   gz,
+			expectBody: string(gz),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tc.whenBody))
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			mw, err := DecompressConfig{Detector: tc.givenDetector}.ToMiddleware()
+			assert.NoError(t, err)
+
+			body := ""
+			err = mw(func(c echox.Context) error {
+				b, err := io.ReadAll(c.Request().Body)
+				body = string(b)
+				return err
+			})(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectBody, body)
+		})
+	}
+}
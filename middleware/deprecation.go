@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/theopenlane/echox"
+)
+
+// DeprecationConfig defines the config for Deprecation middleware.
+type DeprecationConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// DeprecatedAt is the date when route was (or will be) deprecated. It is sent as HTTP-date in `Deprecation` header.
+	// Optional. Default value zero time, in which case `Deprecation: true` header is sent.
+	DeprecatedAt time.Time
+
+	// Sunset is the date after which route is expected to become unresponsive. It is sent as HTTP-date in `Sunset`
+	// header (RFC 8594).
+	// Optional. Default value zero time, in which case `Sunset` header is not sent.
+	Sunset time.Time
+
+	// Link is URL of documentation describing the deprecation (i.e. migration guide). It is sent in `Link` header with
+	// `rel="deprecation"` relation type.
+	// Optional. Default value "", in which case `Link` header is not sent.
+	Link string
+}
+
+// Deprecation returns a Deprecation middleware that marks responses of routes it is added to as deprecated with
+// `Deprecation: true` header.
+//
+// Example:
+//
+//	e.GET("/v1/users", listUsers, middleware.Deprecation())
+func Deprecation() echox.MiddlewareFunc {
+	return DeprecationWithConfig(DeprecationConfig{})
+}
+
+// DeprecationWithConfig returns a Deprecation middleware with config. Middleware sets `Deprecation`, `Sunset` and
+// `Link` response headers just before the response is committed so they are present also on error responses.
+func DeprecationWithConfig(config DeprecationConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts DeprecationConfig to middleware or returns an error for invalid configuration
+func (config DeprecationConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if config.Skipper == nil {
+		config.Skipper = DefaultSkipper
+	}
+
+	if !config.DeprecatedAt.IsZero() && !config.Sunset.IsZero() && config.Sunset.Before(config.DeprecatedAt) {
+		return nil, errors.New("echo deprecation middleware sunset can not be before deprecation date")
+	}
+
+	deprecation := "true"
+	if !config.DeprecatedAt.IsZero() {
+		deprecation = config.DeprecatedAt.UTC().Format(http.TimeFormat)
+	}
+
+	sunset := ""
+	if !config.Sunset.IsZero() {
+		sunset = config.Sunset.UTC().Format(http.TimeFormat)
+	}
+
+	link := ""
+	if config.Link != "" {
+		link = "<" + config.Link + `>; rel="deprecation"`
+	}
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			res := c.Response()
+			res.Before(func() {
+				h := res.Header()
+				h.Set(echox.HeaderDeprecation, deprecation)
+
+				if sunset != "" {
+					h.Set(echox.HeaderSunset, sunset)
+				}
+
+				if link != "" {
+					h.Add(echox.HeaderLink, link)
+				}
+			})
+
+			return next(c)
+		}
+	}, nil
+}
@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
+)
+
+func TestDeprecationWithConfig(t *testing.T) {
+	var testCases = []struct {
+		name              string
+		givenConfig       DeprecationConfig
+		whenPath          string
+		expectStatus      int
+		expectDeprecation string
+		expectSunset      string
+		expectLink        string
+	}{
+		{
+			name:              "ok, flagged route without dates",
+			whenPath:          "/v1/users",
+			expectStatus:      http.StatusOK,
+			expectDeprecation: "true",
+		},
+		{
+			name: "ok, flagged route with dates and link",
+			givenConfig: DeprecationConfig{
+				DeprecatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Sunset:       time.Date(2024, 6, 30, 23, 59, 59, 0, time.UTC),
+				Link:         "https://example.com/docs/migrate-to-v2",
+			},
+			whenPath:          "/v1/users",
+			expectStatus:      http.StatusOK,
+			expectDeprecation: "Mon, 01 Jan 2024 00:00:00 GMT",
+			expectSunset:      "Sun, 30 Jun 2024 23:59:59 GMT",
+			expectLink:        `<https://example.com/docs/migrate-to-v2>; rel="deprecation"`,
+		},
+		{
+			name: "ok, headers are set on error response",
+			givenConfig: DeprecationConfig{
+				Sunset: time.Date(2024, 6, 30, 23, 59, 59, 0, time.UTC),
+			},
+			whenPath:          "/v1/error",
+			expectStatus:      http.StatusInternalServerError,
+			expectDeprecation: "true",
+			expectSunset:      "Sun, 30 Jun 2024 23:59:59 GMT",
+		},
+		{
+			name:         "ok, route not flagged",
+			whenPath:     "/v2/users",
+			expectStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+
+			v1 := e.Group("/v1", DeprecationWithConfig(tc.givenConfig))
+			v1.GET("/users", func(c echox.Context) error {
+				return c.String(http.StatusOK, "users")
+			})
+			v1.GET("/error", func(c echox.Context) error {
+				return errors.New("error")
+			})
+			e.GET("/v2/users", func(c echox.Context) error {
+				return c.String(http.StatusOK, "users")
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tc.whenPath, nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectStatus, rec.Code)
+			assert.Equal(t, tc.expectDeprecation, rec.Header().Get(echox.HeaderDeprecation))
+			assert.Equal(t, tc.expectSunset, rec.Header().Get(echox.HeaderSunset))
+			assert.Equal(t, tc.expectLink, rec.Header().Get(echox.HeaderLink))
+		})
+	}
+}
+
+func TestDeprecationConfig_ToMiddlewareSunsetBeforeDeprecation(t *testing.T) {
+	_, err := DeprecationConfig{
+		DeprecatedAt: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		Sunset:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}.ToMiddleware()
+
+	assert.EqualError(t, err, "echo deprecation middleware sunset can not be before deprecation date")
+}
@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/theopenlane/echox"
+)
+
+// RequireDPoPConfig defines the config for RequireDPoP middleware.
+type RequireDPoPConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// TokenLookup is a string in the form of "<source>:<name>" or "<source>:<name>,<source>:<name>" that is used
+	// to extract DPoP-bound access token from the request. See KeyAuthConfig.KeyLookup for possible values.
+	// Optional. Default value "header:Authorization:DPoP ".
+	TokenLookup string
+
+	// Verifier verifies that DPoP proof is valid for the request and bound to the access token (i.e. checks proof
+	// signature, `htm`, `htu` and `ath` claims). Verification is left to user code so the JOSE library can be chosen
+	// freely. Returned error results in "401 - Unauthorized" response, unless it is *echox.HTTPError which is
+	// returned as is.
+	// Required.
+	Verifier DPoPVerifier
+}
+
+// DPoPVerifier defines a function to verify DPoP proof against access token of the request.
+type DPoPVerifier func(c echox.Context, proof string, accessToken string) error
+
+// DefaultRequireDPoPConfig is the default RequireDPoP middleware config.
+var DefaultRequireDPoPConfig = RequireDPoPConfig{
+	Skipper:     DefaultSkipper,
+	TokenLookup: "header:" + echox.HeaderAuthorization + ":DPoP ",
+}
+
+// RequireDPoP returns a RequireDPoP middleware.
+//
+// RequireDPoP middleware reads DPoP proof from `DPoP` header and access token from `Authorization: DPoP <token>`
+// header and calls verifier to bind the proof to the token. Requests with missing proof or token, or with proof
+// rejected by verifier, are rejected with "401 - Unauthorized" response.
+func RequireDPoP(verifier DPoPVerifier) echox.MiddlewareFunc {
+	return RequireDPoPWithConfig(RequireDPoPConfig{Verifier: verifier})
+}
+
+// RequireDPoPWithConfig returns a RequireDPoP middleware with config.
+// See: `RequireDPoP()`.
+func RequireDPoPWithConfig(config RequireDPoPConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts RequireDPoPConfig to middleware or returns an error for invalid configuration
+func (config RequireDPoPConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if config.Verifier == nil {
+		return nil, errors.New("echo require DPoP middleware requires a verifier function")
+	}
+
+	if config.Skipper == nil {
+		config.Skipper = DefaultRequireDPoPConfig.Skipper
+	}
+
+	if config.TokenLookup == "" {
+		config.TokenLookup = DefaultRequireDPoPConfig.TokenLookup
+	}
+
+	extractors, cErr := createExtractors(config.TokenLookup)
+	if cErr != nil {
+		return nil, fmt.Errorf("echo require DPoP middleware could not create token extractor: %w", cErr)
+	}
+
+	if len(extractors) == 0 {
+		return nil, errors.New("echo require DPoP middleware could not create extractors from TokenLookup string")
+	}
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			proof, err := c.DPoPProof()
+			if err != nil {
+				return err
+			}
+
+			var token string
+
+			var lastExtractorErr error
+
+			for _, extractor := range extractors {
+				tokens, _, extrErr := extractor(c)
+				if extrErr != nil {
+					lastExtractorErr = extrErr
+					continue
+				}
+
+				token = tokens[0]
+
+				break
+			}
+
+			if token == "" {
+				return echox.ErrUnauthorized.WithInternal(lastExtractorErr)
+			}
+
+			if err := config.Verifier(c, proof, token); err != nil {
+				var httpErr *echox.HTTPError
+				if errors.As(err, &httpErr) {
+					return err
+				}
+
+				return echox.ErrDPoPProofInvalid.WithInternal(err)
+			}
+
+			return next(c)
+		}
+	}, nil
+}
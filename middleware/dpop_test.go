@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
+)
+
+func TestRequireDPoPWithConfig(t *testing.T) {
+	verifier := func(c echox.Context, proof string, accessToken string) error {
+		if proof != "proof-for-"+accessToken {
+			return errors.New("proof is not bound to token")
+		}
+		return nil
+	}
+
+	var testCases = []struct {
+		name              string
+		givenConfig       RequireDPoPConfig
+		whenAuthorization string
+		whenDPoP          string
+		expectErr         string
+	}{
+		{
+			name:              "ok",
+			givenConfig:       RequireDPoPConfig{Verifier: verifier},
+			whenAuthorization: "DPoP token",
+			whenDPoP:          "proof-for-token",
+		},
+		{
+			name:              "nok, missing proof",
+			givenConfig:       RequireDPoPConfig{Verifier: verifier},
+			whenAuthorization: "DPoP token",
+			expectErr:         "code=401, message=missing DPoP proof",
+		},
+		{
+			name:        "nok, missing token",
+			givenConfig: RequireDPoPConfig{Verifier: verifier},
+			whenDPoP:    "proof-for-token",
+			expectErr:   "code=401, message=Unauthorized, internal=missing value in request header",
+		},
+		{
+			name:              "nok, proof not bound to token",
+			givenConfig:       RequireDPoPConfig{Verifier: verifier},
+			whenAuthorization: "DPoP token",
+			whenDPoP:          "proof-for-other",
+			expectErr:         "code=401, message=invalid DPoP proof, internal=proof is not bound to token",
+		},
+		{
+			name: "nok, verifier returns HTTPError",
+			givenConfig: RequireDPoPConfig{
+				Verifier: func(c echox.Context, proof string, accessToken string) error {
+					return echox.NewHTTPError(http.StatusBadRequest, "use_dpop_nonce")
+				},
+			},
+			whenAuthorization: "DPoP token",
+			whenDPoP:          "proof-for-token",
+			expectErr:         "code=400, message=use_dpop_nonce",
+		},
+		{
+			name: "ok, custom token lookup",
+			givenConfig: RequireDPoPConfig{
+				Verifier:    verifier,
+				TokenLookup: "header:Authorization:Bearer ",
+			},
+			whenAuthorization: "Bearer token",
+			whenDPoP:          "proof-for-token",
+		},
+		{
+			name: "ok, skipped",
+			givenConfig: RequireDPoPConfig{
+				Verifier: verifier,
+				Skipper:  func(c echox.Context) bool { return true },
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.whenAuthorization != "" {
+				req.Header.Set(echox.HeaderAuthorization, tc.whenAuthorization)
+			}
+			if tc.whenDPoP != "" {
+				req.Header.Set(echox.HeaderDPoP, tc.whenDPoP)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := RequireDPoPWithConfig(tc.givenConfig)(func(c echox.Context) error {
+				return c.String(http.StatusOK, "test")
+			})(c)
+
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "test", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestRequireDPoPWithConfig_invalidConfig(t *testing.T) {
+	_, err := RequireDPoPConfig{}.ToMiddleware()
+	assert.EqualError(t, err, "echo require DPoP middleware requires a verifier function")
+}
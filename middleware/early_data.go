@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/theopenlane/echox"
+)
+
+// EarlyDataConfig defines the config for EarlyData middleware.
+type EarlyDataConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// AllowMethods is list of HTTP methods that are allowed to be served from TLS early data (0-RTT). Requests with
+	// other methods sent in early data are rejected with "425 - Too Early" response so client retries them after
+	// TLS handshake has completed.
+	// Optional. Default value idempotent methods: GET, HEAD, OPTIONS, TRACE, PUT and DELETE.
+	AllowMethods []string
+}
+
+// DefaultEarlyDataConfig is the default EarlyData middleware config.
+var DefaultEarlyDataConfig = EarlyDataConfig{
+	Skipper: DefaultSkipper,
+	AllowMethods: []string{
+		http.MethodGet,
+		http.MethodHead,
+		http.MethodOptions,
+		http.MethodTrace,
+		http.MethodPut,
+		http.MethodDelete,
+	},
+}
+
+// EarlyData returns an EarlyData middleware.
+//
+// EarlyData middleware protects against replay of requests sent in TLS 1.3 early data (0-RTT). Requests are considered
+// to be sent in early data when TLS terminator (proxy) marks them with `Early-Data: 1` header (RFC 8470). Such requests
+// with non-idempotent methods (i.e. POST, PATCH) are rejected with "425 - Too Early" response.
+func EarlyData() echox.MiddlewareFunc {
+	return EarlyDataWithConfig(DefaultEarlyDataConfig)
+}
+
+// EarlyDataWithConfig returns an EarlyData middleware with config.
+// See: `EarlyData()`.
+func EarlyDataWithConfig(config EarlyDataConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts EarlyDataConfig to middleware or returns an error for invalid configuration
+func (config EarlyDataConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if config.Skipper == nil {
+		config.Skipper = DefaultEarlyDataConfig.Skipper
+	}
+
+	if len(config.AllowMethods) == 0 {
+		config.AllowMethods = DefaultEarlyDataConfig.AllowMethods
+	}
+
+	allowMethods := make(map[string]struct{}, len(config.AllowMethods))
+	for _, m := range config.AllowMethods {
+		allowMethods[m] = struct{}{}
+	}
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			req := c.Request()
+			if req.Header.Get(echox.HeaderEarlyData) != "1" {
+				return next(c)
+			}
+
+			if _, ok := allowMethods[req.Method]; !ok {
+				return echox.ErrTooEarly
+			}
+
+			return next(c)
+		}
+	}, nil
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
+)
+
+func TestEarlyData(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		givenConfig   *EarlyDataConfig
+		whenMethod    string
+		whenEarlyData string
+		expectErr     string
+	}{
+		{
+			name:          "nok, POST in early data",
+			whenMethod:    http.MethodPost,
+			whenEarlyData: "1",
+			expectErr:     "code=425, message=Too Early",
+		},
+		{
+			name:          "ok, GET in early data",
+			whenMethod:    http.MethodGet,
+			whenEarlyData: "1",
+		},
+		{
+			name:       "ok, POST without early data header",
+			whenMethod: http.MethodPost,
+		},
+		{
+			name:          "ok, POST with other early data header value",
+			whenMethod:    http.MethodPost,
+			whenEarlyData: "0",
+		},
+		{
+			name:          "nok, PUT in early data with custom allowed methods",
+			givenConfig:   &EarlyDataConfig{AllowMethods: []string{http.MethodGet}},
+			whenMethod:    http.MethodPut,
+			whenEarlyData: "1",
+			expectErr:     "code=425, message=Too Early",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			req := httptest.NewRequest(tc.whenMethod, "/", nil)
+			if tc.whenEarlyData != "" {
+				req.Header.Set(echox.HeaderEarlyData, tc.whenEarlyData)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			mw := EarlyData()
+			if tc.givenConfig != nil {
+				mw = EarlyDataWithConfig(*tc.givenConfig)
+			}
+
+			err := mw(func(c echox.Context) error {
+				return c.String(http.StatusOK, "OK")
+			})(c)
+
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+			}
+		})
+	}
+}
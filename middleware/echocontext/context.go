@@ -2,6 +2,7 @@ package echocontext
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/theopenlane/utils/contextx"
 
@@ -14,7 +15,10 @@ type CustomContext struct {
 	ctx context.Context
 }
 
-// EchoContextToContextMiddleware is the middleware that adds the echo.Context to the parent context
+// EchoContextToContextMiddleware is the middleware that adds the echo.Context to the parent context. The echo.Context is
+// stored under a type based key so it is retrievable with EchoContextFromContext from any context derived from the
+// request context (i.e. with context.WithTimeout or context.WithCancel by ContextTimeout middleware). Contexts that are
+// not derived from the request context (i.e. context.Background()) do not carry the echo.Context
 func EchoContextToContextMiddleware() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -29,6 +33,28 @@ func EchoContextToContextMiddleware() echo.MiddlewareFunc {
 	}
 }
 
+// UnderlyingContext returns the context.Context stored with the echo.Context. It is always the context of the
+// current request, i.e. same as `c.Request().Context()`
+func (cc *CustomContext) UnderlyingContext() context.Context {
+	return cc.ctx
+}
+
+// SetRequest sets the request and keeps the stored context.Context in sync with the new request context. When the new
+// request context does not carry the echo.Context it is re-attached so EchoContextFromContext keeps working for
+// handlers further down the chain
+func (cc *CustomContext) SetRequest(r *http.Request) {
+	ctx := r.Context()
+	if _, ok := contextx.From[echo.Context](ctx); !ok {
+		if ec, ok := contextx.From[echo.Context](cc.ctx); ok {
+			ctx = contextx.With(ctx, ec)
+			r = r.WithContext(ctx)
+		}
+	}
+
+	cc.Context.SetRequest(r)
+	cc.ctx = ctx
+}
+
 // EchoContextFromContext gets the echo.Context from the parent context
 func EchoContextFromContext(ctx context.Context) (echo.Context, error) {
 	ec, ok := contextx.From[echo.Context](ctx)
@@ -38,3 +64,11 @@ func EchoContextFromContext(ctx context.Context) (echo.Context, error) {
 
 	return ec, nil
 }
+
+// EchoContextFromContextOrNil gets the echo.Context from the parent context or returns nil when the context does not
+// carry the echo.Context
+func EchoContextFromContextOrNil(ctx context.Context) echo.Context {
+	ec, _ := contextx.From[echo.Context](ctx)
+
+	return ec
+}
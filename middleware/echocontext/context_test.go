@@ -5,10 +5,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	echo "github.com/theopenlane/echox"
+	"github.com/theopenlane/echox/middleware"
 	"github.com/theopenlane/echox/middleware/echocontext"
 	"github.com/theopenlane/utils/contextx"
 )
@@ -78,3 +80,77 @@ func TestEchoContextFromContext_NotFound(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, retrievedEchoContext)
 }
+
+func TestCustomContext_UnderlyingContext(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	ec := e.NewContext(req, rec)
+
+	type ctxKey struct{}
+
+	handler := echocontext.EchoContextToContextMiddleware()(func(c echo.Context) error {
+		cc, ok := c.(*echocontext.CustomContext)
+		require.True(t, ok)
+		assert.Equal(t, c.Request().Context(), cc.UnderlyingContext())
+
+		// replace request with context that does not carry the echo.Context
+		c.SetRequest(c.Request().WithContext(context.WithValue(context.Background(), ctxKey{}, "value")))
+
+		ctx := cc.UnderlyingContext()
+		assert.Equal(t, c.Request().Context(), ctx)
+		assert.Equal(t, "value", ctx.Value(ctxKey{}))
+
+		retrievedEchoContext, err := echocontext.EchoContextFromContext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, ec, retrievedEchoContext)
+
+		return nil
+	})
+
+	require.NoError(t, handler(ec))
+}
+
+func TestEchoContextFromContextOrNil(t *testing.T) {
+	echoCtx := echocontext.NewTestEchoContext()
+
+	ctx := contextx.With(context.Background(), echoCtx)
+	assert.Equal(t, echoCtx, echocontext.EchoContextFromContextOrNil(ctx))
+
+	assert.Nil(t, echocontext.EchoContextFromContextOrNil(context.Background()))
+}
+
+func TestEchoContextToContextMiddleware_withContextTimeout(t *testing.T) {
+	e := echo.New()
+	e.Use(echocontext.EchoContextToContextMiddleware())
+	e.Use(middleware.ContextTimeout(time.Second))
+
+	var retrievedEchoContext echo.Context
+
+	e.GET("/", func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline)
+
+		var err error
+
+		retrievedEchoContext, err = echocontext.EchoContextFromContext(ctx)
+		require.NoError(t, err)
+
+		// derived contexts still carry the echo.Context
+		cancelCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		assert.Equal(t, retrievedEchoContext, echocontext.EchoContextFromContextOrNil(cancelCtx))
+
+		return c.String(http.StatusOK, "OK")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotNil(t, retrievedEchoContext)
+}
@@ -0,0 +1,65 @@
+package echocontext
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	echo "github.com/theopenlane/echox"
+)
+
+// PropagatedHeaders holds request header values copied into the request context by ContextPropagation
+type PropagatedHeaders map[string]string
+
+// ContextPropagationConfig defines the config for ContextPropagation middleware
+type ContextPropagationConfig struct {
+	// Headers is list of request header names to copy into the request context. Headers missing from the request are
+	// not stored.
+	Headers []string
+
+	// KeyFunc maps header name to the key the value is stored under in PropagatedHeaders.
+	// Optional. Default value uses canonical header name (`x-request-id` is stored as `X-Request-Id`).
+	KeyFunc func(header string) string
+}
+
+// ContextPropagation returns middleware that copies configured request headers into the request context so code that
+// only has the context.Context (services, repositories, outgoing clients) can read them with PropagatedHeader.
+// Multiple values of the same header are joined with a comma.
+func ContextPropagation(config ContextPropagationConfig) echo.MiddlewareFunc {
+	keyFunc := config.KeyFunc
+	if keyFunc == nil {
+		keyFunc = http.CanonicalHeaderKey
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			values := PropagatedHeaders{}
+
+			for _, name := range config.Headers {
+				if v := req.Header.Values(name); len(v) > 0 {
+					values[keyFunc(name)] = strings.Join(v, ",")
+				}
+			}
+
+			if len(values) > 0 {
+				c.SetRequest(req.WithContext(WithValue(req.Context(), values)))
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// PropagatedHeader returns the header value stored under key by ContextPropagation middleware
+func PropagatedHeader(ctx context.Context, key string) (string, bool) {
+	values, ok := ValueFromContext[PropagatedHeaders](ctx)
+	if !ok {
+		return "", false
+	}
+
+	v, ok := values[key]
+
+	return v, ok
+}
@@ -0,0 +1,88 @@
+package echocontext_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	echo "github.com/theopenlane/echox"
+	"github.com/theopenlane/echox/middleware/echocontext"
+)
+
+func TestContextPropagation(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Request-Id", "req-1")
+	req.Header.Add("Baggage", "a=1")
+	req.Header.Add("Baggage", "b=2")
+	req.Header.Set("X-Other", "ignored")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mw := echocontext.ContextPropagation(echocontext.ContextPropagationConfig{
+		Headers: []string{"x-request-id", "Baggage", "X-Tenant"},
+	})
+
+	err := mw(func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		v, ok := echocontext.PropagatedHeader(ctx, "X-Request-Id")
+		require.True(t, ok)
+		assert.Equal(t, "req-1", v)
+
+		v, ok = echocontext.PropagatedHeader(ctx, "Baggage")
+		require.True(t, ok)
+		assert.Equal(t, "a=1,b=2", v)
+
+		_, ok = echocontext.PropagatedHeader(ctx, "X-Tenant")
+		assert.False(t, ok)
+
+		_, ok = echocontext.PropagatedHeader(ctx, "X-Other")
+		assert.False(t, ok)
+
+		return nil
+	})(c)
+	require.NoError(t, err)
+}
+
+func TestContextPropagation_keyFunc(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Request-Id", "req-1")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mw := echocontext.ContextPropagation(echocontext.ContextPropagationConfig{
+		Headers: []string{"X-Request-Id"},
+		KeyFunc: strings.ToLower,
+	})
+
+	err := mw(func(c echo.Context) error {
+		v, ok := echocontext.PropagatedHeader(c.Request().Context(), "x-request-id")
+		require.True(t, ok)
+		assert.Equal(t, "req-1", v)
+
+		return nil
+	})(c)
+	require.NoError(t, err)
+}
+
+func TestContextPropagation_noHeaders(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mw := echocontext.ContextPropagation(echocontext.ContextPropagationConfig{Headers: []string{"X-Request-Id"}})
+
+	err := mw(func(c echo.Context) error {
+		_, ok := echocontext.ValueFromContext[echocontext.PropagatedHeaders](c.Request().Context())
+		assert.False(t, ok)
+
+		return nil
+	})(c)
+	require.NoError(t, err)
+}
@@ -0,0 +1,38 @@
+package echocontext
+
+import (
+	"context"
+
+	"github.com/theopenlane/utils/contextx"
+
+	echo "github.com/theopenlane/echox"
+)
+
+// WithValue returns a copy of the parent context carrying the value. Values are keyed by their type so only one value
+// of each type can be stored
+func WithValue[T any](ctx context.Context, v T) context.Context {
+	return contextx.With(ctx, v)
+}
+
+// ValueFromContext gets the value of type T from the context
+func ValueFromContext[T any](ctx context.Context) (T, bool) {
+	return contextx.From[T](ctx)
+}
+
+// StoreValueMiddleware is the middleware that stores the value returned by provider in the request context so it can be
+// retrieved with ValueFromContext by code that only has the context.Context. Errors returned by provider are returned
+// without calling next handler
+func StoreValueMiddleware[T any](provider func(c echo.Context) (T, error)) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			v, err := provider(c)
+			if err != nil {
+				return err
+			}
+
+			c.SetRequest(c.Request().WithContext(WithValue(c.Request().Context(), v)))
+
+			return next(c)
+		}
+	}
+}
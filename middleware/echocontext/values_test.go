@@ -0,0 +1,71 @@
+package echocontext_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	echo "github.com/theopenlane/echox"
+	"github.com/theopenlane/echox/middleware/echocontext"
+)
+
+type testTenant struct {
+	ID string
+}
+
+func TestWithValue(t *testing.T) {
+	ctx := echocontext.WithValue(context.Background(), testTenant{ID: "tenant-1"})
+
+	tenant, ok := echocontext.ValueFromContext[testTenant](ctx)
+	assert.True(t, ok)
+	assert.Equal(t, testTenant{ID: "tenant-1"}, tenant)
+
+	_, ok = echocontext.ValueFromContext[*testTenant](ctx)
+	assert.False(t, ok)
+}
+
+func TestStoreValueMiddleware(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Tenant", "tenant-1")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mw := echocontext.StoreValueMiddleware(func(c echo.Context) (testTenant, error) {
+		return testTenant{ID: c.Request().Header.Get("X-Tenant")}, nil
+	})
+
+	err := mw(func(c echo.Context) error {
+		tenant, ok := echocontext.ValueFromContext[testTenant](c.Request().Context())
+		require.True(t, ok)
+		assert.Equal(t, "tenant-1", tenant.ID)
+
+		return nil
+	})(c)
+	require.NoError(t, err)
+}
+
+func TestStoreValueMiddleware_providerError(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	providerErr := errors.New("no tenant")
+	mw := echocontext.StoreValueMiddleware(func(c echo.Context) (testTenant, error) {
+		return testTenant{}, providerErr
+	})
+
+	called := false
+	err := mw(func(c echo.Context) error {
+		called = true
+		return nil
+	})(c)
+
+	assert.ErrorIs(t, err, providerErr)
+	assert.False(t, called)
+}
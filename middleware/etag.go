@@ -0,0 +1,187 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/theopenlane/echox"
+)
+
+// ETagConfig defines the config for ETag middleware.
+type ETagConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// Weak marks generated entity-tags as weak (`W/"..."`). Use weak entity-tags when the same entity-tag is sent for
+	// differently encoded representations, i.e. when ETag is used together with Gzip middleware.
+	// Optional. Default value false.
+	Weak bool
+
+	// MinSize is minimum response body size in bytes for entity-tag to be generated. Hashing tiny bodies does not save
+	// any bandwidth.
+	// Optional. Default value 0 (entity-tag is generated for all responses).
+	MinSize int
+}
+
+type etagResponseWriter struct {
+	http.ResponseWriter
+	buffer      bytes.Buffer
+	code        int
+	wroteHeader bool
+	streaming   bool
+}
+
+// ETag returns an ETag middleware with default config.
+//
+// ETag middleware buffers successful (200) responses to GET and HEAD requests, sets `ETag` header with hash of the
+// response body and responds with "304 - Not Modified" without body when request `If-None-Match` header matches it.
+// Entity-tag set by the handler itself is left as is and used for the comparison. Flushed (streaming) responses are
+// passed through without entity-tag.
+//
+// When used together with Gzip middleware add ETag after Gzip (`e.Use(middleware.Gzip(), middleware.ETag())`) so
+// the hash is calculated from the uncompressed body and "304" responses are not compressed at all.
+func ETag() echox.MiddlewareFunc {
+	return ETagWithConfig(ETagConfig{})
+}
+
+// ETagWithConfig returns an ETag middleware with config.
+// See: `ETag()`.
+func ETagWithConfig(config ETagConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts ETagConfig to middleware or returns an error for invalid configuration
+func (config ETagConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if config.Skipper == nil {
+		config.Skipper = DefaultSkipper
+	}
+
+	if config.MinSize < 0 {
+		return nil, errors.New("echo etag middleware min size can not be negative")
+	}
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			req := c.Request()
+			if req.Method != http.MethodGet && req.Method != http.MethodHead {
+				return next(c)
+			}
+
+			res := c.Response()
+			rw := res.Writer
+			writer := &etagResponseWriter{ResponseWriter: rw}
+			res.Writer = writer
+
+			err := next(c)
+
+			res.Writer = rw
+
+			if writer.streaming || !writer.wroteHeader {
+				return err
+			}
+
+			tag := res.Header().Get(echox.HeaderETag)
+			if tag == "" && err == nil && writer.code == http.StatusOK && writer.buffer.Len() >= config.MinSize {
+				tag = generateETag(writer.buffer.Bytes(), config.Weak)
+				res.Header().Set(echox.HeaderETag, tag)
+			}
+
+			if tag != "" && writer.code == http.StatusOK && etagMatches(req.Header.Values(echox.HeaderIfNoneMatch), tag) {
+				res.Header().Del(echox.HeaderContentLength)
+				res.Status = http.StatusNotModified
+				rw.WriteHeader(http.StatusNotModified)
+
+				return err
+			}
+
+			rw.WriteHeader(writer.code)
+
+			if _, wErr := writer.buffer.WriteTo(rw); wErr != nil && err == nil {
+				err = wErr
+			}
+
+			return err
+		}
+	}, nil
+}
+
+func generateETag(body []byte, weak bool) string {
+	sum := sha256.Sum256(body)
+	tag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	if weak {
+		return "W/" + tag
+	}
+
+	return tag
+}
+
+// etagMatches compares `If-None-Match` header values against entity-tag using weak comparison (RFC 9110 13.1.2)
+func etagMatches(ifNoneMatch []string, tag string) bool {
+	tag = strings.TrimPrefix(tag, "W/")
+
+	for _, v := range ifNoneMatch {
+		for _, t := range strings.Split(v, ",") {
+			t = strings.TrimSpace(t)
+			if t == "*" || strings.TrimPrefix(t, "W/") == tag {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (w *etagResponseWriter) WriteHeader(code int) {
+	if w.streaming {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+
+	// delay writing of the header until we know if response is "304 - Not Modified"
+	w.wroteHeader = true
+	w.code = code
+}
+
+func (w *etagResponseWriter) Write(b []byte) (int, error) {
+	if w.streaming {
+		return w.ResponseWriter.Write(b)
+	}
+
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.buffer.Write(b)
+}
+
+func (w *etagResponseWriter) Flush() {
+	if !w.streaming {
+		// streaming response can not have entity-tag calculated from the whole body, write out what we have so far
+		w.streaming = true
+
+		if w.wroteHeader {
+			w.ResponseWriter.WriteHeader(w.code)
+		}
+
+		w.buffer.WriteTo(w.ResponseWriter) // nolint: errcheck
+	}
+
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *etagResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return w.ResponseWriter.(http.Hijacker).Hijack()
+}
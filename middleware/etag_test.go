@@ -0,0 +1,231 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
+)
+
+func TestETagWithConfig(t *testing.T) {
+	const body = "Hello, World!"
+	const bodyTag = `"dffd6021bb2bd5b0af676290809ec3a5"`
+
+	var testCases = []struct {
+		name            string
+		givenConfig     ETagConfig
+		givenHandler    echox.HandlerFunc
+		whenMethod      string
+		whenIfNoneMatch string
+		expectStatus    int
+		expectETag      string
+		expectBody      string
+		expectErr       string
+	}{
+		{
+			name:         "ok, etag is generated",
+			expectStatus: http.StatusOK,
+			expectETag:   bodyTag,
+			expectBody:   body,
+		},
+		{
+			name:         "ok, weak etag is generated",
+			givenConfig:  ETagConfig{Weak: true},
+			expectStatus: http.StatusOK,
+			expectETag:   "W/" + bodyTag,
+			expectBody:   body,
+		},
+		{
+			name:            "ok, not modified",
+			whenIfNoneMatch: bodyTag,
+			expectStatus:    http.StatusNotModified,
+			expectETag:      bodyTag,
+			expectBody:      "",
+		},
+		{
+			name:            "ok, not modified with HEAD request",
+			whenMethod:      http.MethodHead,
+			whenIfNoneMatch: bodyTag,
+			expectStatus:    http.StatusNotModified,
+			expectETag:      bodyTag,
+			expectBody:      "",
+		},
+		{
+			name:            "ok, not modified with weak comparison and multiple tags",
+			givenConfig:     ETagConfig{Weak: true},
+			whenIfNoneMatch: `"other", ` + bodyTag,
+			expectStatus:    http.StatusNotModified,
+			expectETag:      "W/" + bodyTag,
+			expectBody:      "",
+		},
+		{
+			name:            "ok, not modified with star",
+			whenIfNoneMatch: "*",
+			expectStatus:    http.StatusNotModified,
+			expectETag:      bodyTag,
+			expectBody:      "",
+		},
+		{
+			name:            "ok, modified",
+			whenIfNoneMatch: `"other"`,
+			expectStatus:    http.StatusOK,
+			expectETag:      bodyTag,
+			expectBody:      body,
+		},
+		{
+			name:         "ok, body smaller than min size",
+			givenConfig:  ETagConfig{MinSize: 100},
+			expectStatus: http.StatusOK,
+			expectETag:   "",
+			expectBody:   body,
+		},
+		{
+			name: "ok, etag set by handler is used",
+			givenHandler: func(c echox.Context) error {
+				c.Response().Header().Set(echox.HeaderETag, `"v42"`)
+				return c.String(http.StatusOK, body)
+			},
+			whenIfNoneMatch: `"v42"`,
+			expectStatus:    http.StatusNotModified,
+			expectETag:      `"v42"`,
+			expectBody:      "",
+		},
+		{
+			name: "ok, no etag for non 200 response",
+			givenHandler: func(c echox.Context) error {
+				return c.String(http.StatusCreated, body)
+			},
+			whenIfNoneMatch: "*",
+			expectStatus:    http.StatusCreated,
+			expectETag:      "",
+			expectBody:      body,
+		},
+		{
+			name:         "ok, no etag for POST request",
+			whenMethod:   http.MethodPost,
+			expectStatus: http.StatusOK,
+			expectETag:   "",
+			expectBody:   body,
+		},
+		{
+			name: "ok, streaming response is passed through",
+			givenHandler: func(c echox.Context) error {
+				c.Response().WriteHeader(http.StatusOK)
+				c.Response().Write([]byte("Hello, "))
+				c.Response().Flush()
+				c.Response().Write([]byte("World!"))
+				return nil
+			},
+			expectStatus: http.StatusOK,
+			expectETag:   "",
+			expectBody:   body,
+		},
+		{
+			name: "nok, handler error is returned",
+			givenHandler: func(c echox.Context) error {
+				return echox.ErrNotFound
+			},
+			expectStatus: http.StatusOK, // recorder default, error is not handled by the middleware
+			expectETag:   "",
+			expectBody:   "",
+			expectErr:    "code=404, message=Not Found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+
+			method := http.MethodGet
+			if tc.whenMethod != "" {
+				method = tc.whenMethod
+			}
+
+			req := httptest.NewRequest(method, "/", nil)
+			if tc.whenIfNoneMatch != "" {
+				req.Header.Set(echox.HeaderIfNoneMatch, tc.whenIfNoneMatch)
+			}
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			handler := tc.givenHandler
+			if handler == nil {
+				handler = func(c echox.Context) error {
+					return c.String(http.StatusOK, body)
+				}
+			}
+
+			err := ETagWithConfig(tc.givenConfig)(handler)(c)
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expectStatus, rec.Code)
+			assert.Equal(t, tc.expectETag, rec.Header().Get(echox.HeaderETag))
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
+
+func TestETagWithConfig_invalidMinSize(t *testing.T) {
+	_, err := ETagConfig{MinSize: -1}.ToMiddleware()
+
+	assert.EqualError(t, err, "echo etag middleware min size can not be negative")
+}
+
+func TestETag_afterGzip(t *testing.T) {
+	body := strings.Repeat("Hello, World!", 100)
+
+	e := echox.New()
+	e.Use(Gzip(), ETag())
+	e.GET("/", func(c echox.Context) error {
+		return c.String(http.StatusOK, body)
+	})
+
+	// plain request
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	plainTag := rec.Header().Get(echox.HeaderETag)
+	assert.NotEmpty(t, plainTag)
+	assert.Equal(t, body, rec.Body.String())
+
+	// compressed request gets same entity-tag calculated from uncompressed body
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echox.HeaderAcceptEncoding, gzipScheme)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, gzipScheme, rec.Header().Get(echox.HeaderContentEncoding))
+	assert.Equal(t, plainTag, rec.Header().Get(echox.HeaderETag))
+
+	r, err := gzip.NewReader(bytes.NewReader(rec.Body.Bytes()))
+	assert.NoError(t, err)
+	b, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, body, string(b))
+
+	// conditional compressed request is answered with empty uncompressed 304
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echox.HeaderAcceptEncoding, gzipScheme)
+	req.Header.Set(echox.HeaderIfNoneMatch, plainTag)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Equal(t, "", rec.Header().Get(echox.HeaderContentEncoding))
+	assert.Equal(t, plainTag, rec.Header().Get(echox.HeaderETag))
+	assert.Equal(t, 0, rec.Body.Len())
+}
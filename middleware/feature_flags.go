@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/theopenlane/echox"
+)
+
+// FeatureFlagsConfig defines the config for FeatureFlags middleware.
+type FeatureFlagsConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// Resolver evaluates feature flags for the request. It is called once per request and result is stored into
+	// context under echox.ContextKeyFeatureFlags.
+	// Required.
+	Resolver FeatureFlagsResolver
+
+	// ErrorHandler is called when Resolver returns an error. When ErrorHandler returns nil request is continued with
+	// all feature flags disabled, otherwise returned error is returned from the middleware.
+	// Optional. Default value nil, in which case "500 - Internal Server Error" with Resolver error as internal is returned.
+	ErrorHandler func(c echox.Context, err error) error
+}
+
+// FeatureFlagsResolver resolves feature flags for the request
+type FeatureFlagsResolver func(c echox.Context) (map[string]bool, error)
+
+// FeatureFlags returns a FeatureFlags middleware.
+//
+// FeatureFlags middleware evaluates feature flags once per request with given resolver so handlers can branch on them
+// with `echox.FeatureEnabled(c, "new-checkout")`.
+func FeatureFlags(resolver FeatureFlagsResolver) echox.MiddlewareFunc {
+	return FeatureFlagsWithConfig(FeatureFlagsConfig{Resolver: resolver})
+}
+
+// FeatureFlagsWithConfig returns a FeatureFlags middleware with config.
+// See: `FeatureFlags()`.
+func FeatureFlagsWithConfig(config FeatureFlagsConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts FeatureFlagsConfig to middleware or returns an error for invalid configuration
+func (config FeatureFlagsConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if config.Resolver == nil {
+		return nil, errors.New("echo feature flags middleware requires a resolver function")
+	}
+
+	if config.Skipper == nil {
+		config.Skipper = DefaultSkipper
+	}
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			flags, err := config.Resolver(c)
+			if err != nil {
+				if config.ErrorHandler == nil {
+					return echox.ErrInternalServerError.WithInternal(err)
+				}
+
+				if hErr := config.ErrorHandler(c, err); hErr != nil {
+					return hErr
+				}
+
+				flags = nil
+			}
+
+			c.Set(echox.ContextKeyFeatureFlags, flags)
+
+			return next(c)
+		}
+	}, nil
+}
@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
+)
+
+func TestFeatureFlagsWithConfig(t *testing.T) {
+	errResolve := errors.New("flag service unavailable")
+
+	var testCases = []struct {
+		name        string
+		givenConfig FeatureFlagsConfig
+		whenUser    string
+		expectBody  string
+		expectErr   string
+	}{
+		{
+			name: "ok, flag enabled",
+			givenConfig: FeatureFlagsConfig{
+				Resolver: func(c echox.Context) (map[string]bool, error) {
+					return map[string]bool{"new-checkout": c.Request().Header.Get("X-User") == "beta"}, nil
+				},
+			},
+			whenUser:   "beta",
+			expectBody: "new checkout",
+		},
+		{
+			name: "ok, flag disabled",
+			givenConfig: FeatureFlagsConfig{
+				Resolver: func(c echox.Context) (map[string]bool, error) {
+					return map[string]bool{"new-checkout": c.Request().Header.Get("X-User") == "beta"}, nil
+				},
+			},
+			whenUser:   "regular",
+			expectBody: "old checkout",
+		},
+		{
+			name: "nok, resolver error",
+			givenConfig: FeatureFlagsConfig{
+				Resolver: func(c echox.Context) (map[string]bool, error) {
+					return nil, errResolve
+				},
+			},
+			expectErr: "code=500, message=Internal Server Error, internal=flag service unavailable",
+		},
+		{
+			name: "nok, resolver error handled by error handler",
+			givenConfig: FeatureFlagsConfig{
+				Resolver: func(c echox.Context) (map[string]bool, error) {
+					return nil, errResolve
+				},
+				ErrorHandler: func(c echox.Context, err error) error {
+					return echox.NewHTTPError(http.StatusServiceUnavailable, "flags unavailable").WithInternal(err)
+				},
+			},
+			expectErr: "code=503, message=flags unavailable, internal=flag service unavailable",
+		},
+		{
+			name: "ok, resolver error ignored by error handler",
+			givenConfig: FeatureFlagsConfig{
+				Resolver: func(c echox.Context) (map[string]bool, error) {
+					return map[string]bool{"new-checkout": true}, errResolve
+				},
+				ErrorHandler: func(c echox.Context, err error) error {
+					return nil
+				},
+			},
+			expectBody: "old checkout",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("X-User", tc.whenUser)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := FeatureFlagsWithConfig(tc.givenConfig)(func(c echox.Context) error {
+				if echox.FeatureEnabled(c, "new-checkout") {
+					return c.String(http.StatusOK, "new checkout")
+				}
+				return c.String(http.StatusOK, "old checkout")
+			})(c)
+
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+				assert.True(t, errors.Is(err, errResolve))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
+
+func TestFeatureFlagsWithConfig_requiresResolver(t *testing.T) {
+	_, err := FeatureFlagsConfig{}.ToMiddleware()
+
+	assert.EqualError(t, err, "echo feature flags middleware requires a resolver function")
+}
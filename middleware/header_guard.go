@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/theopenlane/echox"
+)
+
+// HeaderGuardConfig defines the config for HeaderGuard middleware.
+type HeaderGuardConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// MaxHeaderCount is maximum number of header values (header lines) allowed in request. Requests with more header
+	// values are rejected with "431 - Request Header Fields Too Large" response.
+	// Optional. Default value 0 (no limit).
+	MaxHeaderCount int
+
+	// MaxHeaderValueLength is maximum allowed length in bytes of a single request header value. Requests with longer
+	// header values are rejected with "431 - Request Header Fields Too Large" response.
+	// Optional. Default value 0 (no limit).
+	MaxHeaderValueLength int
+
+	// DeniedHeaders is list of header names that are removed from the request before it is passed to next handler.
+	// Names are matched case-insensitively.
+	// Optional. Default value nil.
+	DeniedHeaders []string
+}
+
+// HeaderGuard returns a HeaderGuard middleware that rejects requests with more than maxHeaderCount header values
+// with "431 - Request Header Fields Too Large" response.
+func HeaderGuard(maxHeaderCount int) echox.MiddlewareFunc {
+	return HeaderGuardWithConfig(HeaderGuardConfig{MaxHeaderCount: maxHeaderCount})
+}
+
+// HeaderGuardWithConfig returns a HeaderGuard middleware with config. Middleware rejects requests exceeding configured
+// header count or header value length limits with "431 - Request Header Fields Too Large" response and strips denied
+// headers from the request.
+func HeaderGuardWithConfig(config HeaderGuardConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts HeaderGuardConfig to middleware or returns an error for invalid configuration
+func (config HeaderGuardConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if config.Skipper == nil {
+		config.Skipper = DefaultSkipper
+	}
+
+	if config.MaxHeaderCount < 0 {
+		return nil, errors.New("echo header guard middleware max header count can not be negative")
+	}
+
+	if config.MaxHeaderValueLength < 0 {
+		return nil, errors.New("echo header guard middleware max header value length can not be negative")
+	}
+
+	stripDenied := newHeaderStripper(config.DeniedHeaders, nil, false)
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			header := c.Request().Header
+
+			if config.MaxHeaderCount > 0 || config.MaxHeaderValueLength > 0 {
+				count := 0
+
+				for _, values := range header {
+					count += len(values)
+					if config.MaxHeaderCount > 0 && count > config.MaxHeaderCount {
+						return echox.ErrRequestHeaderFieldsTooLarge
+					}
+
+					if config.MaxHeaderValueLength > 0 {
+						for _, v := range values {
+							if len(v) > config.MaxHeaderValueLength {
+								return echox.ErrRequestHeaderFieldsTooLarge
+							}
+						}
+					}
+				}
+			}
+
+			stripDenied(header)
+
+			return next(c)
+		}
+	}, nil
+}
+
+// newHeaderStripper returns function removing headers with given names, headers starting with one of given prefixes
+// and, when hopByHop is set, hop-by-hop headers (and headers listed in `Connection` header) from the header map. Names
+// and prefixes are matched case-insensitively.
+func newHeaderStripper(names []string, prefixes []string, hopByHop bool) func(header http.Header) {
+	canonicalPrefixes := make([]string, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		canonicalPrefixes = append(canonicalPrefixes, http.CanonicalHeaderKey(prefix))
+	}
+
+	return func(header http.Header) {
+		if hopByHop {
+			for _, value := range header.Values(echox.HeaderConnection) {
+				for _, name := range strings.Split(value, ",") {
+					if name = strings.TrimSpace(name); name != "" {
+						header.Del(name)
+					}
+				}
+			}
+
+			for _, name := range HopByHopHeaders {
+				header.Del(name)
+			}
+		}
+
+		for _, name := range names {
+			header.Del(name)
+		}
+
+		if len(canonicalPrefixes) > 0 {
+			for name := range header {
+				for _, prefix := range canonicalPrefixes {
+					if strings.HasPrefix(http.CanonicalHeaderKey(name), prefix) {
+						delete(header, name)
+						break
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
+)
+
+func TestHeaderGuardWithConfig(t *testing.T) {
+	var testCases = []struct {
+		name         string
+		givenConfig  HeaderGuardConfig
+		whenHeaders  map[string][]string
+		expectErr    string
+		expectHeader http.Header
+	}{
+		{
+			name:         "ok, within limits",
+			givenConfig:  HeaderGuardConfig{MaxHeaderCount: 3, MaxHeaderValueLength: 10},
+			whenHeaders:  map[string][]string{"X-A": {"a"}, "X-B": {"b", "bb"}},
+			expectHeader: http.Header{"X-A": {"a"}, "X-B": {"b", "bb"}},
+		},
+		{
+			name:        "nok, header count over limit",
+			givenConfig: HeaderGuardConfig{MaxHeaderCount: 2},
+			whenHeaders: map[string][]string{"X-A": {"a"}, "X-B": {"b", "bb"}},
+			expectErr:   "code=431, message=Request Header Fields Too Large",
+		},
+		{
+			name:        "nok, header value length over limit",
+			givenConfig: HeaderGuardConfig{MaxHeaderValueLength: 5},
+			whenHeaders: map[string][]string{"X-A": {"a"}, "X-B": {strings.Repeat("b", 6)}},
+			expectErr:   "code=431, message=Request Header Fields Too Large",
+		},
+		{
+			name:         "ok, denied header is stripped",
+			givenConfig:  HeaderGuardConfig{DeniedHeaders: []string{"x-debug"}},
+			whenHeaders:  map[string][]string{"X-A": {"a"}, "X-Debug": {"true"}},
+			expectHeader: http.Header{"X-A": {"a"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, values := range tc.whenHeaders {
+				for _, v := range values {
+					req.Header.Add(k, v)
+				}
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			var receivedHeader http.Header
+			mw := HeaderGuardWithConfig(tc.givenConfig)
+			err := mw(func(c echox.Context) error {
+				receivedHeader = c.Request().Header.Clone()
+				return c.String(http.StatusOK, "test")
+			})(c)
+
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+				assert.Nil(t, receivedHeader)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectHeader, receivedHeader)
+			}
+		})
+	}
+}
+
+func TestHeaderGuard(t *testing.T) {
+	e := echox.New()
+	e.Use(HeaderGuard(10))
+	e.GET("/", func(c echox.Context) error {
+		return c.String(http.StatusOK, "test")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	for i := 0; i < 11; i++ {
+		req.Header.Add("X-Junk-"+strconv.Itoa(i), "junk")
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusRequestHeaderFieldsTooLarge, rec.Code)
+}
+
+func TestHeaderGuardConfig_ToMiddlewareNegativeLimits(t *testing.T) {
+	_, err := HeaderGuardConfig{MaxHeaderCount: -1}.ToMiddleware()
+	assert.EqualError(t, err, "echo header guard middleware max header count can not be negative")
+
+	_, err = HeaderGuardConfig{MaxHeaderValueLength: -1}.ToMiddleware()
+	assert.EqualError(t, err, "echo header guard middleware max header value length can not be negative")
+}
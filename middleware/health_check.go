@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/theopenlane/echox"
+)
+
+// HealthCheckConfig defines the config for HealthCheck middleware.
+type HealthCheckConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// LivenessPath is request path liveness status is served at.
+	// Optional. Default value "/livez".
+	LivenessPath string
+
+	// ReadinessPath is request path readiness status is served at.
+	// Optional. Default value "/readyz".
+	ReadinessPath string
+
+	// LivenessChecker reports if application is alive. Returning an error results in "503 - Service Unavailable" response.
+	// Optional. Default value nil, in which case application is always reported as alive.
+	LivenessChecker func(c echox.Context) error
+
+	// ReadinessChecker reports if application is ready to serve requests (i.e. database is reachable). Returning an
+	// error results in "503 - Service Unavailable" response. Readiness is always reported as unavailable after
+	// Echo.BeginDrain has been called.
+	// Optional. Default value nil, in which case application is reported as ready until draining starts.
+	ReadinessChecker func(c echox.Context) error
+}
+
+// HealthCheckStatus is JSON response written by HealthCheck middleware.
+type HealthCheckStatus struct {
+	Status string `json:"status"`
+}
+
+const (
+	// HealthCheckStatusOK is status reported when check succeeded
+	HealthCheckStatusOK = "ok"
+	// HealthCheckStatusUnavailable is status reported when check failed
+	HealthCheckStatusUnavailable = "unavailable"
+)
+
+// DefaultHealthCheckConfig is the default HealthCheck middleware config.
+var DefaultHealthCheckConfig = HealthCheckConfig{
+	Skipper:       DefaultSkipper,
+	LivenessPath:  "/livez",
+	ReadinessPath: "/readyz",
+}
+
+// HealthCheck returns a HealthCheck middleware with default config.
+//
+// HealthCheck middleware answers GET and HEAD requests to liveness and readiness paths with JSON status without
+// calling the rest of the middleware chain. Add it with `e.Pre` so it is executed before routing and all other
+// middlewares (i.e. authentication, rate limiting) and health probes do not pollute their metrics.
+//
+// Example:
+//
+//	e.Pre(middleware.HealthCheck())
+func HealthCheck() echox.MiddlewareFunc {
+	return HealthCheckWithConfig(DefaultHealthCheckConfig)
+}
+
+// HealthCheckWithConfig returns a HealthCheck middleware with config.
+// See: `HealthCheck()`.
+func HealthCheckWithConfig(config HealthCheckConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts HealthCheckConfig to middleware or returns an error for invalid configuration
+func (config HealthCheckConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if config.Skipper == nil {
+		config.Skipper = DefaultHealthCheckConfig.Skipper
+	}
+
+	if config.LivenessPath == "" {
+		config.LivenessPath = DefaultHealthCheckConfig.LivenessPath
+	}
+
+	if config.ReadinessPath == "" {
+		config.ReadinessPath = DefaultHealthCheckConfig.ReadinessPath
+	}
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			req := c.Request()
+			if req.Method != http.MethodGet && req.Method != http.MethodHead {
+				return next(c)
+			}
+
+			var checker func(c echox.Context) error
+
+			draining := false
+
+			switch req.URL.Path {
+			case config.LivenessPath:
+				checker = config.LivenessChecker
+			case config.ReadinessPath:
+				checker = config.ReadinessChecker
+				draining = c.Echo().Draining()
+			default:
+				return next(c)
+			}
+
+			code := http.StatusOK
+			status := HealthCheckStatus{Status: HealthCheckStatusOK}
+
+			if draining {
+				code = http.StatusServiceUnavailable
+				status.Status = HealthCheckStatusUnavailable
+			} else if checker != nil {
+				if err := checker(c); err != nil {
+					code = http.StatusServiceUnavailable
+					status.Status = HealthCheckStatusUnavailable
+				}
+			}
+
+			if req.Method == http.MethodHead {
+				return c.NoContent(code)
+			}
+
+			return c.JSON(code, status)
+		}
+	}, nil
+}
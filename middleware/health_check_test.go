@@ -0,0 +1,172 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
+)
+
+func TestHealthCheckWithConfig(t *testing.T) {
+	var testCases = []struct {
+		name         string
+		givenConfig  HealthCheckConfig
+		whenMethod   string
+		whenPath     string
+		expectStatus int
+		expectBody   string
+	}{
+		{
+			name:         "ok, liveness",
+			whenMethod:   http.MethodGet,
+			whenPath:     "/livez",
+			expectStatus: http.StatusOK,
+			expectBody:   `{"status":"ok"}` + "\n",
+		},
+		{
+			name: "ok, readiness",
+			givenConfig: HealthCheckConfig{
+				ReadinessChecker: func(c echox.Context) error { return nil },
+			},
+			whenMethod:   http.MethodGet,
+			whenPath:     "/readyz",
+			expectStatus: http.StatusOK,
+			expectBody:   `{"status":"ok"}` + "\n",
+		},
+		{
+			name: "nok, readiness checker fails",
+			givenConfig: HealthCheckConfig{
+				ReadinessChecker: func(c echox.Context) error { return errors.New("database unreachable") },
+			},
+			whenMethod:   http.MethodGet,
+			whenPath:     "/readyz",
+			expectStatus: http.StatusServiceUnavailable,
+			expectBody:   `{"status":"unavailable"}` + "\n",
+		},
+		{
+			name: "nok, liveness checker fails on HEAD request",
+			givenConfig: HealthCheckConfig{
+				LivenessChecker: func(c echox.Context) error { return errors.New("deadlock") },
+			},
+			whenMethod:   http.MethodHead,
+			whenPath:     "/livez",
+			expectStatus: http.StatusServiceUnavailable,
+			expectBody:   "",
+		},
+		{
+			name:         "ok, custom path",
+			givenConfig:  HealthCheckConfig{LivenessPath: "/health"},
+			whenMethod:   http.MethodGet,
+			whenPath:     "/health",
+			expectStatus: http.StatusOK,
+			expectBody:   `{"status":"ok"}` + "\n",
+		},
+		{
+			name:         "ok, other path is passed to next",
+			whenMethod:   http.MethodGet,
+			whenPath:     "/users",
+			expectStatus: http.StatusTeapot,
+			expectBody:   "next",
+		},
+		{
+			name:         "ok, other method is passed to next",
+			whenMethod:   http.MethodPost,
+			whenPath:     "/livez",
+			expectStatus: http.StatusTeapot,
+			expectBody:   "next",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			req := httptest.NewRequest(tc.whenMethod, tc.whenPath, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := HealthCheckWithConfig(tc.givenConfig)(func(c echox.Context) error {
+				return c.String(http.StatusTeapot, "next")
+			})(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectStatus, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHealthCheck_skipsRestOfChain(t *testing.T) {
+	e := echox.New()
+
+	rateLimiterCalls := 0
+	e.Pre(HealthCheck())
+	e.Use(RateLimiterWithConfig(RateLimiterConfig{
+		Store: NewRateLimiterMemoryStoreWithConfig(RateLimiterMemoryStoreConfig{Rate: 1, Burst: 1}),
+		BeforeFunc: func(c echox.Context) {
+			rateLimiterCalls++
+		},
+	}))
+	e.GET("/users", func(c echox.Context) error {
+		return c.String(http.StatusOK, "users")
+	})
+
+	for i := 0; i < 5; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/livez", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+	assert.Equal(t, 0, rateLimiterCalls)
+
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 1, rateLimiterCalls)
+}
+
+func TestHealthCheck_readinessAfterBeginDrain(t *testing.T) {
+	e := echox.New()
+	e.Pre(HealthCheck())
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	e.GET("/slow", func(c echox.Context) error {
+		close(started)
+		<-release
+		return c.String(http.StatusOK, "done")
+	})
+
+	probe := func(path string) int {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, probe("/readyz"))
+
+	inFlight := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		e.ServeHTTP(inFlight, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	}()
+	<-started
+
+	e.BeginDrain()
+
+	assert.Equal(t, http.StatusServiceUnavailable, probe("/readyz"))
+	assert.Equal(t, http.StatusOK, probe("/livez"))
+
+	close(release)
+	<-done
+
+	assert.Equal(t, http.StatusOK, inFlight.Code)
+	assert.Equal(t, "done", inFlight.Body.String())
+}
@@ -0,0 +1,386 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/theopenlane/echox"
+)
+
+// IdempotencyStore is the interface to be implemented by custom stores for Idempotency middleware.
+type IdempotencyStore interface {
+	// Begin reserves key for request that is about to be processed. Returns cached response when request with the same
+	// key was already completed, ErrIdempotencyKeyInFlight when request with the same key is still being processed and
+	// nil response with nil error when key was reserved.
+	Begin(key string) (*IdempotencyResponse, error)
+	// Complete stores response for reserved key so it is replayed for requests with the same key.
+	Complete(key string, response IdempotencyResponse) error
+	// Release removes reservation for key without storing response so request with the same key can be retried.
+	Release(key string) error
+}
+
+// IdempotencyResponse is response cached by Idempotency middleware.
+type IdempotencyResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	// Fingerprint is hash of request body the response was created for. Request reusing the key with different body
+	// is rejected instead of replayed.
+	Fingerprint string
+}
+
+// IdempotencyConfig defines the config for Idempotency middleware.
+type IdempotencyConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// Store caches responses by idempotency key.
+	// Required.
+	Store IdempotencyStore
+
+	// KeyHeader is request header idempotency key is read from. Requests without the header are passed through.
+	// Optional. Default value echox.HeaderIdempotencyKey.
+	KeyHeader string
+
+	// KeyFunc returns key responses are cached by from idempotency key sent by client. Keys should be scoped so that
+	// other clients reusing or guessing the key do not get the cached response, i.e. add authenticated user or tenant:
+	//
+	//	KeyFunc: func(c echox.Context, key string) string {
+	//		return middleware.DefaultIdempotencyKeyFunc(c, key) + " " + c.Get("user_id").(string)
+	//	}
+	//
+	// Optional. Default value DefaultIdempotencyKeyFunc (request method, route path and key).
+	KeyFunc func(c echox.Context, key string) string
+
+	// ExcludeHeaders are response headers that are not cached and replayed as they belong only to the original request
+	// (cookies, request and span IDs).
+	// Optional. Default value DefaultIdempotencyExcludeHeaders.
+	ExcludeHeaders []string
+
+	// MaxBodySize is maximum size in bytes of response body that is cached. Responses with larger bodies are not cached
+	// and requests with the same key are executed again.
+	// Optional. Default value 0 (no limit).
+	MaxBodySize int
+
+	// MaxRequestBodySize is maximum size in bytes of request body read into memory to compute its fingerprint. Requests
+	// with larger bodies are rejected with "413 - Request Entity Too Large" response.
+	// Optional. Default value 1MB.
+	MaxRequestBodySize int64
+}
+
+// DefaultIdempotencyMaxRequestBodySize is default maximum size of request body fingerprinted by Idempotency middleware
+const DefaultIdempotencyMaxRequestBodySize = 1 * MB
+
+// DefaultIdempotencyExcludeHeaders are response headers Idempotency middleware does not cache by default
+var DefaultIdempotencyExcludeHeaders = []string{
+	echox.HeaderSetCookie,
+	echox.HeaderXRequestID,
+	echox.HeaderXSpanID,
+	echox.HeaderXParentSpan,
+}
+
+// ErrIdempotencyKeyReused denotes an error raised when idempotency key is reused with different request body
+var ErrIdempotencyKeyReused = echox.NewHTTPError(http.StatusUnprocessableEntity, "idempotency key was already used with a different request payload")
+
+// ErrIdempotencyKeyInFlight denotes an error raised when request with the same idempotency key is still being processed
+var ErrIdempotencyKeyInFlight = echox.NewHTTPError(http.StatusConflict, "request with the same idempotency key is being processed")
+
+// Idempotency returns an Idempotency middleware.
+//
+// Idempotency middleware caches response of request with `Idempotency-Key` header and replays it for subsequent
+// requests with the same key instead of executing the handler again. Request with the same key arriving while the
+// first one is still being processed is rejected with "409 - Conflict" response and request reusing the key with
+// different body is rejected with "422 - Unprocessable Entity" response. Request body (up to MaxRequestBodySize) is
+// read into memory to compute its fingerprint. Responses are cached only when
+// handler returns no error, streaming responses (see BodyDump) are never cached.
+//
+// Example:
+//
+//	e.POST("/payments", createPayment, middleware.Idempotency(middleware.NewIdempotencyMemoryStore(24*time.Hour)))
+func Idempotency(store IdempotencyStore) echox.MiddlewareFunc {
+	return IdempotencyWithConfig(IdempotencyConfig{Store: store})
+}
+
+// IdempotencyWithConfig returns an Idempotency middleware with config.
+// See: `Idempotency()`.
+func IdempotencyWithConfig(config IdempotencyConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts IdempotencyConfig to middleware or returns an error for invalid configuration
+func (config IdempotencyConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if config.Store == nil {
+		return nil, errors.New("echo idempotency middleware requires a store")
+	}
+
+	if config.MaxBodySize < 0 {
+		return nil, errors.New("echo idempotency middleware max body size can not be negative")
+	}
+
+	if config.MaxRequestBodySize < 0 {
+		return nil, errors.New("echo idempotency middleware max request body size can not be negative")
+	}
+
+	if config.MaxRequestBodySize == 0 {
+		config.MaxRequestBodySize = DefaultIdempotencyMaxRequestBodySize
+	}
+
+	if config.Skipper == nil {
+		config.Skipper = DefaultSkipper
+	}
+
+	if config.KeyHeader == "" {
+		config.KeyHeader = echox.HeaderIdempotencyKey
+	}
+
+	if config.KeyFunc == nil {
+		config.KeyFunc = DefaultIdempotencyKeyFunc
+	}
+
+	if config.ExcludeHeaders == nil {
+		config.ExcludeHeaders = DefaultIdempotencyExcludeHeaders
+	}
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			key := c.Request().Header.Get(config.KeyHeader)
+			if key == "" {
+				return next(c)
+			}
+			key = config.KeyFunc(c, key)
+
+			fingerprint, err := idempotencyFingerprint(c.Request(), config.MaxRequestBodySize)
+			if err != nil {
+				return err
+			}
+
+			cached, err := config.Store.Begin(key)
+			if err != nil {
+				return err
+			}
+
+			if cached != nil {
+				if cached.Fingerprint != fingerprint {
+					return ErrIdempotencyKeyReused
+				}
+
+				return replayIdempotencyResponse(c, cached)
+			}
+
+			res := c.Response()
+			writer := &bodyDumpResponseWriter{
+				ResponseWriter: res.Writer,
+				maxBodySize:    config.MaxBodySize,
+			}
+			res.Writer = writer
+
+			// release reservation also when handler panics so request can be retried
+			stored := false
+			defer func() {
+				if stored {
+					return
+				}
+
+				if rErr := config.Store.Release(key); rErr != nil {
+					c.Echo().Logger.Error(rErr)
+				}
+			}()
+
+			err = next(c)
+
+			if err != nil || !res.Committed || writer.buffer == nil || writer.streaming || writer.truncated {
+				return err
+			}
+
+			header := res.Header().Clone()
+			for _, name := range config.ExcludeHeaders {
+				header.Del(name)
+			}
+
+			stored = true
+			if cErr := config.Store.Complete(key, IdempotencyResponse{
+				StatusCode:  res.Status,
+				Header:      header,
+				Body:        append([]byte(nil), writer.buffer.Bytes()...),
+				Fingerprint: fingerprint,
+			}); cErr != nil {
+				c.Echo().Logger.Error(cErr)
+			}
+
+			return nil
+		}
+	}, nil
+}
+
+// DefaultIdempotencyKeyFunc scopes idempotency key by request method and route path
+func DefaultIdempotencyKeyFunc(c echox.Context, key string) string {
+	return c.Request().Method + " " + c.Path() + " " + key
+}
+
+// idempotencyFingerprint returns hash of request body. Body is restored so handler can still read it. Bodies larger than
+// maxBytes are not buffered and echox.ErrStatusRequestEntityTooLarge is returned.
+func idempotencyFingerprint(req *http.Request, maxBytes int64) (string, error) {
+	h := sha256.New()
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.ContentLength > maxBytes {
+			return "", echox.ErrStatusRequestEntityTooLarge
+		}
+
+		body, err := io.ReadAll(io.LimitReader(req.Body, maxBytes+1))
+		if err != nil {
+			return "", err
+		}
+
+		if int64(len(body)) > maxBytes {
+			return "", echox.ErrStatusRequestEntityTooLarge
+		}
+
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		h.Write(body)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func replayIdempotencyResponse(c echox.Context, cached *IdempotencyResponse) error {
+	header := c.Response().Header()
+	for k, v := range cached.Header {
+		header[k] = append([]string(nil), v...)
+	}
+
+	c.Response().WriteHeader(cached.StatusCode)
+	_, err := c.Response().Write(cached.Body)
+
+	return err
+}
+
+// IdempotencyMemoryStore is the built-in store implementation for Idempotency middleware
+type IdempotencyMemoryStore struct {
+	entries     map[string]*idempotencyEntry
+	mutex       sync.Mutex
+	expiresIn   time.Duration
+	lastCleanup time.Time
+
+	timeNow func() time.Time
+}
+
+type idempotencyEntry struct {
+	response  *IdempotencyResponse
+	expiresAt time.Time
+}
+
+// IdempotencyMemoryStoreConfig represents configuration for IdempotencyMemoryStore
+type IdempotencyMemoryStoreConfig struct {
+	ExpiresIn time.Duration // ExpiresIn is the duration cached response is replayed for
+}
+
+// DefaultIdempotencyMemoryStoreConfig provides default configuration values for IdempotencyMemoryStore
+var DefaultIdempotencyMemoryStoreConfig = IdempotencyMemoryStoreConfig{
+	ExpiresIn: 24 * time.Hour,
+}
+
+/*
+NewIdempotencyMemoryStore returns an instance of IdempotencyMemoryStore replaying cached responses for
+the provided duration.
+
+Example:
+
+	store := middleware.NewIdempotencyMemoryStore(24 * time.Hour)
+*/
+func NewIdempotencyMemoryStore(expiresIn time.Duration) (store *IdempotencyMemoryStore) {
+	return NewIdempotencyMemoryStoreWithConfig(IdempotencyMemoryStoreConfig{
+		ExpiresIn: expiresIn,
+	})
+}
+
+/*
+NewIdempotencyMemoryStoreWithConfig returns an instance of IdempotencyMemoryStore with the provided configuration.
+ExpiresIn will be set to default value if not provided.
+
+The build-in memory store keeps cached responses in process memory so it does not work with multiple application
+instances. For these setups other store implementations should be considered.
+*/
+func NewIdempotencyMemoryStoreWithConfig(config IdempotencyMemoryStoreConfig) (store *IdempotencyMemoryStore) {
+	store = &IdempotencyMemoryStore{}
+
+	store.expiresIn = config.ExpiresIn
+	if config.ExpiresIn == 0 {
+		store.expiresIn = DefaultIdempotencyMemoryStoreConfig.ExpiresIn
+	}
+
+	store.entries = make(map[string]*idempotencyEntry)
+	store.timeNow = time.Now
+	store.lastCleanup = store.timeNow()
+
+	return
+}
+
+// Begin implements IdempotencyStore.Begin
+func (store *IdempotencyMemoryStore) Begin(key string) (*IdempotencyResponse, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	now := store.timeNow()
+	if now.Sub(store.lastCleanup) > store.expiresIn {
+		store.cleanupExpiredEntries()
+	}
+
+	if entry, exists := store.entries[key]; exists {
+		if entry.response == nil {
+			return nil, ErrIdempotencyKeyInFlight
+		}
+
+		if now.Before(entry.expiresAt) {
+			return entry.response, nil
+		}
+	}
+
+	store.entries[key] = &idempotencyEntry{}
+
+	return nil, nil
+}
+
+// Complete implements IdempotencyStore.Complete
+func (store *IdempotencyMemoryStore) Complete(key string, response IdempotencyResponse) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.entries[key] = &idempotencyEntry{
+		response:  &response,
+		expiresAt: store.timeNow().Add(store.expiresIn),
+	}
+
+	return nil
+}
+
+// Release implements IdempotencyStore.Release
+func (store *IdempotencyMemoryStore) Release(key string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	delete(store.entries, key)
+
+	return nil
+}
+
+// cleanupExpiredEntries removes cached responses that are no longer replayed. In-flight reservations are kept.
+func (store *IdempotencyMemoryStore) cleanupExpiredEntries() {
+	now := store.timeNow()
+	for key, entry := range store.entries {
+		if entry.response != nil && !now.Before(entry.expiresAt) {
+			delete(store.entries, key)
+		}
+	}
+
+	store.lastCleanup = now
+}
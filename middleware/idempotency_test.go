@@ -0,0 +1,342 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
+)
+
+func TestIdempotency(t *testing.T) {
+	e := echox.New()
+
+	calls := 0
+	e.POST("/payments", func(c echox.Context) error {
+		calls++
+		c.Response().Header().Set("X-Payment-Id", strconv.Itoa(calls))
+
+		return c.String(http.StatusCreated, "payment "+strconv.Itoa(calls))
+	}, Idempotency(NewIdempotencyMemoryStore(time.Minute)))
+
+	testCases := []struct {
+		name         string
+		whenKey      string
+		expectStatus int
+		expectBody   string
+		expectHeader string
+	}{
+		{name: "first request with key is executed", whenKey: "key-1", expectStatus: http.StatusCreated, expectBody: "payment 1", expectHeader: "1"},
+		{name: "repeated request with key is replayed", whenKey: "key-1", expectStatus: http.StatusCreated, expectBody: "payment 1", expectHeader: "1"},
+		{name: "request with other key is executed", whenKey: "key-2", expectStatus: http.StatusCreated, expectBody: "payment 2", expectHeader: "2"},
+		{name: "request without key is executed", whenKey: "", expectStatus: http.StatusCreated, expectBody: "payment 3", expectHeader: "3"},
+		{name: "request without key is executed again", whenKey: "", expectStatus: http.StatusCreated, expectBody: "payment 4", expectHeader: "4"},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/payments", nil)
+		if tc.whenKey != "" {
+			req.Header.Set(echox.HeaderIdempotencyKey, tc.whenKey)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, tc.expectStatus, rec.Code, tc.name)
+		assert.Equal(t, tc.expectBody, rec.Body.String(), tc.name)
+		assert.Equal(t, tc.expectHeader, rec.Header().Get("X-Payment-Id"), tc.name)
+		assert.Equal(t, echox.MIMETextPlainCharsetUTF8, rec.Header().Get(echox.HeaderContentType), tc.name)
+	}
+	assert.Equal(t, 4, calls)
+}
+
+func TestIdempotency_inFlight(t *testing.T) {
+	e := echox.New()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	e.POST("/payments", func(c echox.Context) error {
+		close(started)
+		<-release
+
+		return c.String(http.StatusCreated, "payment")
+	}, Idempotency(NewIdempotencyMemoryStore(time.Minute)))
+
+	firstDone := make(chan *httptest.ResponseRecorder)
+	go func() {
+		req := httptest.NewRequest(http.MethodPost, "/payments", nil)
+		req.Header.Set(echox.HeaderIdempotencyKey, "key")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		firstDone <- rec
+	}()
+	<-started
+
+	req := httptest.NewRequest(http.MethodPost, "/payments", nil)
+	req.Header.Set(echox.HeaderIdempotencyKey, "key")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	close(release)
+	first := <-firstDone
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, "payment", first.Body.String())
+}
+
+func TestIdempotency_errorIsNotCached(t *testing.T) {
+	e := echox.New()
+
+	calls := 0
+	e.POST("/payments", func(c echox.Context) error {
+		calls++
+		if calls == 1 {
+			return errors.New("payment provider unavailable")
+		}
+
+		return c.String(http.StatusCreated, "payment")
+	}, Idempotency(NewIdempotencyMemoryStore(time.Minute)))
+
+	for _, expectStatus := range []int{http.StatusInternalServerError, http.StatusCreated, http.StatusCreated} {
+		req := httptest.NewRequest(http.MethodPost, "/payments", nil)
+		req.Header.Set(echox.HeaderIdempotencyKey, "key")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, expectStatus, rec.Code)
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotency_maxBodySize(t *testing.T) {
+	e := echox.New()
+
+	calls := 0
+	e.POST("/payments", func(c echox.Context) error {
+		calls++
+		return c.String(http.StatusCreated, strings.Repeat("x", 20))
+	}, IdempotencyWithConfig(IdempotencyConfig{
+		Store:       NewIdempotencyMemoryStore(time.Minute),
+		MaxBodySize: 10,
+	}))
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/payments", nil)
+		req.Header.Set(echox.HeaderIdempotencyKey, "key")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, strings.Repeat("x", 20), rec.Body.String())
+	}
+	assert.Equal(t, 2, calls) // too large response is not cached
+}
+
+func TestIdempotency_keyScopedByRoute(t *testing.T) {
+	e := echox.New()
+	mw := Idempotency(NewIdempotencyMemoryStore(time.Minute))
+	e.POST("/payments", func(c echox.Context) error {
+		return c.String(http.StatusCreated, "payment")
+	}, mw)
+	e.POST("/refunds", func(c echox.Context) error {
+		return c.String(http.StatusCreated, "refund")
+	}, mw)
+
+	for _, tc := range []struct{ path, expectBody string }{{"/payments", "payment"}, {"/refunds", "refund"}} {
+		req := httptest.NewRequest(http.MethodPost, tc.path, nil)
+		req.Header.Set(echox.HeaderIdempotencyKey, "key")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, tc.expectBody, rec.Body.String())
+	}
+}
+
+func TestIdempotency_keyFunc(t *testing.T) {
+	e := echox.New()
+
+	calls := 0
+	e.POST("/payments", func(c echox.Context) error {
+		calls++
+		return c.String(http.StatusCreated, "payment "+strconv.Itoa(calls))
+	}, IdempotencyWithConfig(IdempotencyConfig{
+		Store: NewIdempotencyMemoryStore(time.Minute),
+		KeyFunc: func(c echox.Context, key string) string {
+			return DefaultIdempotencyKeyFunc(c, key) + " " + c.Request().Header.Get("X-Tenant")
+		},
+	}))
+
+	for _, tc := range []struct{ tenant, expectBody string }{{"a", "payment 1"}, {"b", "payment 2"}, {"a", "payment 1"}} {
+		req := httptest.NewRequest(http.MethodPost, "/payments", nil)
+		req.Header.Set(echox.HeaderIdempotencyKey, "key")
+		req.Header.Set("X-Tenant", tc.tenant)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, tc.expectBody, rec.Body.String())
+	}
+}
+
+func TestIdempotency_differentPayload(t *testing.T) {
+	e := echox.New()
+
+	calls := 0
+	e.POST("/payments", func(c echox.Context) error {
+		calls++
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return err
+		}
+
+		return c.String(http.StatusCreated, "payment "+string(body))
+	}, Idempotency(NewIdempotencyMemoryStore(time.Minute)))
+
+	testCases := []struct {
+		whenBody     string
+		expectStatus int
+		expectBody   string
+	}{
+		{whenBody: `{"amount":10}`, expectStatus: http.StatusCreated, expectBody: `payment {"amount":10}`},
+		{whenBody: `{"amount":10}`, expectStatus: http.StatusCreated, expectBody: `payment {"amount":10}`},
+		{whenBody: `{"amount":99}`, expectStatus: http.StatusUnprocessableEntity, expectBody: `{"message":"idempotency key was already used with a different request payload"}` + "\n"},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/payments", strings.NewReader(tc.whenBody))
+		req.Header.Set(echox.HeaderIdempotencyKey, "key")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, tc.expectStatus, rec.Code)
+		assert.Equal(t, tc.expectBody, rec.Body.String())
+	}
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotency_maxRequestBodySize(t *testing.T) {
+	e := echox.New()
+
+	calls := 0
+	e.POST("/payments", func(c echox.Context) error {
+		calls++
+		return c.String(http.StatusCreated, "payment")
+	}, IdempotencyWithConfig(IdempotencyConfig{
+		Store:              NewIdempotencyMemoryStore(time.Minute),
+		MaxRequestBodySize: 10,
+	}))
+
+	testCases := []struct {
+		name         string
+		whenBody     io.Reader
+		expectStatus int
+	}{
+		{name: "ok, body within limit", whenBody: strings.NewReader("0123456789"), expectStatus: http.StatusCreated},
+		{name: "nok, body with content length over limit", whenBody: strings.NewReader(strings.Repeat("x", 11)), expectStatus: http.StatusRequestEntityTooLarge},
+		{name: "nok, body of unknown length over limit", whenBody: io.MultiReader(strings.NewReader(strings.Repeat("x", 11))), expectStatus: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/payments", tc.whenBody)
+		req.Header.Set(echox.HeaderIdempotencyKey, "key-"+tc.name)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, tc.expectStatus, rec.Code, tc.name)
+	}
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotency_excludeHeaders(t *testing.T) {
+	e := echox.New()
+
+	calls := 0
+	e.POST("/payments", func(c echox.Context) error {
+		calls++
+		c.Response().Header().Set(echox.HeaderXRequestID, "req-"+strconv.Itoa(calls))
+		c.SetCookie(&http.Cookie{Name: "session", Value: "secret"})
+		c.Response().Header().Set("X-Payment-Id", "1")
+
+		return c.String(http.StatusCreated, "payment")
+	}, Idempotency(NewIdempotencyMemoryStore(time.Minute)))
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/payments", nil)
+		req.Header.Set(echox.HeaderIdempotencyKey, "key")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, "1", rec.Header().Get("X-Payment-Id"))
+		if i == 0 {
+			assert.Equal(t, "req-1", rec.Header().Get(echox.HeaderXRequestID))
+			assert.NotEmpty(t, rec.Header().Get(echox.HeaderSetCookie))
+			continue
+		}
+		assert.Empty(t, rec.Header().Get(echox.HeaderXRequestID))
+		assert.Empty(t, rec.Header().Get(echox.HeaderSetCookie))
+	}
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotencyConfig_ToMiddleware(t *testing.T) {
+	_, err := IdempotencyConfig{}.ToMiddleware()
+	assert.EqualError(t, err, "echo idempotency middleware requires a store")
+
+	_, err = IdempotencyConfig{Store: NewIdempotencyMemoryStore(time.Minute), MaxBodySize: -1}.ToMiddleware()
+	assert.EqualError(t, err, "echo idempotency middleware max body size can not be negative")
+
+	_, err = IdempotencyConfig{Store: NewIdempotencyMemoryStore(time.Minute), MaxRequestBodySize: -1}.ToMiddleware()
+	assert.EqualError(t, err, "echo idempotency middleware max request body size can not be negative")
+}
+
+func TestIdempotencyMemoryStore(t *testing.T) {
+	store := NewIdempotencyMemoryStoreWithConfig(IdempotencyMemoryStoreConfig{ExpiresIn: time.Minute})
+	now := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	store.timeNow = func() time.Time {
+		return now
+	}
+
+	cached, err := store.Begin("key")
+	assert.NoError(t, err)
+	assert.Nil(t, cached)
+
+	_, err = store.Begin("key")
+	assert.ErrorIs(t, err, ErrIdempotencyKeyInFlight)
+
+	assert.NoError(t, store.Complete("key", IdempotencyResponse{StatusCode: http.StatusCreated, Body: []byte("ok")}))
+
+	now = now.Add(59 * time.Second)
+	cached, err = store.Begin("key")
+	assert.NoError(t, err)
+	assert.Equal(t, &IdempotencyResponse{StatusCode: http.StatusCreated, Body: []byte("ok")}, cached)
+
+	now = now.Add(2 * time.Second) // expired
+	cached, err = store.Begin("key")
+	assert.NoError(t, err)
+	assert.Nil(t, cached)
+
+	assert.NoError(t, store.Release("key"))
+	cached, err = store.Begin("key")
+	assert.NoError(t, err)
+	assert.Nil(t, cached)
+}
+
+func TestIdempotencyMemoryStore_cleanupExpiredEntries(t *testing.T) {
+	store := NewIdempotencyMemoryStore(time.Minute)
+	store.entries = map[string]*idempotencyEntry{
+		"in-flight": {},
+		"valid":     {response: &IdempotencyResponse{}, expiresAt: time.Now().Add(time.Minute)},
+		"expired":   {response: &IdempotencyResponse{}, expiresAt: time.Now().Add(-time.Minute)},
+	}
+
+	store.cleanupExpiredEntries()
+
+	assert.Contains(t, store.entries, "in-flight")
+	assert.Contains(t, store.entries, "valid")
+	assert.NotContains(t, store.entries, "expired")
+}
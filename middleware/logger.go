@@ -31,6 +31,8 @@ type LoggerConfig struct {
 	// - time_rfc3339_nano
 	// - time_custom
 	// - id (Request ID)
+	// - span_id (Span ID, see SpanID middleware)
+	// - parent_span_id (Parent span ID, see SpanID middleware)
 	// - remote_ip
 	// - uri
 	// - host
@@ -163,6 +165,10 @@ func (config LoggerConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 					}
 
 					return buf.WriteString(id)
+				case "span_id":
+					return buf.WriteString(contextString(c, ContextKeySpanID))
+				case "parent_span_id":
+					return buf.WriteString(contextString(c, ContextKeyParentSpanID))
 				case "remote_ip":
 					return buf.WriteString(c.RealIP())
 				case "host":
@@ -211,14 +217,9 @@ func (config LoggerConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 				case "latency_human":
 					return buf.WriteString(stop.Sub(start).String())
 				case "bytes_in":
-					cl := req.Header.Get(echox.HeaderContentLength)
-					if cl == "" {
-						cl = "0"
-					}
-
-					return buf.WriteString(cl)
+					return buf.WriteString(strconv.FormatInt(c.BytesIn(), 10))
 				case "bytes_out":
-					return buf.WriteString(strconv.FormatInt(res.Size, 10))
+					return buf.WriteString(strconv.FormatInt(c.BytesOut(), 10))
 				default:
 					switch {
 					case strings.HasPrefix(tag, "header:"):
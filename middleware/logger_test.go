@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -318,3 +319,29 @@ func TestLoggerCustomTagFunc(t *testing.T) {
 
 	assert.Equal(t, `{"method":"GET","tag":"my-value"}`+"\n", buf.String())
 }
+
+func TestLoggerTemplate_bytesInChunkedBody(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	e := echox.New()
+	e.Logger = &testLogger{output: buf}
+	e.Use(LoggerWithConfig(LoggerConfig{
+		Format: `${bytes_in} ${bytes_out}`,
+		Output: buf,
+	}))
+	e.POST("/", func(c echox.Context) error {
+		b, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, strconv.Itoa(len(b)))
+	})
+
+	// io.MultiReader hides body length so request is sent without Content-Length
+	req := httptest.NewRequest(http.MethodPost, "/", io.MultiReader(strings.NewReader(strings.Repeat("a", 1234))))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, "1234", rec.Body.String())
+	assert.Equal(t, "1234 4", buf.String())
+}
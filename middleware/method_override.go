@@ -1,7 +1,10 @@
 package middleware
 
 import (
+	"bytes"
+	"io"
 	"net/http"
+	"slices"
 
 	"github.com/theopenlane/echox"
 )
@@ -14,6 +17,23 @@ type MethodOverrideConfig struct {
 	// Getter is a function that gets overridden method from the request.
 	// Optional. Default values MethodFromHeader(echox.HeaderXHTTPMethodOverride).
 	Getter MethodOverrideGetter
+
+	// Getters are functions that get overridden method from the request. They are evaluated in order until one of
+	// them returns non-empty method (i.e. header first, then form field). When set, Getter is not used.
+	// Optional. Default value nil.
+	Getters []MethodOverrideGetter
+
+	// AllowedMethods lists methods that POST request is allowed to be overridden to. Overridden method not in this
+	// list is ignored and request method stays POST. Methods are compared case-sensitively.
+	// Optional. Default value DefaultMethodOverrideAllowedMethods.
+	AllowedMethods []string
+}
+
+// DefaultMethodOverrideAllowedMethods is the default list of methods POST request can be overridden to.
+var DefaultMethodOverrideAllowedMethods = []string{
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
 }
 
 // MethodOverrideGetter is a function that gets overridden method from the request
@@ -21,15 +41,17 @@ type MethodOverrideGetter func(echox.Context) string
 
 // DefaultMethodOverrideConfig is the default MethodOverride middleware config.
 var DefaultMethodOverrideConfig = MethodOverrideConfig{
-	Skipper: DefaultSkipper,
-	Getter:  MethodFromHeader(echox.HeaderXHTTPMethodOverride),
+	Skipper:        DefaultSkipper,
+	Getter:         MethodFromHeader(echox.HeaderXHTTPMethodOverride),
+	AllowedMethods: DefaultMethodOverrideAllowedMethods,
 }
 
 // MethodOverride returns a MethodOverride middleware.
 // MethodOverride  middleware checks for the overridden method from the request and
 // uses it instead of the original method.
 //
-// For security reasons, only `POST` method can be overridden.
+// For security reasons, only `POST` method can be overridden and only to one of
+// DefaultMethodOverrideAllowedMethods.
 func MethodOverride() echox.MiddlewareFunc {
 	return MethodOverrideWithConfig(DefaultMethodOverrideConfig)
 }
@@ -46,8 +68,16 @@ func (config MethodOverrideConfig) ToMiddleware() (echox.MiddlewareFunc, error)
 		config.Skipper = DefaultMethodOverrideConfig.Skipper
 	}
 
-	if config.Getter == nil {
-		config.Getter = DefaultMethodOverrideConfig.Getter
+	getters := config.Getters
+	if len(getters) == 0 {
+		if config.Getter == nil {
+			config.Getter = DefaultMethodOverrideConfig.Getter
+		}
+		getters = []MethodOverrideGetter{config.Getter}
+	}
+
+	if len(config.AllowedMethods) == 0 {
+		config.AllowedMethods = DefaultMethodOverrideAllowedMethods
 	}
 
 	return func(next echox.HandlerFunc) echox.HandlerFunc {
@@ -58,8 +88,14 @@ func (config MethodOverrideConfig) ToMiddleware() (echox.MiddlewareFunc, error)
 
 			req := c.Request()
 			if req.Method == http.MethodPost {
-				m := config.Getter(c)
-				if m != "" {
+				m := ""
+				for _, getter := range getters {
+					if m = getter(c); m != "" {
+						break
+					}
+				}
+
+				if m != "" && slices.Contains(config.AllowedMethods, m) {
 					req.Method = m
 				}
 			}
@@ -92,3 +128,31 @@ func MethodFromQuery(param string) MethodOverrideGetter {
 		return c.QueryParam(param)
 	}
 }
+
+// MethodFromTrailer is a `MethodOverrideGetter` that gets overridden method from
+// the request trailer (i.e. sent by gRPC-web style clients).
+//
+// Trailers are available only after request body has been read to the end, so the getter reads the body into memory
+// and replaces it with a reader over read bytes so handlers can still read the body. At most maxBytes of body are
+// buffered; for larger bodies no method is returned and the body is left intact for the handler. The limit is needed
+// as MethodOverride runs with `Echo#Pre` before BodyLimit middleware could limit the body. The method is read from
+// trailer only when request declares trailers with `Trailer` header.
+func MethodFromTrailer(name string, maxBytes int64) MethodOverrideGetter {
+	return func(c echox.Context) string {
+		req := c.Request()
+		if len(req.Trailer) == 0 || req.Body == nil {
+			return ""
+		}
+
+		// bytes read from the original body are captured by tee as MaxBytesReader drops the byte that exceeds limit
+		read := new(bytes.Buffer)
+		_, err := io.ReadAll(http.MaxBytesReader(c.Response(), io.NopCloser(io.TeeReader(req.Body, read)), maxBytes))
+		req.Body = readCloser{Reader: io.MultiReader(read, req.Body), Closer: req.Body}
+
+		if err != nil {
+			return ""
+		}
+
+		return req.Trailer.Get(name)
+	}
+}
@@ -1,9 +1,12 @@
 package middleware
 
 import (
+	"bufio"
 	"bytes"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -92,3 +95,191 @@ func TestMethodOverride_ignoreGet(t *testing.T) {
 
 	assert.Equal(t, http.MethodGet, req.Method)
 }
+
+func TestMethodOverride_allowedMethods(t *testing.T) {
+	var testCases = []struct {
+		name         string
+		givenConfig  MethodOverrideConfig
+		whenOverride string
+		expectMethod string
+	}{
+		{
+			name:         "ok, default allows PATCH",
+			whenOverride: http.MethodPatch,
+			expectMethod: http.MethodPatch,
+		},
+		{
+			name:         "nok, unknown method is ignored",
+			whenOverride: "FOO",
+			expectMethod: http.MethodPost,
+		},
+		{
+			name:         "nok, TRACE is not allowed by default",
+			whenOverride: http.MethodTrace,
+			expectMethod: http.MethodPost,
+		},
+		{
+			name:         "nok, lowercase method is not allowed",
+			whenOverride: "delete",
+			expectMethod: http.MethodPost,
+		},
+		{
+			name:         "ok, custom allowed method",
+			givenConfig:  MethodOverrideConfig{AllowedMethods: []string{"FOO"}},
+			whenOverride: "FOO",
+			expectMethod: "FOO",
+		},
+		{
+			name:         "nok, custom list replaces defaults",
+			givenConfig:  MethodOverrideConfig{AllowedMethods: []string{"FOO"}},
+			whenOverride: http.MethodDelete,
+			expectMethod: http.MethodPost,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			m, err := tc.givenConfig.ToMiddleware()
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.Header.Set(echox.HeaderXHTTPMethodOverride, tc.whenOverride)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err = m(func(c echox.Context) error { return nil })(c)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectMethod, req.Method)
+		})
+	}
+}
+
+func TestMethodOverride_getters(t *testing.T) {
+	var testCases = []struct {
+		name         string
+		whenHeader   string
+		whenForm     string
+		expectMethod string
+	}{
+		{
+			name:         "ok, header is empty and form param provides override",
+			whenForm:     http.MethodDelete,
+			expectMethod: http.MethodDelete,
+		},
+		{
+			name:         "ok, header is used before form param",
+			whenHeader:   http.MethodPut,
+			whenForm:     http.MethodDelete,
+			expectMethod: http.MethodPut,
+		},
+		{
+			name:         "ok, no getter returns method",
+			expectMethod: http.MethodPost,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			m, err := MethodOverrideConfig{
+				Getters: []MethodOverrideGetter{
+					MethodFromHeader(echox.HeaderXHTTPMethodOverride),
+					MethodFromForm("_method"),
+				},
+			}.ToMiddleware()
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("_method="+tc.whenForm)))
+			req.Header.Set(echox.HeaderContentType, echox.MIMEApplicationForm)
+			if tc.whenHeader != "" {
+				req.Header.Set(echox.HeaderXHTTPMethodOverride, tc.whenHeader)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err = m(func(c echox.Context) error { return nil })(c)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectMethod, req.Method)
+		})
+	}
+}
+
+func TestMethodOverride_trailer(t *testing.T) {
+	var testCases = []struct {
+		name         string
+		whenMaxBytes int64
+		whenRequest  string
+		expectMethod string
+		expectBody   string
+	}{
+		{
+			name: "ok, method from trailer",
+			whenRequest: "POST / HTTP/1.1\r\n" +
+				"Host: example.com\r\n" +
+				"Transfer-Encoding: chunked\r\n" +
+				"Trailer: X-HTTP-Method-Override\r\n" +
+				"\r\n" +
+				"5\r\nhello\r\n" +
+				"0\r\n" +
+				"X-HTTP-Method-Override: DELETE\r\n" +
+				"\r\n",
+			expectMethod: http.MethodDelete,
+			expectBody:   "hello",
+		},
+		{
+			name:         "ok, body larger than max bytes is not buffered",
+			whenMaxBytes: 3,
+			whenRequest: "POST / HTTP/1.1\r\n" +
+				"Host: example.com\r\n" +
+				"Transfer-Encoding: chunked\r\n" +
+				"Trailer: X-HTTP-Method-Override\r\n" +
+				"\r\n" +
+				"5\r\nhello\r\n" +
+				"0\r\n" +
+				"X-HTTP-Method-Override: DELETE\r\n" +
+				"\r\n",
+			expectMethod: http.MethodPost,
+			expectBody:   "hello",
+		},
+		{
+			name: "ok, request without declared trailers is not read",
+			whenRequest: "POST / HTTP/1.1\r\n" +
+				"Host: example.com\r\n" +
+				"Content-Length: 5\r\n" +
+				"\r\n" +
+				"hello",
+			expectMethod: http.MethodPost,
+			expectBody:   "hello",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.ReadRequest(bufio.NewReader(strings.NewReader(tc.whenRequest)))
+			assert.NoError(t, err)
+
+			e := echox.New()
+			maxBytes := tc.whenMaxBytes
+			if maxBytes == 0 {
+				maxBytes = 1024
+			}
+			m, err := MethodOverrideConfig{Getter: MethodFromTrailer(echox.HeaderXHTTPMethodOverride, maxBytes)}.ToMiddleware()
+			assert.NoError(t, err)
+
+			body := ""
+			c := e.NewContext(req, httptest.NewRecorder())
+			err = m(func(c echox.Context) error {
+				b, err := io.ReadAll(c.Request().Body)
+				body = string(b)
+				return err
+			})(c)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectMethod, req.Method)
+			assert.Equal(t, tc.expectBody, body)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/theopenlane/echox"
+)
+
+// MetricsSink receives observations of served requests. Implement it to export metrics to the monitoring system
+// of your choice (i.e. Prometheus histogram labeled by route, method and status).
+type MetricsSink interface {
+	// ObserveRequest is called after request is served. Route is route path template (i.e. `/users/:id`) and not the
+	// request URL, so number of distinct label values stays bounded. It is called concurrently from multiple
+	// goroutines.
+	ObserveRequest(route, method string, status int, dur time.Duration)
+}
+
+// MetricsConfig defines the config for Metrics middleware.
+type MetricsConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// Sink receives observation of every request.
+	// Required.
+	Sink MetricsSink
+
+	// timeNow is used in tests
+	timeNow func() time.Time
+}
+
+// Metrics returns a Metrics middleware that reports route, method, response status and duration of every request to
+// the sink (RED metrics - rate, errors, duration).
+//
+// When handler chain returns an error, status is taken from echox.HTTPError (500 for other errors) as the response is
+// written later by HTTPErrorHandler.
+func Metrics(sink MetricsSink) echox.MiddlewareFunc {
+	return MetricsWithConfig(MetricsConfig{Sink: sink})
+}
+
+// MetricsWithConfig returns a Metrics middleware with config or panics on invalid configuration.
+// See: `Metrics()`.
+func MetricsWithConfig(config MetricsConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts MetricsConfig to middleware or returns an error for invalid configuration
+func (config MetricsConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if config.Skipper == nil {
+		config.Skipper = DefaultSkipper
+	}
+
+	if config.Sink == nil {
+		return nil, errors.New("echo metrics middleware requires sink")
+	}
+
+	now := time.Now
+	if config.timeNow != nil {
+		now = config.timeNow
+	}
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			start := now()
+			err := next(c)
+			dur := now().Sub(start)
+
+			res := c.Response()
+			status := res.Status
+
+			if err != nil && !res.Committed {
+				status = http.StatusInternalServerError
+
+				var httpErr *echox.HTTPError
+				if errors.As(err, &httpErr) {
+					status = httpErr.Code
+				}
+			}
+
+			config.Sink.ObserveRequest(c.Path(), c.Request().Method, status, dur)
+
+			return err
+		}
+	}, nil
+}
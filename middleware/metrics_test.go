@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
+)
+
+type metricsObservation struct {
+	route  string
+	method string
+	status int
+	dur    time.Duration
+}
+
+type fakeMetricsSink struct {
+	mu           sync.Mutex
+	observations []metricsObservation
+}
+
+func (s *fakeMetricsSink) ObserveRequest(route, method string, status int, dur time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.observations = append(s.observations, metricsObservation{route: route, method: method, status: status, dur: dur})
+}
+
+func TestMetrics(t *testing.T) {
+	var testCases = []struct {
+		name       string
+		whenMethod string
+		whenURL    string
+		expect     metricsObservation
+	}{
+		{
+			name:       "ok, route template is used as label",
+			whenMethod: http.MethodGet,
+			whenURL:    "/users/42",
+			expect:     metricsObservation{route: "/users/:id", method: http.MethodGet, status: http.StatusOK, dur: time.Second},
+		},
+		{
+			name:       "ok, status of returned HTTPError",
+			whenMethod: http.MethodPost,
+			whenURL:    "/users/42",
+			expect:     metricsObservation{route: "/users/:id", method: http.MethodPost, status: http.StatusConflict, dur: time.Second},
+		},
+		{
+			name:       "ok, other errors are reported as 500",
+			whenMethod: http.MethodDelete,
+			whenURL:    "/users/42",
+			expect:     metricsObservation{route: "/users/:id", method: http.MethodDelete, status: http.StatusInternalServerError, dur: time.Second},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sink := &fakeMetricsSink{}
+			start := time.Unix(1700000000, 0)
+			calls := 0
+
+			e := echox.New()
+			e.Use(MetricsWithConfig(MetricsConfig{
+				Sink: sink,
+				timeNow: func() time.Time {
+					calls++
+					return start.Add(time.Duration(calls-1) * time.Second)
+				},
+			}))
+			e.GET("/users/:id", func(c echox.Context) error {
+				return c.String(http.StatusOK, "user")
+			})
+			e.POST("/users/:id", func(c echox.Context) error {
+				return echox.NewHTTPError(http.StatusConflict, "exists")
+			})
+			e.DELETE("/users/:id", func(c echox.Context) error {
+				return errors.New("db down")
+			})
+
+			req := httptest.NewRequest(tc.whenMethod, tc.whenURL, nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, []metricsObservation{tc.expect}, sink.observations)
+		})
+	}
+}
+
+func TestMetricsWithConfig_missingSink(t *testing.T) {
+	mw, err := MetricsConfig{}.ToMiddleware()
+
+	assert.EqualError(t, err, "echo metrics middleware requires sink")
+	assert.Nil(t, mw)
+}
@@ -57,6 +57,23 @@ func rewriteURL(rewriteRegex map[*regexp.Regexp]string, req *http.Request) error
 		return nil
 	}
 
+	target, ok := matchRewriteRules(rewriteRegex, requestPathURI(req))
+	if !ok {
+		return nil
+	}
+
+	url, err := req.URL.Parse(target)
+	if err != nil {
+		return err
+	}
+
+	req.URL = url
+
+	return nil
+}
+
+// requestPathURI returns RequestURI of the request without scheme and host parts.
+func requestPathURI(req *http.Request) string {
 	// Depending how HTTP request is sent RequestURI could contain Scheme://Host/path or be just /path.
 	// We only want to use path part for rewriting and therefore trim prefix if it exists
 	rawURI := req.RequestURI
@@ -75,20 +92,18 @@ func rewriteURL(rewriteRegex map[*regexp.Regexp]string, req *http.Request) error
 		}
 	}
 
+	return rawURI
+}
+
+// matchRewriteRules returns target of the first rule matching rawURI with captured values substituted.
+func matchRewriteRules(rewriteRegex map[*regexp.Regexp]string, rawURI string) (string, bool) {
 	for k, v := range rewriteRegex {
 		if replacer := captureTokens(k, rawURI); replacer != nil {
-			url, err := req.URL.Parse(replacer.Replace(v))
-			if err != nil {
-				return err
-			}
-
-			req.URL = url
-
-			return nil // rewrite only once
+			return replacer.Replace(v), true // rewrite only once
 		}
 	}
 
-	return nil
+	return "", false
 }
 
 // DefaultSkipper returns false which processes the middleware.
@@ -96,6 +111,80 @@ func DefaultSkipper(echox.Context) bool {
 	return false
 }
 
+// AndSkipper returns a Skipper that skips the middleware only when all given skippers return true.
+// Skippers are evaluated in order and evaluation stops at the first one returning false. When no skippers
+// are given the middleware is never skipped.
+func AndSkipper(skippers ...Skipper) Skipper {
+	return func(c echox.Context) bool {
+		for _, s := range skippers {
+			if !s(c) {
+				return false
+			}
+		}
+
+		return len(skippers) > 0
+	}
+}
+
+// OrSkipper returns a Skipper that skips the middleware when any of the given skippers returns true.
+// Skippers are evaluated in order and evaluation stops at the first one returning true.
+func OrSkipper(skippers ...Skipper) Skipper {
+	return func(c echox.Context) bool {
+		for _, s := range skippers {
+			if s(c) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// NotSkipper returns a Skipper that negates the result of the given skipper.
+func NotSkipper(skipper Skipper) Skipper {
+	return func(c echox.Context) bool {
+		return !skipper(c)
+	}
+}
+
+// OnlyMethods returns a middleware that runs given middleware only for requests with one of the listed HTTP methods.
+// Requests with other methods skip the middleware and continue to next handler in chain.
+//
+// Example:
+//
+//	e.Use(middleware.OnlyMethods([]string{http.MethodPost, http.MethodPut, http.MethodPatch}, middleware.BodyLimit(1024*1024)))
+//
+// Note: do not restrict middlewares that need to run on safe methods this way, i.e. CSRF issues its token cookie on
+// GET requests so limiting it to unsafe methods makes every request fail token validation.
+func OnlyMethods(methods []string, mw echox.MiddlewareFunc) echox.MiddlewareFunc {
+	return methodsMiddleware(methods, mw, true)
+}
+
+// ExceptMethods returns a middleware that runs given middleware for all requests except the ones with one of the
+// listed HTTP methods.
+func ExceptMethods(methods []string, mw echox.MiddlewareFunc) echox.MiddlewareFunc {
+	return methodsMiddleware(methods, mw, false)
+}
+
+func methodsMiddleware(methods []string, mw echox.MiddlewareFunc, only bool) echox.MiddlewareFunc {
+	set := make(map[string]struct{}, len(methods))
+	for _, m := range methods {
+		set[strings.ToUpper(m)] = struct{}{}
+	}
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		wrapped := mw(next)
+
+		return func(c echox.Context) error {
+			if _, ok := set[c.Request().Method]; ok == only {
+				return wrapped(c)
+			}
+
+			return next(c)
+		}
+	}
+}
+
 func toMiddlewareOrPanic(config echox.MiddlewareConfigurator) echox.MiddlewareFunc {
 	mw, err := config.ToMiddleware()
 	if err != nil {
@@ -104,3 +193,19 @@ func toMiddlewareOrPanic(config echox.MiddlewareConfigurator) echox.MiddlewareFu
 
 	return mw
 }
+
+// matchesMediaRange checks if media type matches one of media ranges (i.e. `application/json`, `application/*`, `*/*`).
+// Used by middlewares filtering requests or responses by content type.
+func matchesMediaRange(mediaType string, ranges []string) bool {
+	for _, r := range ranges {
+		if r == "*/*" || r == mediaType {
+			return true
+		}
+
+		if prefix, ok := strings.CutSuffix(r, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+
+	return false
+}
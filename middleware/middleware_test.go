@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
 )
 
 func TestRewriteURL(t *testing.T) {
@@ -92,3 +94,150 @@ func TestRewriteURL(t *testing.T) {
 		})
 	}
 }
+
+func TestSkipperCombinators(t *testing.T) {
+	calls := make([]string, 0)
+	skipper := func(name string, result bool) Skipper {
+		return func(c echox.Context) bool {
+			calls = append(calls, name)
+			return result
+		}
+	}
+
+	var testCases = []struct {
+		name        string
+		whenSkipper func() Skipper
+		expect      bool
+		expectCalls []string
+	}{
+		{
+			name:        "and, all true",
+			whenSkipper: func() Skipper { return AndSkipper(skipper("a", true), skipper("b", true)) },
+			expect:      true,
+			expectCalls: []string{"a", "b"},
+		},
+		{
+			name:        "and, short-circuits on first false",
+			whenSkipper: func() Skipper { return AndSkipper(skipper("a", false), skipper("b", true)) },
+			expect:      false,
+			expectCalls: []string{"a"},
+		},
+		{
+			name:        "and, no skippers",
+			whenSkipper: func() Skipper { return AndSkipper() },
+			expect:      false,
+			expectCalls: []string{},
+		},
+		{
+			name:        "or, short-circuits on first true",
+			whenSkipper: func() Skipper { return OrSkipper(skipper("a", true), skipper("b", false)) },
+			expect:      true,
+			expectCalls: []string{"a"},
+		},
+		{
+			name:        "or, all false",
+			whenSkipper: func() Skipper { return OrSkipper(skipper("a", false), skipper("b", false)) },
+			expect:      false,
+			expectCalls: []string{"a", "b"},
+		},
+		{
+			name:        "or, no skippers",
+			whenSkipper: func() Skipper { return OrSkipper() },
+			expect:      false,
+			expectCalls: []string{},
+		},
+		{
+			name:        "not",
+			whenSkipper: func() Skipper { return NotSkipper(skipper("a", false)) },
+			expect:      true,
+			expectCalls: []string{"a"},
+		},
+		{
+			name:        "not, with default skipper",
+			whenSkipper: func() Skipper { return NotSkipper(DefaultSkipper) },
+			expect:      true,
+			expectCalls: []string{},
+		},
+		{
+			name: "nested",
+			whenSkipper: func() Skipper {
+				return OrSkipper(AndSkipper(skipper("a", true), skipper("b", false)), NotSkipper(skipper("c", false)))
+			},
+			expect:      true,
+			expectCalls: []string{"a", "b", "c"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls = make([]string, 0)
+			e := echox.New()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+
+			assert.Equal(t, tc.expect, tc.whenSkipper()(c))
+			assert.Equal(t, tc.expectCalls, calls)
+		})
+	}
+}
+
+func TestMethodsMiddleware(t *testing.T) {
+	var testCases = []struct {
+		name         string
+		givenOnly    bool
+		whenMethod   string
+		expectCalled bool
+	}{
+		{
+			name:         "only, listed method runs middleware",
+			givenOnly:    true,
+			whenMethod:   http.MethodPost,
+			expectCalled: true,
+		},
+		{
+			name:         "only, other method skips middleware",
+			givenOnly:    true,
+			whenMethod:   http.MethodGet,
+			expectCalled: false,
+		},
+		{
+			name:         "except, listed method skips middleware",
+			whenMethod:   http.MethodPut,
+			expectCalled: false,
+		},
+		{
+			name:         "except, other method runs middleware",
+			whenMethod:   http.MethodGet,
+			expectCalled: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			called := false
+			mw := func(next echox.HandlerFunc) echox.HandlerFunc {
+				return func(c echox.Context) error {
+					called = true
+					return next(c)
+				}
+			}
+
+			methods := []string{http.MethodPost, "put"}
+			wrapped := ExceptMethods(methods, mw)
+			if tc.givenOnly {
+				wrapped = OnlyMethods(methods, mw)
+			}
+
+			req := httptest.NewRequest(tc.whenMethod, "/", nil)
+			rec := httptest.NewRecorder()
+			c := echox.New().NewContext(req, rec)
+
+			err := wrapped(func(c echox.Context) error {
+				return c.String(http.StatusOK, "test")
+			})(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectCalled, called)
+			assert.Equal(t, "test", rec.Body.String())
+		})
+	}
+}
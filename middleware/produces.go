@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/theopenlane/echox"
+)
+
+// ProducesConfig defines the config for Produces middleware.
+type ProducesConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// Types are media types handler can respond with, in order of preference (i.e. `application/json`).
+	// Required.
+	Types []string
+}
+
+// ContextKeyProduces is context key Produces middleware stores media type negotiated from `Accept` header into.
+const ContextKeyProduces = "produces_media_type"
+
+// Produces returns a Produces middleware that negotiates response media type from request `Accept` header and
+// rejects requests with "406 - Not Acceptable" when none of given types is acceptable. Negotiated type is stored in
+// context under ContextKeyProduces. Requests without `Accept` header accept the first type.
+func Produces(types ...string) echox.MiddlewareFunc {
+	return ProducesWithConfig(ProducesConfig{Types: types})
+}
+
+// ProducesWithConfig returns a Produces middleware with config or panics on invalid configuration.
+// See: `Produces()`.
+func ProducesWithConfig(config ProducesConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts ProducesConfig to middleware or returns an error for invalid configuration
+func (config ProducesConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if config.Skipper == nil {
+		config.Skipper = DefaultSkipper
+	}
+
+	if len(config.Types) == 0 {
+		return nil, errors.New("echo produces middleware requires media types")
+	}
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			mediaType := echox.NegotiateMediaType(c.Request().Header.Get(echox.HeaderAccept), config.Types...)
+			if mediaType == "" {
+				return echox.ErrNotAcceptable
+			}
+
+			c.Set(ContextKeyProduces, mediaType)
+
+			return next(c)
+		}
+	}, nil
+}
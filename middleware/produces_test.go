@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
+)
+
+func TestProduces(t *testing.T) {
+	var testCases = []struct {
+		name            string
+		givenTypes      []string
+		whenAccept      string
+		expectStatus    int
+		expectMediaType string
+	}{
+		{
+			name:         "nok, xml requested from JSON-only endpoint",
+			givenTypes:   []string{echox.MIMEApplicationJSON},
+			whenAccept:   echox.MIMEApplicationXML,
+			expectStatus: http.StatusNotAcceptable,
+		},
+		{
+			name:            "ok, any type",
+			givenTypes:      []string{echox.MIMEApplicationJSON},
+			whenAccept:      "*/*",
+			expectStatus:    http.StatusOK,
+			expectMediaType: echox.MIMEApplicationJSON,
+		},
+		{
+			name:            "ok, no accept header",
+			givenTypes:      []string{echox.MIMEApplicationJSON, echox.MIMEApplicationXML},
+			expectStatus:    http.StatusOK,
+			expectMediaType: echox.MIMEApplicationJSON,
+		},
+		{
+			name:            "ok, quality values pick preferred type",
+			givenTypes:      []string{echox.MIMEApplicationJSON, echox.MIMEApplicationXML},
+			whenAccept:      "application/json;q=0.2, application/xml;q=0.8",
+			expectStatus:    http.StatusOK,
+			expectMediaType: echox.MIMEApplicationXML,
+		},
+		{
+			name:         "nok, only type is excluded with zero quality",
+			givenTypes:   []string{echox.MIMEApplicationJSON},
+			whenAccept:   "application/json;q=0, text/html",
+			expectStatus: http.StatusNotAcceptable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			e.Use(Produces(tc.givenTypes...))
+			e.GET("/", func(c echox.Context) error {
+				return c.String(http.StatusOK, c.Get(ContextKeyProduces).(string))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.whenAccept != "" {
+				req.Header.Set(echox.HeaderAccept, tc.whenAccept)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectStatus, rec.Code)
+			if tc.expectMediaType != "" {
+				assert.Equal(t, tc.expectMediaType, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestProducesWithConfig_missingTypes(t *testing.T) {
+	mw, err := ProducesConfig{}.ToMiddleware()
+
+	assert.EqualError(t, err, "echo produces middleware requires media types")
+	assert.Nil(t, mw)
+}
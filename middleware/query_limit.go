@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/theopenlane/echox"
+)
+
+// QueryLimitConfig defines the config for QueryLimit middleware.
+type QueryLimitConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// MaxQueryParams is maximum number of query parameters (`key=value` pairs) allowed in request URL.
+	// Optional. Default value 0 (no limit).
+	MaxQueryParams int
+
+	// MaxArrayLength is maximum number of values allowed for single query parameter (i.e. `?ids=1&ids=2`).
+	// Optional. Default value 0 (no limit).
+	MaxArrayLength int
+}
+
+// ErrTooManyQueryParams denotes an error raised when request has more query parameters than allowed
+var ErrTooManyQueryParams = echox.NewHTTPError(http.StatusBadRequest, "too many query parameters")
+
+// ErrQueryArrayTooLong denotes an error raised when query parameter has more values than allowed
+var ErrQueryArrayTooLong = echox.NewHTTPError(http.StatusBadRequest, "too many values for query parameter")
+
+// QueryLimit returns a QueryLimit middleware that rejects requests with more than maxQueryParams query parameters
+// with "400 - Bad Request" response.
+func QueryLimit(maxQueryParams int) echox.MiddlewareFunc {
+	return QueryLimitWithConfig(QueryLimitConfig{MaxQueryParams: maxQueryParams})
+}
+
+// QueryLimitWithConfig returns a QueryLimit middleware with config. Middleware counts query parameters in raw query
+// string before it is parsed (i.e. by `c.QueryParam` or binder) and rejects requests exceeding configured limits
+// with "400 - Bad Request" response.
+func QueryLimitWithConfig(config QueryLimitConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts QueryLimitConfig to middleware or returns an error for invalid configuration
+func (config QueryLimitConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if config.Skipper == nil {
+		config.Skipper = DefaultSkipper
+	}
+
+	if config.MaxQueryParams < 0 {
+		return nil, errors.New("echo query limit middleware max query params can not be negative")
+	}
+
+	if config.MaxArrayLength < 0 {
+		return nil, errors.New("echo query limit middleware max array length can not be negative")
+	}
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			if err := checkQueryLimits(c.Request().URL.RawQuery, config.MaxQueryParams, config.MaxArrayLength); err != nil {
+				return err
+			}
+
+			return next(c)
+		}
+	}, nil
+}
+
+func checkQueryLimits(rawQuery string, maxParams int, maxArrayLength int) error {
+	if rawQuery == "" || (maxParams == 0 && maxArrayLength == 0) {
+		return nil
+	}
+
+	var lengths map[string]int
+	if maxArrayLength > 0 {
+		lengths = make(map[string]int)
+	}
+
+	count := 0
+
+	for rawQuery != "" {
+		var pair string
+		pair, rawQuery, _ = strings.Cut(rawQuery, "&")
+
+		if pair == "" {
+			continue
+		}
+
+		count++
+		if maxParams > 0 && count > maxParams {
+			return ErrTooManyQueryParams
+		}
+
+		if lengths != nil {
+			key, _, _ := strings.Cut(pair, "=")
+			if k, err := url.QueryUnescape(key); err == nil {
+				key = k
+			}
+
+			lengths[key]++
+			if lengths[key] > maxArrayLength {
+				return ErrQueryArrayTooLong
+			}
+		}
+	}
+
+	return nil
+}
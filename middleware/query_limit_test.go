@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
+)
+
+func TestQueryLimitWithConfig(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		givenConfig QueryLimitConfig
+		whenQuery   string
+		expectErr   string
+	}{
+		{
+			name:        "ok, compliant request",
+			givenConfig: QueryLimitConfig{MaxQueryParams: 5, MaxArrayLength: 3},
+			whenQuery:   "ids=1&ids=2&ids=3&sort=name",
+		},
+		{
+			name:        "ok, no query",
+			givenConfig: QueryLimitConfig{MaxQueryParams: 1, MaxArrayLength: 1},
+			whenQuery:   "",
+		},
+		{
+			name:        "ok, empty pairs are not counted",
+			givenConfig: QueryLimitConfig{MaxQueryParams: 1},
+			whenQuery:   "a=1&&",
+		},
+		{
+			name:        "nok, too many query params",
+			givenConfig: QueryLimitConfig{MaxQueryParams: 3},
+			whenQuery:   "a=1&b=2&c=3&d=4",
+			expectErr:   "code=400, message=too many query parameters",
+		},
+		{
+			name:        "nok, array param over limit",
+			givenConfig: QueryLimitConfig{MaxArrayLength: 3},
+			whenQuery:   "ids=1&ids=2&ids=3&ids=4",
+			expectErr:   "code=400, message=too many values for query parameter",
+		},
+		{
+			name:        "nok, array param over limit with escaped key",
+			givenConfig: QueryLimitConfig{MaxArrayLength: 2},
+			whenQuery:   "ids%5B%5D=1&ids[]=2&ids%5b%5d=3",
+			expectErr:   "code=400, message=too many values for query parameter",
+		},
+		{
+			name:        "nok, thousands of array values",
+			givenConfig: QueryLimitConfig{MaxQueryParams: 1000, MaxArrayLength: 100},
+			whenQuery:   strings.Repeat("ids=1&", 5000),
+			expectErr:   "code=400, message=too many values for query parameter",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			req := httptest.NewRequest(http.MethodGet, "/?"+tc.whenQuery, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := QueryLimitWithConfig(tc.givenConfig)(func(c echox.Context) error {
+				return c.String(http.StatusOK, "OK")
+			})(c)
+
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+			}
+		})
+	}
+}
+
+func TestQueryLimit(t *testing.T) {
+	e := echox.New()
+	e.Use(QueryLimit(2))
+	e.GET("/", func(c echox.Context) error {
+		return c.String(http.StatusOK, "OK")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/?a=1&b=2&c=3", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestQueryLimitConfig_ToMiddlewareNegativeLimits(t *testing.T) {
+	_, err := QueryLimitConfig{MaxQueryParams: -1}.ToMiddleware()
+	assert.EqualError(t, err, "echo query limit middleware max query params can not be negative")
+
+	_, err = QueryLimitConfig{MaxArrayLength: -1}.ToMiddleware()
+	assert.EqualError(t, err, "echo query limit middleware max array length can not be negative")
+}
@@ -1,8 +1,12 @@
 package middleware
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -16,6 +20,50 @@ type RateLimiterStore interface {
 	Allow(identifier string) (bool, error)
 }
 
+// RateLimiterContextStore is optional interface for RateLimiterStore implementations that can be slow (i.e. backed by
+// network service). RateLimiter middleware prefers AllowContext over Allow and passes request context to it so store
+// can give up when request deadline is exceeded or request is canceled.
+type RateLimiterContextStore interface {
+	RateLimiterStore
+	// AllowContext is same as Allow but respects context deadline and cancellation. Store should return context error
+	// (ctx.Err()) when it gives up waiting.
+	AllowContext(ctx context.Context, identifier string) (bool, error)
+}
+
+// RateLimiterRetryAfterStore is optional interface for RateLimiterStore implementations that can tell when
+// identifier is allowed to make the next request. It is used to set `Retry-After` header for denied requests.
+type RateLimiterRetryAfterStore interface {
+	RateLimiterStore
+	// RetryAfter returns duration after which the next request from identifier would be allowed. Returns false when
+	// the duration can not be determined.
+	RetryAfter(identifier string) (time.Duration, bool)
+}
+
+// ContextKeyRateLimiterRetryAfter is context key RateLimiter middleware stores `time.Duration` returned by
+// RateLimiterRetryAfterStore.RetryAfter into before calling DenyHandler. Value is not set for stores that do not
+// implement RateLimiterRetryAfterStore.
+const ContextKeyRateLimiterRetryAfter = "rate_limiter_retry_after"
+
+// RateLimiterRemainingStore is optional interface for RateLimiterStore implementations that can tell how many requests
+// identifier is allowed to make right now.
+type RateLimiterRemainingStore interface {
+	RateLimiterStore
+	// Remaining returns number of requests identifier can make before being limited. Returns false when the number
+	// can not be determined (i.e. limit is infinite).
+	Remaining(identifier string) (int, bool)
+}
+
+const (
+	// ContextKeyRateLimiterIdentifier is context key RateLimiter middleware stores identifier returned by
+	// IdentifierExtractor into so handlers and loggers do not need to extract it again.
+	ContextKeyRateLimiterIdentifier = "rate_limiter_identifier"
+
+	// ContextKeyRateLimiterRemaining is context key RateLimiter middleware stores `int` returned by
+	// RateLimiterRemainingStore.Remaining into after store has decided on the request. Value is not set for stores
+	// that do not implement RateLimiterRemainingStore.
+	ContextKeyRateLimiterRemaining = "rate_limiter_remaining"
+)
+
 // RateLimiterConfig defines the configuration for the rate limiter
 type RateLimiterConfig struct {
 	Skipper    Skipper
@@ -28,6 +76,12 @@ type RateLimiterConfig struct {
 	ErrorHandler func(c echox.Context, err error) error
 	// DenyHandler provides a handler to be called when RateLimiter denies access
 	DenyHandler func(c echox.Context, identifier string, err error) error
+	// DenyStatusCode is status code of error returned by default DenyHandler. Not used when DenyHandler is set.
+	// Optional. Default value 429 (Too Many Requests).
+	DenyStatusCode int
+	// DenyMessage is message of error returned by default DenyHandler. Not used when DenyHandler is set.
+	// Optional. Default value "rate limit exceeded".
+	DenyMessage string
 }
 
 // Extractor is used to extract data from echox.Context
@@ -36,6 +90,10 @@ type Extractor func(c echox.Context) (string, error)
 // ErrRateLimitExceeded denotes an error raised when rate limit is exceeded
 var ErrRateLimitExceeded = echox.NewHTTPError(http.StatusTooManyRequests, "rate limit exceeded")
 
+// ErrRateLimiterStoreTimeout denotes an error raised when RateLimiterContextStore gives up because request context
+// deadline was exceeded or request was canceled
+var ErrRateLimiterStoreTimeout = echox.NewHTTPError(http.StatusServiceUnavailable, "rate limiter store timeout")
+
 // ErrExtractorError denotes an error raised when extractor function is unsuccessful
 var ErrExtractorError = echox.NewHTTPError(http.StatusForbidden, "error while extracting identifier")
 
@@ -53,13 +111,58 @@ var DefaultRateLimiterConfig = RateLimiterConfig{
 			Internal: err,
 		}
 	},
-	DenyHandler: func(c echox.Context, identifier string, err error) error {
+	DenyHandler: newRateLimiterDenyHandler(ErrRateLimitExceeded.Code, ErrRateLimitExceeded.Message),
+}
+
+// newRateLimiterDenyHandler creates default DenyHandler returning error with given status code and message. Handler
+// sets `Retry-After` header when store provided the retry duration.
+func newRateLimiterDenyHandler(code int, message interface{}) func(c echox.Context, identifier string, err error) error {
+	return func(c echox.Context, identifier string, err error) error {
+		if retryAfter, ok := c.Get(ContextKeyRateLimiterRetryAfter).(time.Duration); ok {
+			c.Response().Header().Set(echox.HeaderRetryAfter, strconv.Itoa(retryAfterSeconds(retryAfter)))
+		}
+
 		return &echox.HTTPError{
-			Code:     ErrRateLimitExceeded.Code,
-			Message:  ErrRateLimitExceeded.Message,
+			Code:     code,
+			Message:  message,
 			Internal: err,
 		}
-	},
+	}
+}
+
+// retryAfterSeconds rounds duration up to whole seconds as `Retry-After` header value. Denied request is never told
+// to retry immediately.
+func retryAfterSeconds(d time.Duration) int {
+	seconds := int(math.Ceil(d.Seconds()))
+	if seconds < 1 {
+		return 1
+	}
+
+	return seconds
+}
+
+// IdentifierFromUserOrIP returns an Extractor that identifies authenticated visitors by user identifier stored in
+// context (i.e. by auth middleware with `c.Set(userKey, userID)`) and anonymous visitors by their real IP. Identifiers
+// are prefixed with `user:` and `ip:` so that user identifier can never collide with an IP address.
+// Value stored under userKey must be a non-empty string or implement fmt.Stringer, otherwise visitor is treated as
+// anonymous.
+func IdentifierFromUserOrIP(userKey string) Extractor {
+	return func(c echox.Context) (string, error) {
+		var userID string
+
+		switch v := c.Get(userKey).(type) {
+		case string:
+			userID = v
+		case fmt.Stringer:
+			userID = v.String()
+		}
+
+		if userID != "" {
+			return "user:" + userID, nil
+		}
+
+		return "ip:" + c.RealIP(), nil
+	}
 }
 
 /*
@@ -124,8 +227,22 @@ func (config RateLimiterConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 		config.ErrorHandler = DefaultRateLimiterConfig.ErrorHandler
 	}
 
+	if config.DenyStatusCode < 0 {
+		return nil, errors.New("echo rate limiter deny status code can not be negative")
+	}
+
 	if config.DenyHandler == nil {
-		config.DenyHandler = DefaultRateLimiterConfig.DenyHandler
+		code := config.DenyStatusCode
+		if code == 0 {
+			code = ErrRateLimitExceeded.Code
+		}
+
+		var message interface{} = config.DenyMessage
+		if config.DenyMessage == "" {
+			message = ErrRateLimitExceeded.Message
+		}
+
+		config.DenyHandler = newRateLimiterDenyHandler(code, message)
 	}
 
 	if config.Store == nil {
@@ -147,7 +264,34 @@ func (config RateLimiterConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 				return config.ErrorHandler(c, err)
 			}
 
-			if allow, allowErr := config.Store.Allow(identifier); !allow {
+			c.Set(ContextKeyRateLimiterIdentifier, identifier)
+
+			var allow bool
+
+			var allowErr error
+
+			if store, ok := config.Store.(RateLimiterContextStore); ok {
+				allow, allowErr = store.AllowContext(c.Request().Context(), identifier)
+				if errors.Is(allowErr, context.DeadlineExceeded) || errors.Is(allowErr, context.Canceled) {
+					return ErrRateLimiterStoreTimeout.WithInternal(allowErr)
+				}
+			} else {
+				allow, allowErr = config.Store.Allow(identifier)
+			}
+
+			if store, ok := config.Store.(RateLimiterRemainingStore); ok && allowErr == nil {
+				if remaining, ok := store.Remaining(identifier); ok {
+					c.Set(ContextKeyRateLimiterRemaining, remaining)
+				}
+			}
+
+			if !allow {
+				if store, ok := config.Store.(RateLimiterRetryAfterStore); ok && allowErr == nil {
+					if retryAfter, ok := store.RetryAfter(identifier); ok {
+						c.Set(ContextKeyRateLimiterRetryAfter, retryAfter)
+					}
+				}
+
 				return config.DenyHandler(c, identifier, allowErr)
 			}
 
@@ -165,15 +309,38 @@ type RateLimiterMemoryStore struct {
 	expiresIn   time.Duration
 	lastCleanup time.Time
 
+	cleanupInterval time.Duration
+	limitProvider   func(identifier string) (rate float64, burst int)
+	algorithm       RateLimiterAlgorithm
+
 	timeNow func() time.Time
 }
 
-// Visitor signifies a unique user's limiter details
+// Visitor signifies a unique user's limiter details.
+//
+// Limiter is never nil. With RateLimiterLeakyBucket algorithm requests are decided by internal leaky bucket and
+// Limiter only carries configured limits (Limit and Burst) of the visitor; its token state does not reflect requests
+// allowed by the bucket.
 type Visitor struct {
 	*rate.Limiter
 	lastSeen time.Time
+	// bucket is used instead of Limiter for decisions by RateLimiterLeakyBucket algorithm
+	bucket *leakyBucket
 }
 
+// RateLimiterAlgorithm is algorithm RateLimiterMemoryStore uses to decide if visitor request is allowed
+type RateLimiterAlgorithm string
+
+const (
+	// RateLimiterTokenBucket allows bursts of up to Burst requests and refills Rate tokens per second
+	// (see https://pkg.go.dev/golang.org/x/time/rate#Limiter).
+	RateLimiterTokenBucket RateLimiterAlgorithm = "token_bucket"
+	// RateLimiterLeakyBucket is classic leaky bucket (as a meter). Every request adds one unit to the bucket which leaks
+	// Rate units per second, requests that would overflow bucket with capacity of Burst units are rejected. Unlike
+	// token bucket, bucket of idle visitor drains continuously so allowed requests are smoothed to steady Rate.
+	RateLimiterLeakyBucket RateLimiterAlgorithm = "leaky_bucket"
+)
+
 /*
 NewRateLimiterMemoryStore returns an instance of RateLimiterMemoryStore with
 the provided rate (as req/s).
@@ -218,11 +385,22 @@ func NewRateLimiterMemoryStoreWithConfig(config RateLimiterMemoryStoreConfig) (s
 	store.rate = config.Rate
 	store.burst = config.Burst
 	store.expiresIn = config.ExpiresIn
+	store.limitProvider = config.LimitProvider
+
+	store.algorithm = config.Algorithm
+	if config.Algorithm == "" {
+		store.algorithm = RateLimiterTokenBucket
+	}
 
 	if config.ExpiresIn == 0 {
 		store.expiresIn = DefaultRateLimiterMemoryStoreConfig.ExpiresIn
 	}
 
+	store.cleanupInterval = config.CleanupInterval
+	if config.CleanupInterval == 0 {
+		store.cleanupInterval = store.expiresIn
+	}
+
 	if config.Burst == 0 {
 		store.burst = int(config.Rate)
 	}
@@ -239,6 +417,23 @@ type RateLimiterMemoryStoreConfig struct {
 	Rate      float64       // Rate of requests allowed to pass as req/s. For more info check out Limiter docs - https://pkg.go.dev/golang.org/x/time/rate#Limit.
 	Burst     int           // Burst is maximum number of requests to pass at the same moment. It additionally allows a number of requests to pass when rate limit is reached.
 	ExpiresIn time.Duration // ExpiresIn is the duration after that a rate limiter is cleaned up
+
+	// CleanupInterval is minimum duration between removals of stale visitors. Cleanup does not use background
+	// goroutine, it is done by Allow call when interval has passed since last cleanup, so store does not need
+	// to be closed.
+	// Optional. Default value is ExpiresIn.
+	CleanupInterval time.Duration
+
+	// LimitProvider returns rate and burst for visitor with given identifier. It is called when limiter for new visitor
+	// is created, existing visitors keep their limiter until they expire or are reset with ResetVisitor. Zero rate or
+	// burst returned by provider falls back to Rate and Burst values.
+	// Optional.
+	LimitProvider func(identifier string) (rate float64, burst int)
+
+	// Algorithm is algorithm used to limit requests. For RateLimiterLeakyBucket Rate is leak rate (req/s) and Burst is
+	// bucket capacity.
+	// Optional. Default value RateLimiterTokenBucket.
+	Algorithm RateLimiterAlgorithm
 }
 
 // DefaultRateLimiterMemoryStoreConfig provides default configuration values for RateLimiterMemoryStore
@@ -252,22 +447,164 @@ func (store *RateLimiterMemoryStore) Allow(identifier string) (bool, error) {
 
 	limiter, exists := store.visitors[identifier]
 	if !exists {
-		limiter = new(Visitor)
-		limiter.Limiter = rate.NewLimiter(rate.Limit(store.rate), store.burst)
+		limiter = store.newVisitor(identifier)
 		store.visitors[identifier] = limiter
 	}
 
 	now := store.timeNow()
 	limiter.lastSeen = now
 
-	if now.Sub(store.lastCleanup) > store.expiresIn {
+	if now.Sub(store.lastCleanup) > store.cleanupInterval {
 		store.cleanupStaleVisitors()
 	}
+
+	if limiter.bucket != nil {
+		allowed := limiter.bucket.allow(now)
+		store.mutex.Unlock()
+
+		return allowed, nil
+	}
 	store.mutex.Unlock()
 
 	return limiter.AllowN(store.timeNow(), 1), nil
 }
 
+// AllowContext implements RateLimiterContextStore.AllowContext. Memory store never blocks so context is ignored.
+func (store *RateLimiterMemoryStore) AllowContext(_ context.Context, identifier string) (bool, error) {
+	return store.Allow(identifier)
+}
+
+// RetryAfter implements RateLimiterRetryAfterStore.RetryAfter
+func (store *RateLimiterMemoryStore) RetryAfter(identifier string) (time.Duration, bool) {
+	store.mutex.Lock()
+	visitor, exists := store.visitors[identifier]
+
+	if exists && visitor.bucket != nil {
+		defer store.mutex.Unlock()
+
+		return visitor.bucket.retryAfter(store.timeNow())
+	}
+	store.mutex.Unlock()
+
+	if !exists {
+		return 0, true
+	}
+
+	limit := visitor.Limit()
+	if limit == rate.Inf {
+		return 0, true
+	}
+
+	if limit <= 0 || visitor.Burst() < 1 {
+		return 0, false
+	}
+
+	missing := 1 - visitor.TokensAt(store.timeNow())
+	if missing <= 0 {
+		return 0, true
+	}
+
+	return time.Duration(missing / float64(limit) * float64(time.Second)), true
+}
+
+// Remaining implements RateLimiterRemainingStore.Remaining
+func (store *RateLimiterMemoryStore) Remaining(identifier string) (int, bool) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	visitor, exists := store.visitors[identifier]
+	if !exists {
+		visitor = store.newVisitor(identifier)
+	}
+
+	if visitor.bucket != nil {
+		return int(math.Max(math.Floor(visitor.bucket.capacity-visitor.bucket.levelAt(store.timeNow())), 0)), true
+	}
+
+	if visitor.Limit() == rate.Inf {
+		return 0, false
+	}
+
+	return int(math.Max(math.Floor(visitor.TokensAt(store.timeNow())), 0)), true
+}
+
+// ResetVisitor removes limiter of visitor with given identifier so next request from the visitor creates a new limiter
+// with limits returned by LimitProvider.
+func (store *RateLimiterMemoryStore) ResetVisitor(identifier string) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	delete(store.visitors, identifier)
+}
+
+func (store *RateLimiterMemoryStore) newVisitor(identifier string) *Visitor {
+	r, burst := store.rate, store.burst
+
+	if store.limitProvider != nil {
+		pRate, pBurst := store.limitProvider(identifier)
+		if pRate != 0 {
+			r = pRate
+		}
+
+		if pBurst != 0 {
+			burst = pBurst
+		}
+	}
+
+	visitor := &Visitor{Limiter: rate.NewLimiter(rate.Limit(r), burst)}
+	if store.algorithm == RateLimiterLeakyBucket {
+		visitor.bucket = &leakyBucket{rate: r, capacity: float64(burst), last: store.timeNow()}
+	}
+
+	return visitor
+}
+
+// leakyBucket is leaky bucket as a meter. Access is guarded by RateLimiterMemoryStore mutex.
+type leakyBucket struct {
+	rate     float64 // units leaked per second
+	capacity float64
+	level    float64
+	last     time.Time
+}
+
+// levelAt returns bucket level after leaking until now
+func (b *leakyBucket) levelAt(now time.Time) float64 {
+	level := b.level
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		level -= elapsed * b.rate
+	}
+
+	return math.Max(level, 0)
+}
+
+func (b *leakyBucket) allow(now time.Time) bool {
+	b.level = b.levelAt(now)
+	if now.After(b.last) {
+		b.last = now
+	}
+
+	if b.level+1 > b.capacity {
+		return false
+	}
+
+	b.level++
+
+	return true
+}
+
+func (b *leakyBucket) retryAfter(now time.Time) (time.Duration, bool) {
+	overflow := b.levelAt(now) + 1 - b.capacity
+	if overflow <= 0 {
+		return 0, true
+	}
+
+	if b.rate <= 0 || b.capacity < 1 {
+		return 0, false
+	}
+
+	return time.Duration(overflow / b.rate * float64(time.Second)), true
+}
+
 /*
 cleanupStaleVisitors helps manage the size of the visitors map by removing stale records
 of users who haven't visited again after the configured expiry time has elapsed
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"errors"
 	"math/rand"
 	"net/http"
@@ -57,6 +58,65 @@ func TestRateLimiter(t *testing.T) {
 	}
 }
 
+func TestRateLimiterWithConfig_identifierFromUserOrIP(t *testing.T) {
+	e := echox.New()
+
+	handler := func(c echox.Context) error {
+		return c.String(http.StatusOK, "test")
+	}
+
+	inMemoryStore := NewRateLimiterMemoryStoreWithConfig(RateLimiterMemoryStoreConfig{Rate: 1, Burst: 2})
+
+	var identifiers []string
+	mw := RateLimiterWithConfig(RateLimiterConfig{
+		Store:               inMemoryStore,
+		IdentifierExtractor: IdentifierFromUserOrIP("user_id"),
+		BeforeFunc: func(c echox.Context) {
+			id, _ := IdentifierFromUserOrIP("user_id")(c)
+			identifiers = append(identifiers, id)
+		},
+	})
+
+	testCases := []struct {
+		name      string
+		whenIP    string
+		whenUser  string
+		expectErr string
+	}{
+		{name: "user, first", whenIP: "127.0.0.1", whenUser: "user-1"},
+		{name: "user, second", whenIP: "127.0.0.1", whenUser: "user-1"},
+		{name: "user, throttled", whenIP: "127.0.0.1", whenUser: "user-1", expectErr: "code=429, message=rate limit exceeded"},
+		{name: "anonymous from same IP is limited independently", whenIP: "127.0.0.1"},
+		{name: "anonymous, second", whenIP: "127.0.0.1"},
+		{name: "anonymous, throttled", whenIP: "127.0.0.1", expectErr: "code=429, message=rate limit exceeded"},
+		{name: "other user is limited independently", whenIP: "127.0.0.1", whenUser: "user-2"},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Add(echox.HeaderXRealIP, tc.whenIP)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		if tc.whenUser != "" {
+			c.Set("user_id", tc.whenUser)
+		}
+
+		err := mw(handler)(c)
+		if tc.expectErr != "" {
+			assert.EqualError(t, err, tc.expectErr, tc.name)
+		} else {
+			assert.NoError(t, err, tc.name)
+		}
+	}
+
+	assert.Equal(t, []string{
+		"user:user-1", "user:user-1", "user:user-1",
+		"ip:127.0.0.1", "ip:127.0.0.1", "ip:127.0.0.1",
+		"user:user-2",
+	}, identifiers)
+}
+
 func TestMustRateLimiterWithConfig_panicBehaviour(t *testing.T) {
 	var inMemoryStore = NewRateLimiterMemoryStoreWithConfig(RateLimiterMemoryStoreConfig{Rate: 1, Burst: 3})
 
@@ -177,6 +237,83 @@ func TestRateLimiterWithConfig_defaultDenyHandler(t *testing.T) {
 	}
 }
 
+func TestRateLimiterWithConfig_defaultDenyHandlerRetryAfter(t *testing.T) {
+	var testCases = []struct {
+		name             string
+		givenStore       RateLimiterStore
+		expectRetryAfter string
+	}{
+		{
+			name:             "ok, memory store sets Retry-After",
+			givenStore:       NewRateLimiterMemoryStoreWithConfig(RateLimiterMemoryStoreConfig{Rate: 0.5, Burst: 1}),
+			expectRetryAfter: "2",
+		},
+		{
+			name:             "ok, Retry-After is at least one second",
+			givenStore:       NewRateLimiterMemoryStoreWithConfig(RateLimiterMemoryStoreConfig{Rate: 100, Burst: 1}),
+			expectRetryAfter: "1",
+		},
+		{
+			name: "ok, store without RetryAfter omits header",
+			givenStore: rateLimiterStoreFunc(func(identifier string) (bool, error) {
+				return identifier == "", nil
+			}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if store, ok := tc.givenStore.(*RateLimiterMemoryStore); ok {
+				now := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+				store.timeNow = func() time.Time { return now }
+			}
+			mw := RateLimiter(tc.givenStore)(func(c echox.Context) error {
+				return c.String(http.StatusOK, "test")
+			})
+
+			var err error
+			var rec *httptest.ResponseRecorder
+			for i := 0; i < 2; i++ {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Add(echox.HeaderXRealIP, "127.0.0.1")
+				rec = httptest.NewRecorder()
+				err = mw(echox.New().NewContext(req, rec))
+			}
+
+			assert.EqualError(t, err, "code=429, message=rate limit exceeded")
+			assert.Equal(t, tc.expectRetryAfter, rec.Header().Get(echox.HeaderRetryAfter))
+		})
+	}
+}
+
+type rateLimiterStoreFunc func(identifier string) (bool, error)
+
+func (f rateLimiterStoreFunc) Allow(identifier string) (bool, error) {
+	return f(identifier)
+}
+
+func TestRateLimiterMemoryStore_RetryAfter(t *testing.T) {
+	store := NewRateLimiterMemoryStoreWithConfig(RateLimiterMemoryStoreConfig{Rate: 2, Burst: 1})
+	now := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	store.timeNow = func() time.Time { return now }
+
+	retryAfter, ok := store.RetryAfter("unknown")
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), retryAfter)
+
+	allowed, _ := store.Allow("A")
+	assert.True(t, allowed)
+
+	retryAfter, ok = store.RetryAfter("A")
+	assert.True(t, ok)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+	now = now.Add(200 * time.Millisecond)
+	retryAfter, ok = store.RetryAfter("A")
+	assert.True(t, ok)
+	assert.Equal(t, 300*time.Millisecond, retryAfter.Round(time.Millisecond))
+}
+
 func TestRateLimiterWithConfig_defaultConfig(t *testing.T) {
 	{
 		var inMemoryStore = NewRateLimiterMemoryStoreWithConfig(RateLimiterMemoryStoreConfig{Rate: 1, Burst: 3})
@@ -412,6 +549,95 @@ func TestRateLimiterMemoryStore_cleanupStaleVisitors(t *testing.T) {
 	assert.Equal(t, true, exists)
 }
 
+func TestRateLimiterMemoryStore_CleanupInterval(t *testing.T) {
+	store := NewRateLimiterMemoryStoreWithConfig(RateLimiterMemoryStoreConfig{
+		Rate:            1,
+		ExpiresIn:       time.Minute,
+		CleanupInterval: 10 * time.Second,
+	})
+	assert.Equal(t, 10*time.Second, store.cleanupInterval)
+
+	now := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	store.timeNow = func() time.Time { return now }
+	store.lastCleanup = now
+
+	store.Allow("A")
+
+	now = now.Add(30 * time.Second)
+	store.Allow("B") // cleanup interval has passed before visitors expire
+	assert.Equal(t, now, store.lastCleanup)
+
+	now = now.Add(45 * time.Second)
+	store.Allow("B") // A is stale
+
+	_, exists := store.visitors["A"]
+	assert.False(t, exists)
+	_, exists = store.visitors["B"]
+	assert.True(t, exists)
+	assert.Equal(t, now, store.lastCleanup)
+
+	defaultStore := NewRateLimiterMemoryStoreWithConfig(RateLimiterMemoryStoreConfig{Rate: 1, ExpiresIn: time.Minute})
+	assert.Equal(t, time.Minute, defaultStore.cleanupInterval)
+}
+
+func TestRateLimiterMemoryStore_LimitProvider(t *testing.T) {
+	store := NewRateLimiterMemoryStoreWithConfig(RateLimiterMemoryStoreConfig{
+		Rate:  1,
+		Burst: 2,
+		LimitProvider: func(identifier string) (float64, int) {
+			if identifier == "premium" {
+				return 0, 5
+			}
+			return 0, 0
+		},
+	})
+	now := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	store.timeNow = func() time.Time { return now }
+
+	countAllowed := func(identifier string) int {
+		allowed := 0
+		for i := 0; i < 10; i++ {
+			if ok, _ := store.Allow(identifier); ok {
+				allowed++
+			}
+		}
+		return allowed
+	}
+
+	assert.Equal(t, 5, countAllowed("premium"))
+	assert.Equal(t, 2, countAllowed("regular"))
+}
+
+func TestRateLimiterMemoryStore_ResetVisitor(t *testing.T) {
+	burst := 1
+	store := NewRateLimiterMemoryStoreWithConfig(RateLimiterMemoryStoreConfig{
+		Rate: 1,
+		LimitProvider: func(identifier string) (float64, int) {
+			return 0, burst
+		},
+	})
+	now := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	store.timeNow = func() time.Time { return now }
+
+	allowed, _ := store.Allow("A")
+	assert.True(t, allowed)
+	allowed, _ = store.Allow("A")
+	assert.False(t, allowed)
+
+	// existing visitor keeps its limiter until reset
+	burst = 3
+	allowed, _ = store.Allow("A")
+	assert.False(t, allowed)
+
+	store.ResetVisitor("A")
+	for i := 0; i < 3; i++ {
+		allowed, _ = store.Allow("A")
+		assert.True(t, allowed)
+	}
+	allowed, _ = store.Allow("A")
+	assert.False(t, allowed)
+}
+
 func TestNewRateLimiterMemoryStore(t *testing.T) {
 	testCases := []struct {
 		rate              float64
@@ -484,3 +710,259 @@ func BenchmarkRateLimiterMemoryStore_conc100_10000(b *testing.B) {
 	var store = NewRateLimiterMemoryStoreWithConfig(RateLimiterMemoryStoreConfig{Rate: 100, Burst: 200, ExpiresIn: testExpiresIn})
 	benchmarkStore(store, 100, 10000, b)
 }
+
+type slowRateLimiterStore struct {
+	allowCalled bool
+}
+
+func (s *slowRateLimiterStore) Allow(identifier string) (bool, error) {
+	s.allowCalled = true
+	return true, nil
+}
+
+func (s *slowRateLimiterStore) AllowContext(ctx context.Context, identifier string) (bool, error) {
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	case <-time.After(time.Second):
+		return true, nil
+	}
+}
+
+func TestRateLimiterWithConfig_contextStore(t *testing.T) {
+	store := &slowRateLimiterStore{}
+	mw := RateLimiter(store)(func(c echox.Context) error {
+		return c.String(http.StatusOK, "test")
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	c := echox.New().NewContext(req, rec)
+
+	start := time.Now()
+	err := mw(c)
+
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.EqualError(t, err, "code=503, message=rate limiter store timeout, internal=context deadline exceeded")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.False(t, store.allowCalled)
+}
+
+func TestRateLimiterMemoryStore_AllowContext(t *testing.T) {
+	store := NewRateLimiterMemoryStoreWithConfig(RateLimiterMemoryStoreConfig{Rate: 1, Burst: 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	allowed, err := store.AllowContext(ctx, "127.0.0.1")
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+}
+
+func TestRateLimiterMemoryStore_LeakyBucket(t *testing.T) {
+	store := NewRateLimiterMemoryStoreWithConfig(RateLimiterMemoryStoreConfig{
+		Rate:      2,
+		Burst:     3,
+		Algorithm: RateLimiterLeakyBucket,
+	})
+
+	testCases := []struct {
+		at      time.Duration
+		allowed bool
+	}{
+		{0, true}, // bucket fills up to capacity
+		{0, true},
+		{0, true},
+		{0, false}, // bucket is full
+		{100 * time.Millisecond, false},
+		{500 * time.Millisecond, true}, // one unit leaked
+		{500 * time.Millisecond, false},
+		{750 * time.Millisecond, false},
+		{1000 * time.Millisecond, true}, // steady state at leak rate
+		{1250 * time.Millisecond, false},
+		{1500 * time.Millisecond, true},
+		{1750 * time.Millisecond, false},
+		{2000 * time.Millisecond, true},
+		{4000 * time.Millisecond, true}, // bucket drained while idle
+		{4000 * time.Millisecond, true},
+		{4000 * time.Millisecond, true},
+		{4000 * time.Millisecond, false},
+	}
+
+	start := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	for i, tc := range testCases {
+		store.timeNow = func() time.Time {
+			return start.Add(tc.at)
+		}
+		allowed, err := store.Allow("127.0.0.1")
+		assert.NoError(t, err)
+		assert.Equal(t, tc.allowed, allowed, "testcase #%d at %v", i, tc.at)
+	}
+
+	retryAfter, ok := store.RetryAfter("127.0.0.1")
+	assert.True(t, ok)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+}
+
+func TestRateLimiterMemoryStore_LeakyBucketLimitProvider(t *testing.T) {
+	store := NewRateLimiterMemoryStoreWithConfig(RateLimiterMemoryStoreConfig{
+		Rate:      1,
+		Burst:     1,
+		Algorithm: RateLimiterLeakyBucket,
+		LimitProvider: func(identifier string) (float64, int) {
+			if identifier == "premium" {
+				return 0, 4
+			}
+			return 0, 0
+		},
+	})
+	now := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	store.timeNow = func() time.Time { return now }
+
+	countAllowed := func(identifier string) int {
+		allowed := 0
+		for i := 0; i < 10; i++ {
+			if ok, _ := store.Allow(identifier); ok {
+				allowed++
+			}
+		}
+		return allowed
+	}
+
+	assert.Equal(t, 4, countAllowed("premium"))
+	assert.Equal(t, 1, countAllowed("regular"))
+}
+
+func TestRateLimiterMemoryStore_LeakyBucketVisitorLimiter(t *testing.T) {
+	store := NewRateLimiterMemoryStoreWithConfig(RateLimiterMemoryStoreConfig{
+		Rate:      2,
+		Burst:     3,
+		Algorithm: RateLimiterLeakyBucket,
+	})
+
+	_, err := store.Allow("127.0.0.1")
+	assert.NoError(t, err)
+
+	visitor := store.visitors["127.0.0.1"]
+	assert.NotNil(t, visitor.Limiter)
+	assert.Equal(t, rate.Limit(2), visitor.Limit())
+	assert.Equal(t, 3, visitor.Burst())
+	assert.NotPanics(t, func() { visitor.Allow() })
+}
+
+func TestRateLimiterWithConfig_denyStatusCodeAndMessage(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		givenConfig RateLimiterConfig
+		expectErr   string
+	}{
+		{
+			name:      "ok, defaults",
+			expectErr: "code=429, message=rate limit exceeded",
+		},
+		{
+			name:        "ok, custom status code and message",
+			givenConfig: RateLimiterConfig{DenyStatusCode: http.StatusServiceUnavailable, DenyMessage: "slow down"},
+			expectErr:   "code=503, message=slow down",
+		},
+		{
+			name:        "ok, custom status code with default message",
+			givenConfig: RateLimiterConfig{DenyStatusCode: http.StatusServiceUnavailable},
+			expectErr:   "code=503, message=rate limit exceeded",
+		},
+		{
+			name: "ok, custom DenyHandler supersedes status code and message",
+			givenConfig: RateLimiterConfig{
+				DenyStatusCode: http.StatusServiceUnavailable,
+				DenyMessage:    "slow down",
+				DenyHandler: func(c echox.Context, identifier string, err error) error {
+					return echox.ErrForbidden
+				},
+			},
+			expectErr: "code=403, message=Forbidden",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := tc.givenConfig
+			config.Store = NewRateLimiterMemoryStoreWithConfig(RateLimiterMemoryStoreConfig{Rate: 1, Burst: 1})
+			mw := RateLimiterWithConfig(config)(func(c echox.Context) error {
+				return c.String(http.StatusOK, "test")
+			})
+
+			var err error
+			for i := 0; i < 2; i++ {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				err = mw(echox.New().NewContext(req, httptest.NewRecorder()))
+			}
+
+			assert.EqualError(t, err, tc.expectErr)
+		})
+	}
+}
+
+func TestRateLimiterWithConfig_invalidDenyStatusCode(t *testing.T) {
+	_, err := RateLimiterConfig{Store: NewRateLimiterMemoryStore(1), DenyStatusCode: -1}.ToMiddleware()
+	assert.EqualError(t, err, "echo rate limiter deny status code can not be negative")
+}
+
+func TestRateLimiterWithConfig_contextValues(t *testing.T) {
+	var testCases = []struct {
+		name            string
+		givenAlgorithm  RateLimiterAlgorithm
+		expectRemaining []int
+		expectErr       []string
+	}{
+		{
+			name:            "ok, token bucket",
+			expectRemaining: []int{2, 1, 0, 0},
+			expectErr:       []string{"", "", "", "code=429, message=rate limit exceeded"},
+		},
+		{
+			name:            "ok, leaky bucket",
+			givenAlgorithm:  RateLimiterLeakyBucket,
+			expectRemaining: []int{2, 1, 0, 0},
+			expectErr:       []string{"", "", "", "code=429, message=rate limit exceeded"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := NewRateLimiterMemoryStoreWithConfig(RateLimiterMemoryStoreConfig{Rate: 1, Burst: 3, Algorithm: tc.givenAlgorithm})
+			now := time.Now()
+			store.timeNow = func() time.Time { return now }
+
+			mw := RateLimiterWithConfig(RateLimiterConfig{Store: store})(func(c echox.Context) error {
+				return c.String(http.StatusOK, "test")
+			})
+
+			for i, expectRemaining := range tc.expectRemaining {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.RemoteAddr = "192.0.2.1:1234"
+				c := echox.New().NewContext(req, httptest.NewRecorder())
+
+				err := mw(c)
+				if tc.expectErr[i] != "" {
+					assert.EqualError(t, err, tc.expectErr[i])
+				} else {
+					assert.NoError(t, err)
+				}
+
+				assert.Equal(t, "192.0.2.1", c.Get(ContextKeyRateLimiterIdentifier))
+				assert.Equal(t, expectRemaining, c.Get(ContextKeyRateLimiterRemaining))
+			}
+		})
+	}
+}
+
+func TestRateLimiterMemoryStore_RemainingInfiniteRate(t *testing.T) {
+	store := NewRateLimiterMemoryStoreWithConfig(RateLimiterMemoryStoreConfig{Rate: float64(rate.Inf)})
+
+	remaining, ok := store.Remaining("127.0.0.1")
+	assert.False(t, ok)
+	assert.Equal(t, 0, remaining)
+}
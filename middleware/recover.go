@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"runtime"
@@ -25,18 +27,50 @@ type RecoverConfig struct {
 	// DisablePrintStack disables printing stack trace.
 	// Optional. Default value as false.
 	DisablePrintStack bool
+
+	// PanicMapper converts known panic values (i.e. panics of a library on invalid input) to HTTPError. When it
+	// returns true, returned HTTPError is returned from the middleware instead of the generic panic error (500) and
+	// stack trace is not added. Recovered value is set as internal error of HTTPError unless it already has one.
+	// Optional. Default value nil.
+	PanicMapper func(recovered any) (*echox.HTTPError, bool)
+
+	// ContextCanceledStatus is status code of error returned when recovered value is an error wrapping
+	// `context.Canceled` (i.e. database driver panics after client disconnected and request context was cancelled).
+	// Used only with EnableContextErrorMapping.
+	// Optional. Default value StatusCodeContextCanceled (499).
+	ContextCanceledStatus int
+
+	// DeadlineExceededStatus is status code of error returned when recovered value is an error wrapping
+	// `context.DeadlineExceeded` (i.e. panic after request context timed out).
+	// Used only with EnableContextErrorMapping.
+	// Optional. Default value 503.
+	DeadlineExceededStatus int
+
+	// EnableContextErrorMapping enables mapping of panics wrapping context errors to ContextCanceledStatus and
+	// DeadlineExceededStatus instead of handling them as any other panic (500). Note: this changes status codes
+	// reported for such panics to logs and metrics.
+	// Optional. Default value false.
+	EnableContextErrorMapping bool
 }
 
 // DefaultRecoverConfig is the default Recover middleware config.
 var DefaultRecoverConfig = RecoverConfig{
-	Skipper:           DefaultSkipper,
-	StackSize:         4 << 10, // 4 KB
-	DisableStackAll:   false,
-	DisablePrintStack: false,
+	Skipper:                DefaultSkipper,
+	StackSize:              4 << 10, // 4 KB
+	DisableStackAll:        false,
+	DisablePrintStack:      false,
+	ContextCanceledStatus:  StatusCodeContextCanceled,
+	DeadlineExceededStatus: http.StatusServiceUnavailable,
 }
 
 // Recover returns a middleware which recovers from panics anywhere in the chain
 // and handles the control to the centralized HTTPErrorHandler.
+//
+// Recover should be added as the outermost middleware (first `e.Use` call) so it can recover panics from all other
+// middlewares. Panic unwinds the call stack through every middleware that Recover wraps so their `defer` statements
+// are executed before Recover returns the error. Error response (500) is written by HTTPErrorHandler only after that,
+// so deferred cleanup of inner middlewares always runs before the response is committed and Response.Before/After
+// hooks they registered fire when the error response is written.
 func Recover() echox.MiddlewareFunc {
 	return RecoverWithConfig(DefaultRecoverConfig)
 }
@@ -57,6 +91,22 @@ func (config RecoverConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 		config.StackSize = DefaultRecoverConfig.StackSize
 	}
 
+	if config.ContextCanceledStatus < 0 {
+		return nil, errors.New("echo recover middleware context canceled status can not be negative")
+	}
+
+	if config.ContextCanceledStatus == 0 {
+		config.ContextCanceledStatus = DefaultRecoverConfig.ContextCanceledStatus
+	}
+
+	if config.DeadlineExceededStatus < 0 {
+		return nil, errors.New("echo recover middleware deadline exceeded status can not be negative")
+	}
+
+	if config.DeadlineExceededStatus == 0 {
+		config.DeadlineExceededStatus = DefaultRecoverConfig.DeadlineExceededStatus
+	}
+
 	return func(next echox.HandlerFunc) echox.HandlerFunc {
 		return func(c echox.Context) (err error) {
 			if config.Skipper(c) {
@@ -74,6 +124,29 @@ func (config RecoverConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 						tmpErr = fmt.Errorf("%v", r)
 					}
 
+					if config.PanicMapper != nil {
+						if he, mapped := config.PanicMapper(r); mapped && he != nil {
+							if he.Internal == nil {
+								he = he.WithInternal(tmpErr)
+							}
+
+							err = he
+
+							return
+						}
+					}
+
+					if config.EnableContextErrorMapping && ok {
+						switch {
+						case errors.Is(tmpErr, context.Canceled):
+							err = echox.NewHTTPErrorWithInternal(config.ContextCanceledStatus, tmpErr, "client closed connection")
+							return
+						case errors.Is(tmpErr, context.DeadlineExceeded):
+							err = echox.NewHTTPErrorWithInternal(config.DeadlineExceededStatus, tmpErr)
+							return
+						}
+					}
+
 					if !config.DisablePrintStack {
 						stack := make([]byte, config.StackSize)
 						length := runtime.Stack(stack, !config.DisableStackAll)
@@ -2,9 +2,12 @@ package middleware
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -27,6 +30,53 @@ func TestRecover(t *testing.T) {
 	assert.Contains(t, buf.String(), "")     // nothing is logged
 }
 
+func TestRecover_innerMiddlewareCleanupRunsBeforeErrorResponse(t *testing.T) {
+	e := echox.New()
+
+	var events []string
+	e.Use(Recover())
+	e.Use(func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			c.Response().Before(func() {
+				events = append(events, fmt.Sprintf("before response %d", c.Response().Status))
+			})
+			c.Response().After(func() {
+				events = append(events, "after response")
+			})
+			defer func() {
+				events = append(events, "outer cleanup")
+			}()
+
+			return next(c)
+		}
+	})
+	e.Use(func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			defer func() {
+				events = append(events, "inner cleanup")
+			}()
+
+			panic("inner middleware panic")
+		}
+	})
+	e.GET("/", func(c echox.Context) error {
+		events = append(events, "handler")
+		return c.String(http.StatusOK, "OK")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, []string{
+		"inner cleanup",
+		"outer cleanup",
+		"before response 500",
+		"after response",
+	}, events)
+}
+
 func TestRecover_skipper(t *testing.T) {
 	e := echox.New()
 
@@ -139,3 +189,150 @@ func TestRecoverWithConfig(t *testing.T) {
 		})
 	}
 }
+
+type templateSyntaxPanic struct {
+	msg string
+}
+
+func TestRecoverWithConfig_PanicMapper(t *testing.T) {
+	mapper := func(recovered any) (*echox.HTTPError, bool) {
+		if p, ok := recovered.(templateSyntaxPanic); ok {
+			return echox.NewHTTPError(http.StatusBadRequest, "invalid template: "+p.msg), true
+		}
+		return nil, false
+	}
+
+	var testCases = []struct {
+		name            string
+		whenPanic       any
+		expectStatus    int
+		expectBody      string
+		expectErrPrefix string
+	}{
+		{
+			name:         "ok, mapped panic is converted to 400",
+			whenPanic:    templateSyntaxPanic{msg: "unexpected {{"},
+			expectStatus: http.StatusBadRequest,
+			expectBody:   `{"message":"invalid template: unexpected {{"}` + "\n",
+		},
+		{
+			name:            "ok, unmapped panic remains 500",
+			whenPanic:       "something else",
+			expectStatus:    http.StatusInternalServerError,
+			expectBody:      `{"message":"Internal Server Error"}` + "\n",
+			expectErrPrefix: "[PANIC RECOVER] something else goroutine",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+
+			var handledErr error
+			e.HTTPErrorHandler = func(c echox.Context, err error) {
+				handledErr = err
+				echox.DefaultHTTPErrorHandler(false)(c, err)
+			}
+			e.Use(RecoverWithConfig(RecoverConfig{PanicMapper: mapper}))
+			e.GET("/", func(c echox.Context) error {
+				panic(tc.whenPanic)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectStatus, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+			if tc.expectErrPrefix != "" {
+				assert.Contains(t, handledErr.Error(), tc.expectErrPrefix)
+			} else {
+				assert.EqualError(t, handledErr, "code=400, message=invalid template: unexpected {{, internal={unexpected {{}")
+			}
+		})
+	}
+}
+
+func TestRecoverWithConfig_contextErrors(t *testing.T) {
+	var testCases = []struct {
+		name         string
+		givenConfig  RecoverConfig
+		whenCancel   bool
+		whenTimeout  bool
+		expectStatus int
+		expectErr    string
+	}{
+		{
+			name:         "ok, panic after cancellation is mapped to 499",
+			givenConfig:  RecoverConfig{EnableContextErrorMapping: true},
+			whenCancel:   true,
+			expectStatus: StatusCodeContextCanceled,
+			expectErr:    "code=499, message=client closed connection, internal=driver: context canceled",
+		},
+		{
+			name:         "ok, panic after deadline is mapped to 503",
+			givenConfig:  RecoverConfig{EnableContextErrorMapping: true},
+			whenTimeout:  true,
+			expectStatus: http.StatusServiceUnavailable,
+			expectErr:    "code=503, message=Service Unavailable, internal=driver: context deadline exceeded",
+		},
+		{
+			name:         "ok, custom status for cancellation",
+			givenConfig:  RecoverConfig{EnableContextErrorMapping: true, ContextCanceledStatus: http.StatusServiceUnavailable},
+			whenCancel:   true,
+			expectStatus: http.StatusServiceUnavailable,
+			expectErr:    "code=503, message=client closed connection, internal=driver: context canceled",
+		},
+		{
+			name:         "ok, mapping is disabled by default and results 500",
+			givenConfig:  RecoverConfig{DisablePrintStack: true},
+			whenCancel:   true,
+			expectStatus: http.StatusInternalServerError,
+			expectErr:    "driver: context canceled",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+
+			var handledErr error
+			e.HTTPErrorHandler = func(c echox.Context, err error) {
+				handledErr = err
+				echox.DefaultHTTPErrorHandler(false)(c, err)
+			}
+			e.Use(RecoverWithConfig(tc.givenConfig))
+			e.GET("/", func(c echox.Context) error {
+				ctx := c.Request().Context()
+				if tc.whenCancel {
+					cctx, cancel := context.WithCancel(ctx)
+					cancel()
+					ctx = cctx
+				}
+				if tc.whenTimeout {
+					tctx, cancel := context.WithDeadline(ctx, time.Now().Add(-time.Second))
+					defer cancel()
+					ctx = tctx
+				}
+				panic(fmt.Errorf("driver: %w", ctx.Err()))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectStatus, rec.Code)
+			assert.EqualError(t, handledErr, tc.expectErr)
+		})
+	}
+}
+
+func TestRecoverWithConfig_negativeContextStatus(t *testing.T) {
+	mw, err := RecoverConfig{ContextCanceledStatus: -1}.ToMiddleware()
+	assert.EqualError(t, err, "echo recover middleware context canceled status can not be negative")
+	assert.Nil(t, mw)
+
+	mw, err = RecoverConfig{DeadlineExceededStatus: -1}.ToMiddleware()
+	assert.EqualError(t, err, "echo recover middleware deadline exceeded status can not be negative")
+	assert.Nil(t, mw)
+}
@@ -3,6 +3,7 @@ package middleware
 import (
 	"errors"
 	"net/http"
+	"regexp"
 	"strings"
 
 	"github.com/theopenlane/echox"
@@ -13,7 +14,7 @@ type RedirectConfig struct {
 	// Skipper defines a function to skip middleware.
 	Skipper
 
-	// Status code to be used when redirecting the request.
+	// Status code to be used when redirecting the request. Must be one of 301, 302, 303, 307 or 308.
 	// Optional. Default value http.StatusMovedPermanently.
 	Code int
 
@@ -27,6 +28,16 @@ type redirectLogic func(scheme, host, uri string) (ok bool, url string)
 
 const www = "www."
 
+// ACMEChallengePathPrefix is URL path prefix of ACME HTTP-01 challenge requests (RFC 8555) that certificate
+// authorities (i.e. Let's Encrypt) send over plain HTTP.
+const ACMEChallengePathPrefix = "/.well-known/acme-challenge/"
+
+// ACMEChallengeSkipper is Skipper that skips ACME HTTP-01 challenge requests so they are served over HTTP. It is
+// default Skipper of HTTPS redirect middlewares.
+func ACMEChallengeSkipper(c echox.Context) bool {
+	return strings.HasPrefix(c.Request().URL.Path, ACMEChallengePathPrefix)
+}
+
 // RedirectHTTPSConfig is the HTTPS Redirect middleware config.
 var RedirectHTTPSConfig = RedirectConfig{redirect: redirectHTTPS}
 
@@ -45,13 +56,21 @@ var RedirectNonWWWConfig = RedirectConfig{redirect: redirectNonWWW}
 // HTTPSRedirect redirects http requests to https.
 // For example, http://labstack.com will be redirect to https://labstack.com.
 //
+// ACME HTTP-01 challenge requests (`/.well-known/acme-challenge/*`) are not redirected so certificates can be
+// issued and renewed while redirect is in place. Set RedirectConfig.Skipper to change that (see ACMEChallengeSkipper).
+//
 // Usage `Echo#Pre(HTTPSRedirect())`
 func HTTPSRedirect() echox.MiddlewareFunc {
 	return HTTPSRedirectWithConfig(RedirectHTTPSConfig)
 }
 
 // HTTPSRedirectWithConfig returns a HTTPS redirect middleware with config or panics on invalid configuration.
+// When config.Skipper is nil, ACMEChallengeSkipper is used.
 func HTTPSRedirectWithConfig(config RedirectConfig) echox.MiddlewareFunc {
+	if config.Skipper == nil {
+		config.Skipper = ACMEChallengeSkipper
+	}
+
 	config.redirect = redirectHTTPS
 	return toMiddlewareOrPanic(config)
 }
@@ -65,7 +84,12 @@ func HTTPSWWWRedirect() echox.MiddlewareFunc {
 }
 
 // HTTPSWWWRedirectWithConfig returns a HTTPS WWW redirect middleware with config or panics on invalid configuration.
+// When config.Skipper is nil, ACMEChallengeSkipper is used.
 func HTTPSWWWRedirectWithConfig(config RedirectConfig) echox.MiddlewareFunc {
+	if config.Skipper == nil {
+		config.Skipper = ACMEChallengeSkipper
+	}
+
 	config.redirect = redirectHTTPSWWW
 	return toMiddlewareOrPanic(config)
 }
@@ -79,7 +103,12 @@ func HTTPSNonWWWRedirect() echox.MiddlewareFunc {
 }
 
 // HTTPSNonWWWRedirectWithConfig returns a HTTPS Non-WWW redirect middleware with config or panics on invalid configuration.
+// When config.Skipper is nil, ACMEChallengeSkipper is used.
 func HTTPSNonWWWRedirectWithConfig(config RedirectConfig) echox.MiddlewareFunc {
+	if config.Skipper == nil {
+		config.Skipper = ACMEChallengeSkipper
+	}
+
 	config.redirect = redirectNonHTTPSWWW
 	return toMiddlewareOrPanic(config)
 }
@@ -122,6 +151,10 @@ func (config RedirectConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 		config.Code = http.StatusMovedPermanently
 	}
 
+	if !isRedirectCode(config.Code) {
+		return nil, errors.New("echo redirect middleware code must be one of 301, 302, 303, 307 or 308")
+	}
+
 	if config.redirect == nil {
 		return nil, errors.New("redirectConfig is missing redirect function")
 	}
@@ -135,7 +168,8 @@ func (config RedirectConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 			req, scheme := c.Request(), c.Scheme()
 			host := req.Host
 
-			if ok, url := config.redirect(scheme, host, req.RequestURI); ok {
+			// URL.RequestURI is always in origin form (path and query) even when request line had absolute URI
+			if ok, url := config.redirect(scheme, host, req.URL.RequestURI()); ok {
 				return c.Redirect(config.Code, url)
 			}
 
@@ -144,6 +178,17 @@ func (config RedirectConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 	}, nil
 }
 
+// isRedirectCode checks if code is status code that redirects client to the URL in `Location` header
+func isRedirectCode(code int) bool {
+	switch code {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+
+	return false
+}
+
 var redirectHTTPS = func(scheme, host, uri string) (bool, string) {
 	if scheme != "https" {
 		return true, "https://" + host + uri
@@ -179,3 +224,83 @@ var redirectNonWWW = func(scheme, host, uri string) (bool, string) {
 	}
 	return false, ""
 }
+
+// RedirectRulesConfig defines the config for RedirectRules middleware.
+type RedirectRulesConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// Status code to be used when redirecting the request. Must be one of 301, 302, 303, 307 or 308.
+	// Optional. Default value http.StatusMovedPermanently.
+	Code int
+
+	// Rules defines the URL path redirect rules. The values captured in asterisk can be
+	// retrieved by index e.g. $1, $2 and so on. Rule syntax is same as in RewriteConfig.Rules.
+	// Example:
+	// "/old":            "/new",
+	// "/old-blog/*":     "/blog/$1",
+	// "/users/*/posts/*": "https://blog.example.com/$1/$2",
+	Rules map[string]string
+
+	// RegexRules defines the URL path redirect rules using regexp.Rexexp with captures
+	// Every capture group in the values can be retrieved by index e.g. $1, $2 and so on.
+	RegexRules map[*regexp.Regexp]string
+}
+
+// RedirectRules returns a middleware that redirects requests which URL path matches one of the rules.
+//
+// Unlike Rewrite, which changes request URL internally, RedirectRules responds with `Location` header and
+// redirect status code (301) so the client sends new request to the target URL.
+//
+// Usage `Echo#Pre(RedirectRules(map[string]string{"/old-blog/*": "/blog/$1"}))`
+func RedirectRules(rules map[string]string) echox.MiddlewareFunc {
+	return RedirectRulesWithConfig(RedirectRulesConfig{Rules: rules})
+}
+
+// RedirectRulesWithConfig returns a RedirectRules middleware with config or panics on invalid configuration.
+func RedirectRulesWithConfig(config RedirectRulesConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts RedirectRulesConfig to middleware or returns an error for invalid configuration
+func (config RedirectRulesConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if config.Skipper == nil {
+		config.Skipper = DefaultSkipper
+	}
+
+	if config.Code == 0 {
+		config.Code = http.StatusMovedPermanently
+	}
+
+	if !isRedirectCode(config.Code) {
+		return nil, errors.New("echo redirect rules middleware code must be one of 301, 302, 303, 307 or 308")
+	}
+
+	if config.Rules == nil && config.RegexRules == nil {
+		return nil, errors.New("echo redirect rules middleware requires url path redirect rules or regex rules")
+	}
+
+	rules := make(map[*regexp.Regexp]string, len(config.Rules)+len(config.RegexRules))
+	for k, v := range config.RegexRules {
+		rules[k] = v
+	}
+
+	for k, v := range rewriteRulesRegex(config.Rules) {
+		rules[k] = v
+	}
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			if url, ok := matchRewriteRules(rules, requestPathURI(c.Request())); ok {
+				// captures come from client path so target could start with `//` or `\/` making it absolute URL
+				return c.Redirect(config.Code, sanitizeURI(url))
+			}
+
+			return next(c)
+		}
+	}, nil
+}
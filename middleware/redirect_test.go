@@ -3,6 +3,7 @@ package middleware
 import (
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -281,3 +282,294 @@ func redirectTest(fn middlewareGenerator, host string, header http.Header) *http
 
 	return res
 }
+
+func TestRedirectRules(t *testing.T) {
+	var testCases = []struct {
+		name             string
+		givenConfig      RedirectRulesConfig
+		whenURL          string
+		expectLocation   string
+		expectStatusCode int
+		expectErr        string
+	}{
+		{
+			name:             "ok, captured path is substituted",
+			givenConfig:      RedirectRulesConfig{Rules: map[string]string{"/old-blog/*": "/blog/$1"}},
+			whenURL:          "/old-blog/2024/hello?page=2",
+			expectLocation:   "/blog/2024/hello?page=2",
+			expectStatusCode: http.StatusMovedPermanently,
+		},
+		{
+			name: "ok, custom code",
+			givenConfig: RedirectRulesConfig{
+				Code:  http.StatusPermanentRedirect,
+				Rules: map[string]string{"/old": "https://example.com/new"},
+			},
+			whenURL:          "/old",
+			expectLocation:   "https://example.com/new",
+			expectStatusCode: http.StatusPermanentRedirect,
+		},
+		{
+			name: "ok, regex rules",
+			givenConfig: RedirectRulesConfig{
+				Code:       http.StatusFound,
+				RegexRules: map[*regexp.Regexp]string{regexp.MustCompile(`^/v1/(.*)`): "/v2/$1"},
+			},
+			whenURL:          "/v1/users",
+			expectLocation:   "/v2/users",
+			expectStatusCode: http.StatusFound,
+		},
+		{
+			name:             "ok, capture starting with // does not redirect to other host",
+			givenConfig:      RedirectRulesConfig{Rules: map[string]string{"/old/*": "/$1"}},
+			whenURL:          "/old//evil.com",
+			expectLocation:   "/evil.com",
+			expectStatusCode: http.StatusMovedPermanently,
+		},
+		{
+			name:             "ok, capture starting with \\/ does not redirect to other host",
+			givenConfig:      RedirectRulesConfig{Rules: map[string]string{"/old/*": "/$1"}},
+			whenURL:          "/old/\\/evil.com",
+			expectLocation:   "/evil.com",
+			expectStatusCode: http.StatusMovedPermanently,
+		},
+		{
+			name:             "ok, path not matching rules is passed to next handler",
+			givenConfig:      RedirectRulesConfig{Rules: map[string]string{"/old-blog/*": "/blog/$1"}},
+			whenURL:          "/blog/2024",
+			expectStatusCode: http.StatusOK,
+		},
+		{
+			name:        "nok, missing rules",
+			givenConfig: RedirectRulesConfig{},
+			expectErr:   "echo redirect rules middleware requires url path redirect rules or regex rules",
+		},
+		{
+			name:        "nok, non redirect code",
+			givenConfig: RedirectRulesConfig{Code: http.StatusOK, Rules: map[string]string{"/a": "/b"}},
+			expectErr:   "echo redirect rules middleware code must be one of 301, 302, 303, 307 or 308",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mw, err := tc.givenConfig.ToMiddleware()
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			e := echox.New()
+			e.Pre(mw)
+			e.GET("/*", func(c echox.Context) error {
+				return c.String(http.StatusOK, "OK")
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tc.whenURL, nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectStatusCode, rec.Code)
+			assert.Equal(t, tc.expectLocation, rec.Header().Get(echox.HeaderLocation))
+		})
+	}
+}
+
+func TestRedirectConfig_invalidCode(t *testing.T) {
+	var testCases = []struct {
+		name      string
+		whenCode  int
+		expectErr string
+	}{
+		{
+			name:     "ok, 0 defaults to 301",
+			whenCode: 0,
+		},
+		{
+			name:     "ok, 307",
+			whenCode: http.StatusTemporaryRedirect,
+		},
+		{
+			name:      "nok, typo",
+			whenCode:  30,
+			expectErr: "echo redirect middleware code must be one of 301, 302, 303, 307 or 308",
+		},
+		{
+			name:      "nok, 304 is not a redirect",
+			whenCode:  http.StatusNotModified,
+			expectErr: "echo redirect middleware code must be one of 301, 302, 303, 307 or 308",
+		},
+		{
+			name:      "nok, negative",
+			whenCode:  -1,
+			expectErr: "echo redirect middleware code must be one of 301, 302, 303, 307 or 308",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := RedirectNonWWWConfig
+			config.Code = tc.whenCode
+
+			mw, err := config.ToMiddleware()
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+				assert.Nil(t, mw)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, mw)
+			}
+		})
+	}
+}
+
+func TestRedirect_preservesPathAndQuery(t *testing.T) {
+	var testCases = []struct {
+		name           string
+		givenMw        middlewareGenerator
+		whenHost       string
+		whenTarget     string
+		expectLocation string
+	}{
+		{
+			name:           "https, path and query",
+			givenMw:        HTTPSRedirect,
+			whenHost:       "labstack.com",
+			whenTarget:     "/page?x=1",
+			expectLocation: "https://labstack.com/page?x=1",
+		},
+		{
+			name:           "https, escaped path and repeated query",
+			givenMw:        HTTPSRedirect,
+			whenHost:       "labstack.com",
+			whenTarget:     "/a%2Fb/c?x=1&x=2&y=%20",
+			expectLocation: "https://labstack.com/a%2Fb/c?x=1&x=2&y=%20",
+		},
+		{
+			name:           "https, absolute request URI",
+			givenMw:        HTTPSRedirect,
+			whenHost:       "labstack.com",
+			whenTarget:     "http://labstack.com/page?x=1",
+			expectLocation: "https://labstack.com/page?x=1",
+		},
+		{
+			name:           "https www, path and query",
+			givenMw:        HTTPSWWWRedirect,
+			whenHost:       "labstack.com",
+			whenTarget:     "/page?x=1",
+			expectLocation: "https://www.labstack.com/page?x=1",
+		},
+		{
+			name:           "https non www, path and query",
+			givenMw:        HTTPSNonWWWRedirect,
+			whenHost:       "www.labstack.com",
+			whenTarget:     "/page?x=1",
+			expectLocation: "https://labstack.com/page?x=1",
+		},
+		{
+			name:           "www, path and query",
+			givenMw:        WWWRedirect,
+			whenHost:       "labstack.com",
+			whenTarget:     "/page?x=1",
+			expectLocation: "http://www.labstack.com/page?x=1",
+		},
+		{
+			name:           "non www, path and query",
+			givenMw:        NonWWWRedirect,
+			whenHost:       "www.labstack.com",
+			whenTarget:     "/page?x=1",
+			expectLocation: "http://labstack.com/page?x=1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			req := httptest.NewRequest(http.MethodGet, tc.whenTarget, nil)
+			req.Host = tc.whenHost
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := tc.givenMw()(func(c echox.Context) error {
+				return c.NoContent(http.StatusOK)
+			})(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+			assert.Equal(t, tc.expectLocation, rec.Header().Get(echox.HeaderLocation))
+		})
+	}
+}
+
+func TestRedirect_ACMEChallenge(t *testing.T) {
+	var testCases = []struct {
+		name             string
+		givenMw          middlewareGenerator
+		whenHost         string
+		whenTarget       string
+		expectStatusCode int
+	}{
+		{
+			name:             "https, challenge is not redirected",
+			givenMw:          HTTPSRedirect,
+			whenTarget:       "/.well-known/acme-challenge/token123",
+			expectStatusCode: http.StatusOK,
+		},
+		{
+			name:             "https www, challenge is not redirected",
+			givenMw:          HTTPSWWWRedirect,
+			whenHost:         "labstack.com",
+			whenTarget:       "/.well-known/acme-challenge/token123",
+			expectStatusCode: http.StatusOK,
+		},
+		{
+			name:             "https non www, challenge is not redirected",
+			givenMw:          HTTPSNonWWWRedirect,
+			whenTarget:       "/.well-known/acme-challenge/token123",
+			expectStatusCode: http.StatusOK,
+		},
+		{
+			name:             "https, other well-known path is redirected",
+			givenMw:          HTTPSRedirect,
+			whenTarget:       "/.well-known/security.txt",
+			expectStatusCode: http.StatusMovedPermanently,
+		},
+		{
+			name: "https, custom skipper replaces default",
+			givenMw: func() echox.MiddlewareFunc {
+				return HTTPSRedirectWithConfig(RedirectConfig{Skipper: func(c echox.Context) bool { return false }})
+			},
+			whenTarget:       "/.well-known/acme-challenge/token123",
+			expectStatusCode: http.StatusMovedPermanently,
+		},
+		{
+			name: "non www, explicit ACME skipper",
+			givenMw: func() echox.MiddlewareFunc {
+				return NonWWWRedirectWithConfig(RedirectConfig{Skipper: ACMEChallengeSkipper})
+			},
+			whenTarget:       "/.well-known/acme-challenge/token123",
+			expectStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			req := httptest.NewRequest(http.MethodGet, tc.whenTarget, nil)
+			req.Host = "www.labstack.com"
+			if tc.whenHost != "" {
+				req.Host = tc.whenHost
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := tc.givenMw()(func(c echox.Context) error {
+				return c.NoContent(http.StatusOK)
+			})(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectStatusCode, rec.Code)
+		})
+	}
+}
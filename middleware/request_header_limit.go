@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/theopenlane/echox"
+)
+
+// RequestHeaderLimitConfig defines the config for RequestHeaderLimit middleware.
+type RequestHeaderLimitConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// Limit is maximum total size of request headers in bytes. Size of every header line is calculated as it is sent
+	// over the wire (`Name: value\r\n`). Requests with larger headers are rejected with
+	// "431 - Request Header Fields Too Large" response.
+	// Optional. Default value 8KB.
+	Limit int
+
+	// HeaderLimits are maximum sizes in bytes of individual headers (i.e. `{"Cookie": 4096}`). Size of header is the
+	// sum of sizes of all its lines. Names are matched case-insensitively. Limit 0 means there is no limit for the
+	// header (only total Limit applies).
+	// Optional. Default value nil.
+	HeaderLimits map[string]int
+}
+
+// DefaultRequestHeaderLimitConfig is the default RequestHeaderLimit middleware config.
+var DefaultRequestHeaderLimitConfig = RequestHeaderLimitConfig{
+	Skipper: DefaultSkipper,
+	Limit:   8 * int(KB),
+}
+
+// RequestHeaderLimit returns a RequestHeaderLimit middleware that rejects requests with headers larger than limit
+// bytes with "431 - Request Header Fields Too Large" response.
+//
+// Add it with `e.Pre` so oversized requests are rejected before any other middleware processes them.
+func RequestHeaderLimit(limit int) echox.MiddlewareFunc {
+	return RequestHeaderLimitWithConfig(RequestHeaderLimitConfig{Limit: limit})
+}
+
+// RequestHeaderLimitWithConfig returns a RequestHeaderLimit middleware with config.
+// See: `RequestHeaderLimit()`.
+func RequestHeaderLimitWithConfig(config RequestHeaderLimitConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts RequestHeaderLimitConfig to middleware or returns an error for invalid configuration
+func (config RequestHeaderLimitConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if config.Skipper == nil {
+		config.Skipper = DefaultRequestHeaderLimitConfig.Skipper
+	}
+
+	if config.Limit < 0 {
+		return nil, errors.New("echo request header limit middleware limit can not be negative")
+	}
+
+	if config.Limit == 0 {
+		config.Limit = DefaultRequestHeaderLimitConfig.Limit
+	}
+
+	headerLimits := make(map[string]int, len(config.HeaderLimits))
+	for name, limit := range config.HeaderLimits {
+		if limit < 0 {
+			return nil, errors.New("echo request header limit middleware header limit can not be negative")
+		}
+
+		if limit == 0 {
+			continue
+		}
+
+		headerLimits[http.CanonicalHeaderKey(name)] = limit
+	}
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			total := 0
+
+			for name, values := range c.Request().Header {
+				size := 0
+				for _, v := range values {
+					size += len(name) + len(v) + 4 // ": " and "\r\n"
+				}
+
+				if limit, ok := headerLimits[name]; ok && size > limit {
+					return echox.ErrRequestHeaderFieldsTooLarge
+				}
+
+				total += size
+				if total > config.Limit {
+					return echox.ErrRequestHeaderFieldsTooLarge
+				}
+			}
+
+			return next(c)
+		}
+	}, nil
+}
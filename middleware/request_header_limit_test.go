@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
+)
+
+func TestRequestHeaderLimitWithConfig(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		givenConfig RequestHeaderLimitConfig
+		whenHeaders map[string]string
+		expectErr   string
+	}{
+		{
+			name:        "ok, headers within default limit",
+			whenHeaders: map[string]string{"Cookie": strings.Repeat("a", 4*1024)},
+		},
+		{
+			name:        "nok, 16KB cookie exceeds 8KB limit",
+			givenConfig: RequestHeaderLimitConfig{Limit: 8 * 1024},
+			whenHeaders: map[string]string{"Cookie": strings.Repeat("a", 16*1024)},
+			expectErr:   "code=431, message=Request Header Fields Too Large",
+		},
+		{
+			name:        "nok, sum of headers exceeds limit",
+			givenConfig: RequestHeaderLimitConfig{Limit: 100},
+			whenHeaders: map[string]string{
+				"Cookie":        strings.Repeat("a", 40),
+				"Authorization": strings.Repeat("b", 40),
+			},
+			expectErr: "code=431, message=Request Header Fields Too Large",
+		},
+		{
+			name: "nok, header exceeds its own limit",
+			givenConfig: RequestHeaderLimitConfig{
+				HeaderLimits: map[string]int{"cookie": 1024},
+			},
+			whenHeaders: map[string]string{"Cookie": strings.Repeat("a", 2*1024)},
+			expectErr:   "code=431, message=Request Header Fields Too Large",
+		},
+		{
+			name: "ok, header within its own limit",
+			givenConfig: RequestHeaderLimitConfig{
+				HeaderLimits: map[string]int{"Cookie": 1024},
+			},
+			whenHeaders: map[string]string{"Cookie": strings.Repeat("a", 512)},
+		},
+		{
+			name: "ok, header limit 0 means no limit for the header",
+			givenConfig: RequestHeaderLimitConfig{
+				HeaderLimits: map[string]int{"Cookie": 0},
+			},
+			whenHeaders: map[string]string{"Cookie": strings.Repeat("a", 512)},
+		},
+		{
+			name: "ok, skipped",
+			givenConfig: RequestHeaderLimitConfig{
+				Skipper: func(c echox.Context) bool { return true },
+				Limit:   10,
+			},
+			whenHeaders: map[string]string{"Cookie": strings.Repeat("a", 100)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range tc.whenHeaders {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := RequestHeaderLimitWithConfig(tc.givenConfig)(func(c echox.Context) error {
+				return c.String(http.StatusOK, "test")
+			})(c)
+
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "test", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestRequestHeaderLimit_preMiddleware(t *testing.T) {
+	e := echox.New()
+	e.Pre(RequestHeaderLimit(8 * 1024))
+	e.GET("/", func(c echox.Context) error {
+		return c.String(http.StatusOK, "test")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echox.HeaderCookie, "session="+strings.Repeat("a", 16*1024))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusRequestHeaderFieldsTooLarge, rec.Code)
+}
+
+func TestRequestHeaderLimitWithConfig_invalidConfig(t *testing.T) {
+	_, err := RequestHeaderLimitConfig{Limit: -1}.ToMiddleware()
+	assert.EqualError(t, err, "echo request header limit middleware limit can not be negative")
+
+	_, err = RequestHeaderLimitConfig{HeaderLimits: map[string]int{"Cookie": -1}}.ToMiddleware()
+	assert.EqualError(t, err, "echo request header limit middleware header limit can not be negative")
+}
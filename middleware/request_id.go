@@ -16,6 +16,30 @@ type RequestIDConfig struct {
 
 	// TargetHeader defines what header to look for to populate the id
 	TargetHeader string
+
+	// Validator decides if request id received in TargetHeader is passed through. Invalid ids are replaced with
+	// id from Generator so they are not reflected to response headers and logs.
+	// Optional. Default value DefaultRequestIDValidator.
+	Validator func(requestID string) bool
+}
+
+// maxRequestIDLength is maximum length of incoming request id accepted by DefaultRequestIDValidator
+const maxRequestIDLength = 128
+
+// DefaultRequestIDValidator accepts request ids that are at most 128 bytes long and do not contain control characters
+// (i.e. newlines that could be used for header or log injection).
+func DefaultRequestIDValidator(requestID string) bool {
+	if len(requestID) > maxRequestIDLength {
+		return false
+	}
+
+	for i := 0; i < len(requestID); i++ {
+		if b := requestID[i]; b < 0x20 || b == 0x7f {
+			return false
+		}
+	}
+
+	return true
 }
 
 // RequestID returns a X-Request-ID middleware.
@@ -42,6 +66,10 @@ func (config RequestIDConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 		config.TargetHeader = echox.HeaderXRequestID
 	}
 
+	if config.Validator == nil {
+		config.Validator = DefaultRequestIDValidator
+	}
+
 	return func(next echox.HandlerFunc) echox.HandlerFunc {
 		return func(c echox.Context) error {
 			if config.Skipper(c) {
@@ -52,7 +80,7 @@ func (config RequestIDConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 			res := c.Response()
 
 			rid := req.Header.Get(config.TargetHeader)
-			if rid == "" {
+			if rid == "" || !config.Validator(rid) {
 				rid = config.Generator()
 			}
 
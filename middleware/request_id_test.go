@@ -3,6 +3,7 @@ package middleware
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -169,3 +170,72 @@ func TestRequestIDConfigDifferentHeader(t *testing.T) {
 	assert.Equal(t, rec.Header().Get(echox.HeaderXCorrelationID), "customGenerator")
 	assert.True(t, calledHandler)
 }
+
+func TestRequestID_invalidIDRegenerated(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		givenConfig RequestIDConfig
+		whenID      string
+		expectID    string
+	}{
+		{
+			name:     "ok, valid id is passed through",
+			whenID:   "<sample-request-id>",
+			expectID: "<sample-request-id>",
+		},
+		{
+			name:     "ok, id with newline is regenerated",
+			whenID:   "abc\r\nSet-Cookie: a=b",
+			expectID: "generated",
+		},
+		{
+			name:     "ok, id with tab is regenerated",
+			whenID:   "abc\tdef",
+			expectID: "generated",
+		},
+		{
+			name:     "ok, id at max length is passed through",
+			whenID:   strings.Repeat("a", 128),
+			expectID: strings.Repeat("a", 128),
+		},
+		{
+			name:     "ok, too long id is regenerated",
+			whenID:   strings.Repeat("a", 129),
+			expectID: "generated",
+		},
+		{
+			name: "ok, custom validator",
+			givenConfig: RequestIDConfig{
+				Validator: func(requestID string) bool { return strings.HasPrefix(requestID, "req-") },
+			},
+			whenID:   "<sample-request-id>",
+			expectID: "generated",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header[echox.HeaderXRequestID] = []string{tc.whenID}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			config := tc.givenConfig
+			config.Generator = func() string { return "generated" }
+
+			var handledID string
+			config.RequestIDHandler = func(c echox.Context, requestID string) {
+				handledID = requestID
+			}
+
+			err := RequestIDWithConfig(config)(func(c echox.Context) error {
+				return c.String(http.StatusOK, "test")
+			})(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectID, rec.Header().Get(echox.HeaderXRequestID))
+			assert.Equal(t, tc.expectID, handledID)
+		})
+	}
+}
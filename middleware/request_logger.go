@@ -132,6 +132,8 @@ type RequestLoggerConfig struct {
 	LogRoutePath bool
 	// LogRequestID instructs logger to extract request ID from request `X-Request-ID` header or response if request did not have value.
 	LogRequestID bool
+	// LogSpanID instructs logger to extract span ID and parent span ID stored in context by SpanID middleware.
+	LogSpanID bool
 	// LogReferer instructs logger to extract request referer values.
 	LogReferer bool
 	// LogUserAgent instructs logger to extract request user agent values.
@@ -144,6 +146,9 @@ type RequestLoggerConfig struct {
 	// LogContentLength instructs logger to extract content length header value. Note: this value could be different from
 	// actual request body size as it could be spoofed etc.
 	LogContentLength bool
+	// LogBytesIn instructs logger to extract number of request body bytes actually read by the handler chain. Unlike
+	// content length header value it is accurate also for chunked requests.
+	LogBytesIn bool
 	// LogResponseSize instructs logger to extract response content length value. Note: when used with Gzip middleware
 	// this value may not be always correct.
 	LogResponseSize bool
@@ -185,6 +190,10 @@ type RequestLoggerValues struct {
 	RoutePath string
 	// RequestID is request ID from request `X-Request-ID` header or response if request did not have value.
 	RequestID string
+	// SpanID is span ID generated for the request by SpanID middleware.
+	SpanID string
+	// ParentSpanID is parent span ID received with the request and captured by SpanID middleware.
+	ParentSpanID string
 	// Referer is request referer values.
 	Referer string
 	// UserAgent is request user agent values.
@@ -196,6 +205,8 @@ type RequestLoggerValues struct {
 	// ContentLength is content length header value. Note: this value could be different from actual request body size
 	// as it could be spoofed etc.
 	ContentLength string
+	// BytesIn is number of request body bytes read by the handler chain.
+	BytesIn int64
 	// ResponseSize is response content length value. Note: when used with Gzip middleware this value may not be always correct.
 	ResponseSize int64
 	// Headers are list of headers from request. Note: request can contain more than one header with same value so slice
@@ -316,6 +327,11 @@ func (config RequestLoggerConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 				v.RequestID = id
 			}
 
+			if config.LogSpanID {
+				v.SpanID = contextString(c, ContextKeySpanID)
+				v.ParentSpanID = contextString(c, ContextKeyParentSpanID)
+			}
+
 			if config.LogReferer {
 				v.Referer = req.Referer()
 			}
@@ -345,8 +361,12 @@ func (config RequestLoggerConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 				v.ContentLength = req.Header.Get(echox.HeaderContentLength)
 			}
 
+			if config.LogBytesIn {
+				v.BytesIn = c.BytesIn()
+			}
+
 			if config.LogResponseSize {
-				v.ResponseSize = res.Size
+				v.ResponseSize = c.BytesOut()
 			}
 
 			if logHeaders {
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -468,3 +469,33 @@ func BenchmarkRequestLogger_withMapFields(b *testing.B) {
 		mw(c)
 	}
 }
+
+func TestRequestLogger_bytesIn(t *testing.T) {
+	e := echox.New()
+
+	var expect RequestLoggerValues
+	e.Use(RequestLoggerWithConfig(RequestLoggerConfig{
+		LogBytesIn:       true,
+		LogContentLength: true,
+		LogResponseSize:  true,
+		LogValuesFunc: func(c echox.Context, values RequestLoggerValues) error {
+			expect = values
+			return nil
+		},
+	}))
+	e.POST("/", func(c echox.Context) error {
+		if _, err := io.Copy(io.Discard, c.Request().Body); err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, "received")
+	})
+
+	// io.MultiReader hides body length so request is sent without Content-Length
+	req := httptest.NewRequest(http.MethodPost, "/", io.MultiReader(strings.NewReader(strings.Repeat("a", 1234))))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, "", expect.ContentLength)
+	assert.Equal(t, int64(1234), expect.BytesIn)
+	assert.Equal(t, int64(8), expect.ResponseSize)
+}
@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/theopenlane/echox"
+)
+
+// RequireScopesConfig defines the config for RequireScopes middleware.
+type RequireScopesConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// Scopes are scopes token must have to access the route. All scopes are required.
+	// Required.
+	Scopes []string
+
+	// ContextKey is context key JWT middleware stored token claims into. Used by default ClaimsExtractor.
+	// Optional. Default value "user".
+	ContextKey string
+
+	// ClaimsExtractor returns claims of authenticated token. Default extractor reads value stored in context with
+	// ContextKey when it is `*jwt.Token` with `jwt.MapClaims` claims (as stored by JWT middleware), `jwt.MapClaims` or
+	// `map[string]any`. Provide custom extractor when token claims are stored in other form (i.e. custom claims struct).
+	// Optional.
+	ClaimsExtractor func(c echox.Context) (map[string]any, error)
+
+	// ScopeClaims are names of claims scopes are read from. Claim value can be space-delimited string (i.e. `scope`
+	// in OAuth 2.0 tokens) or an array of strings. Scopes from all claims are combined.
+	// Optional. Default value ["scope", "scp"].
+	ScopeClaims []string
+}
+
+// ErrClaimsMissing denotes an error raised when token claims could not be found in context
+var ErrClaimsMissing = errors.New("token claims are missing from context")
+
+// DefaultRequireScopesConfig is the default RequireScopes middleware config.
+var DefaultRequireScopesConfig = RequireScopesConfig{
+	Skipper:     DefaultSkipper,
+	ContextKey:  "user",
+	ScopeClaims: []string{"scope", "scp"},
+}
+
+// RequireScopes returns a RequireScopes middleware.
+//
+// RequireScopes middleware checks that claims of token authenticated by JWT middleware contain all required scopes.
+// Requests without claims are rejected with "401 - Unauthorized" response and requests with insufficient scopes with
+// "403 - Forbidden" response. Add it to routes after JWT middleware.
+//
+// Example:
+//
+//	e.POST("/users", createUser, middleware.RequireScopes("write:users"))
+func RequireScopes(scopes ...string) echox.MiddlewareFunc {
+	return RequireScopesWithConfig(RequireScopesConfig{Scopes: scopes})
+}
+
+// RequireScopesWithConfig returns a RequireScopes middleware with config.
+// See: `RequireScopes()`.
+func RequireScopesWithConfig(config RequireScopesConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts RequireScopesConfig to middleware or returns an error for invalid configuration
+func (config RequireScopesConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if len(config.Scopes) == 0 {
+		return nil, errors.New("echo require scopes middleware requires scopes")
+	}
+
+	if config.Skipper == nil {
+		config.Skipper = DefaultRequireScopesConfig.Skipper
+	}
+
+	if config.ContextKey == "" {
+		config.ContextKey = DefaultRequireScopesConfig.ContextKey
+	}
+
+	if len(config.ScopeClaims) == 0 {
+		config.ScopeClaims = DefaultRequireScopesConfig.ScopeClaims
+	}
+
+	if config.ClaimsExtractor == nil {
+		contextKey := config.ContextKey
+		config.ClaimsExtractor = func(c echox.Context) (map[string]any, error) {
+			claims, ok := claimsFromValue(c.Get(contextKey))
+			if !ok {
+				return nil, ErrClaimsMissing
+			}
+
+			return claims, nil
+		}
+	}
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			claims, err := config.ClaimsExtractor(c)
+			if err != nil {
+				return echox.ErrUnauthorized.WithInternal(err)
+			}
+
+			granted := make(map[string]struct{})
+			for _, name := range config.ScopeClaims {
+				for _, s := range scopesFromClaim(claims[name]) {
+					granted[s] = struct{}{}
+				}
+			}
+
+			for _, s := range config.Scopes {
+				if _, ok := granted[s]; !ok {
+					return echox.ErrForbidden
+				}
+			}
+
+			return next(c)
+		}
+	}, nil
+}
+
+// claimsFromValue returns claims of token stored in context by JWT middleware
+func claimsFromValue(v any) (map[string]any, bool) {
+	switch t := v.(type) {
+	case *jwt.Token:
+		if t == nil {
+			return nil, false
+		}
+
+		return claimsFromValue(t.Claims)
+	case jwt.MapClaims:
+		return t, true
+	case map[string]any:
+		return t, true
+	}
+
+	return nil, false
+}
+
+func scopesFromClaim(claim any) []string {
+	switch v := claim.(type) {
+	case string:
+		return strings.Fields(v)
+	case []string:
+		return v
+	case []any:
+		scopes := make([]string, 0, len(v))
+		for _, s := range v {
+			if str, ok := s.(string); ok {
+				scopes = append(scopes, str)
+			}
+		}
+
+		return scopes
+	}
+
+	return nil
+}
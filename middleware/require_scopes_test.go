@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
+)
+
+func TestRequireScopesWithConfig(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		givenConfig RequireScopesConfig
+		whenClaims  any
+		expectErr   string
+	}{
+		{
+			name:        "ok, jwt token with map claims",
+			givenConfig: RequireScopesConfig{Scopes: []string{"write:users"}},
+			whenClaims:  jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"scope": "read:users write:users"}),
+		},
+		{
+			name:        "ok, jwt map claims",
+			givenConfig: RequireScopesConfig{Scopes: []string{"write:users"}},
+			whenClaims:  jwt.MapClaims{"scp": []any{"write:users"}},
+		},
+		{
+			name:        "nok, jwt token with insufficient scopes",
+			givenConfig: RequireScopesConfig{Scopes: []string{"write:users"}},
+			whenClaims:  jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"scope": "read:users"}),
+			expectErr:   "code=403, message=Forbidden",
+		},
+		{
+			name:        "nok, jwt token with non map claims",
+			givenConfig: RequireScopesConfig{Scopes: []string{"write:users"}},
+			whenClaims:  jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: "user"}),
+			expectErr:   "code=401, message=Unauthorized, internal=token claims are missing from context",
+		},
+		{
+			name:        "ok, space-delimited scope claim has sufficient scopes",
+			givenConfig: RequireScopesConfig{Scopes: []string{"write:users"}},
+			whenClaims:  map[string]any{"scope": "read:users write:users"},
+		},
+		{
+			name:        "ok, array scope claim has sufficient scopes",
+			givenConfig: RequireScopesConfig{Scopes: []string{"read:users", "write:users"}},
+			whenClaims:  map[string]any{"scp": []any{"read:users", "write:users"}},
+		},
+		{
+			name:        "ok, string slice scope claim",
+			givenConfig: RequireScopesConfig{Scopes: []string{"write:users"}},
+			whenClaims:  map[string]any{"scp": []string{"write:users"}},
+		},
+		{
+			name:        "ok, custom scope claim",
+			givenConfig: RequireScopesConfig{Scopes: []string{"admin"}, ScopeClaims: []string{"permissions"}},
+			whenClaims:  map[string]any{"permissions": []any{"admin"}},
+		},
+		{
+			name:        "nok, insufficient scopes",
+			givenConfig: RequireScopesConfig{Scopes: []string{"write:users"}},
+			whenClaims:  map[string]any{"scope": "read:users"},
+			expectErr:   "code=403, message=Forbidden",
+		},
+		{
+			name:        "nok, one of required scopes missing",
+			givenConfig: RequireScopesConfig{Scopes: []string{"read:users", "write:users"}},
+			whenClaims:  map[string]any{"scope": "write:users"},
+			expectErr:   "code=403, message=Forbidden",
+		},
+		{
+			name:        "nok, no claims in context",
+			givenConfig: RequireScopesConfig{Scopes: []string{"write:users"}},
+			expectErr:   "code=401, message=Unauthorized, internal=token claims are missing from context",
+		},
+		{
+			name: "nok, custom claims extractor error",
+			givenConfig: RequireScopesConfig{
+				Scopes: []string{"write:users"},
+				ClaimsExtractor: func(c echox.Context) (map[string]any, error) {
+					return nil, errors.New("unexpected claims type")
+				},
+			},
+			whenClaims: map[string]any{"scope": "write:users"},
+			expectErr:  "code=401, message=Unauthorized, internal=unexpected claims type",
+		},
+		{
+			name: "ok, skipped",
+			givenConfig: RequireScopesConfig{
+				Scopes:  []string{"write:users"},
+				Skipper: func(c echox.Context) bool { return true },
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			req := httptest.NewRequest(http.MethodPost, "/users", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			if tc.whenClaims != nil {
+				c.Set("user", tc.whenClaims)
+			}
+
+			err := RequireScopesWithConfig(tc.givenConfig)(func(c echox.Context) error {
+				return c.String(http.StatusOK, "test")
+			})(c)
+
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "test", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestRequireScopes_perRoute(t *testing.T) {
+	e := echox.New()
+	e.Use(func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			c.Set("user", jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"scope": "read:users"}))
+			return next(c)
+		}
+	})
+	handler := func(c echox.Context) error {
+		return c.String(http.StatusOK, "test")
+	}
+	e.GET("/users", handler, RequireScopes("read:users"))
+	e.POST("/users", handler, RequireScopes("write:users"))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users", nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestRequireScopesWithConfig_invalidConfig(t *testing.T) {
+	_, err := RequireScopesConfig{}.ToMiddleware()
+	assert.EqualError(t, err, "echo require scopes middleware requires scopes")
+}
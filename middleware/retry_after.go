@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/theopenlane/echox"
+)
+
+// RetryAfterConfig defines the config for RetryAfter middleware.
+type RetryAfterConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// Delay is default delay sent in `Retry-After` header. It is rounded up to whole seconds.
+	// Optional. Default value 60 seconds.
+	Delay time.Duration
+
+	// StatusCodes are response status codes `Retry-After` header is added to.
+	// Optional. Default value [429, 503].
+	StatusCodes []int
+}
+
+// DefaultRetryAfterConfig is the default RetryAfter middleware config.
+var DefaultRetryAfterConfig = RetryAfterConfig{
+	Skipper:     DefaultSkipper,
+	Delay:       60 * time.Second,
+	StatusCodes: []int{http.StatusTooManyRequests, http.StatusServiceUnavailable},
+}
+
+// RetryAfter returns a RetryAfter middleware that adds `Retry-After` header with given delay to "429 - Too Many
+// Requests" and "503 - Service Unavailable" responses that do not have the header already. Header value set by
+// handlers or other middlewares (i.e. RateLimiter) is never overwritten.
+//
+// Add it with `e.Pre` or as the first `e.Use` middleware so it covers responses written by all other middlewares
+// and the HTTPErrorHandler.
+func RetryAfter(delay time.Duration) echox.MiddlewareFunc {
+	return RetryAfterWithConfig(RetryAfterConfig{Delay: delay})
+}
+
+// RetryAfterWithConfig returns a RetryAfter middleware with config.
+// See: `RetryAfter()`.
+func RetryAfterWithConfig(config RetryAfterConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts RetryAfterConfig to middleware or returns an error for invalid configuration
+func (config RetryAfterConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if config.Skipper == nil {
+		config.Skipper = DefaultRetryAfterConfig.Skipper
+	}
+
+	if config.Delay < 0 {
+		return nil, errors.New("echo retry after middleware delay can not be negative")
+	}
+
+	if config.Delay == 0 {
+		config.Delay = DefaultRetryAfterConfig.Delay
+	}
+
+	if len(config.StatusCodes) == 0 {
+		config.StatusCodes = DefaultRetryAfterConfig.StatusCodes
+	}
+
+	retryAfter := strconv.Itoa(retryAfterSeconds(config.Delay))
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			res := c.Response()
+			res.Before(func() {
+				if res.Header().Get(echox.HeaderRetryAfter) != "" {
+					return
+				}
+
+				for _, code := range config.StatusCodes {
+					if res.Status == code {
+						res.Header().Set(echox.HeaderRetryAfter, retryAfter)
+						return
+					}
+				}
+			})
+
+			return next(c)
+		}
+	}, nil
+}
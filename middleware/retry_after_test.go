@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
+)
+
+func TestRetryAfterWithConfig(t *testing.T) {
+	var testCases = []struct {
+		name             string
+		givenConfig      RetryAfterConfig
+		whenHandler      echox.HandlerFunc
+		expectStatus     int
+		expectRetryAfter string
+	}{
+		{
+			name: "ok, 429 without Retry-After gets default",
+			whenHandler: func(c echox.Context) error {
+				return c.NoContent(http.StatusTooManyRequests)
+			},
+			expectStatus:     http.StatusTooManyRequests,
+			expectRetryAfter: "60",
+		},
+		{
+			name:        "ok, 503 error gets configured delay",
+			givenConfig: RetryAfterConfig{Delay: 1500 * time.Millisecond},
+			whenHandler: func(c echox.Context) error {
+				return echox.ErrServiceUnavailable
+			},
+			expectStatus:     http.StatusServiceUnavailable,
+			expectRetryAfter: "2",
+		},
+		{
+			name: "ok, explicit Retry-After is kept",
+			whenHandler: func(c echox.Context) error {
+				c.Response().Header().Set(echox.HeaderRetryAfter, "5")
+				return c.NoContent(http.StatusTooManyRequests)
+			},
+			expectStatus:     http.StatusTooManyRequests,
+			expectRetryAfter: "5",
+		},
+		{
+			name: "ok, other status codes are not changed",
+			whenHandler: func(c echox.Context) error {
+				return c.String(http.StatusOK, "test")
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:        "ok, custom status codes",
+			givenConfig: RetryAfterConfig{StatusCodes: []int{http.StatusServiceUnavailable}},
+			whenHandler: func(c echox.Context) error {
+				return c.NoContent(http.StatusTooManyRequests)
+			},
+			expectStatus: http.StatusTooManyRequests,
+		},
+		{
+			name: "ok, skipped",
+			givenConfig: RetryAfterConfig{
+				Skipper: func(c echox.Context) bool { return true },
+			},
+			whenHandler: func(c echox.Context) error {
+				return c.NoContent(http.StatusTooManyRequests)
+			},
+			expectStatus: http.StatusTooManyRequests,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			e.Use(RetryAfterWithConfig(tc.givenConfig))
+			e.GET("/", tc.whenHandler)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectStatus, rec.Code)
+			assert.Equal(t, tc.expectRetryAfter, rec.Header().Get(echox.HeaderRetryAfter))
+		})
+	}
+}
+
+func TestRetryAfter_keepsRateLimiterValue(t *testing.T) {
+	e := echox.New()
+	e.Use(RetryAfter(time.Minute))
+	e.Use(RateLimiter(NewRateLimiterMemoryStoreWithConfig(RateLimiterMemoryStoreConfig{Rate: 0.5, Burst: 1})))
+	e.GET("/", func(c echox.Context) error {
+		return c.String(http.StatusOK, "test")
+	})
+
+	var rec *httptest.ResponseRecorder
+	for i := 0; i < 2; i++ {
+		rec = httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get(echox.HeaderRetryAfter))
+}
+
+func TestRetryAfterWithConfig_invalidConfig(t *testing.T) {
+	_, err := RetryAfterConfig{Delay: -time.Second}.ToMiddleware()
+	assert.EqualError(t, err, "echo retry after middleware delay can not be negative")
+}
@@ -1,7 +1,12 @@
 package middleware
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/theopenlane/echox"
 )
@@ -33,6 +38,12 @@ type SecureConfig struct {
 	// - "ALLOW-FROM uri" - The page can only be displayed in a frame on the specified origin.
 	XFrameOptions string
 
+	// PreferCSPFrameAncestors omits the `X-Frame-Options` header when ContentSecurityPolicy contains `frame-ancestors`
+	// directive. Browsers supporting CSP ignore `X-Frame-Options` when `frame-ancestors` is present so sending both
+	// with different values only confuses. Has no effect with CSPReportOnly as report-only policy is not enforced.
+	// Optional. Default value false.
+	PreferCSPFrameAncestors bool
+
 	// HSTSMaxAge sets the `Strict-Transport-Security` header to indicate how
 	// long (in seconds) browsers should remember that this site is only to
 	// be accessed using HTTPS. This reduces your exposure to some SSL-stripping
@@ -46,13 +57,26 @@ type SecureConfig struct {
 	// Optional. Default value false.
 	HSTSExcludeSubdomains bool
 
+	// HSTSIncludeSubdomainsFunc decides per request if the subdomains tag is included in the `Strict Transport Security`
+	// header, i.e. to apply subdomains policy only to some hosts. When set HSTSExcludeSubdomains is ignored.
+	// Optional. Default value nil.
+	HSTSIncludeSubdomainsFunc func(c echox.Context) bool
+
 	// ContentSecurityPolicy sets the `Content-Security-Policy` header providing
 	// security against cross-site scripting (XSS), clickjacking and other code
 	// injection attacks resulting from execution of malicious content in the
 	// trusted web page context.
+	// Every `$NONCE` placeholder in the policy is replaced with a cryptographically random, base64 encoded nonce
+	// generated for each request (i.e. "script-src 'nonce-$NONCE'"). The nonce is stored into context under
+	// CSPNonceContextKey so templates can add it to `<script nonce="...">` tags.
 	// Optional. Default value "".
 	ContentSecurityPolicy string
 
+	// CSPNonceContextKey is context key to store generated Content-Security-Policy nonce into context. Nonce is only
+	// generated when ContentSecurityPolicy contains `$NONCE` placeholder.
+	// Optional. Default value "csp_nonce".
+	CSPNonceContextKey string
+
 	// CSPReportOnly would use the `Content-Security-Policy-Report-Only` header instead
 	// of the `Content-Security-Policy` header. This allows iterative updates of the
 	// content security policy by only reporting the violations that would
@@ -60,6 +84,20 @@ type SecureConfig struct {
 	// Optional. Default value false.
 	CSPReportOnly bool
 
+	// CSPReportURI is appended to ContentSecurityPolicy as `report-uri` directive. Browsers send violation reports to
+	// the URI. Multiple URIs can be separated by space.
+	// Optional. Default value "".
+	CSPReportURI string
+
+	// CSPReportTo is appended to ContentSecurityPolicy as `report-to` directive with reporting group name.
+	// Optional. Default value "".
+	CSPReportTo string
+
+	// CSPReportToEndpoint is URL of the CSPReportTo reporting group. When set, the companion `Report-To` header
+	// defining the group is sent with the response.
+	// Optional. Default value "".
+	CSPReportToEndpoint string
+
 	// HSTSPreloadEnabled will add the preload tag in the `Strict Transport Security`
 	// header, which enables the domain to be included in the HSTS preload list
 	// maintained by Chrome (and used by Firefox and Safari): https://hstspreload.org/
@@ -70,6 +108,12 @@ type SecureConfig struct {
 	// leaking potentially sensitive request paths to third parties.
 	// Optional. Default value "".
 	ReferrerPolicy string
+
+	// ApplyOnError sets the security headers again just before the response is written. This guarantees the headers
+	// are present also on responses written by the HTTPErrorHandler after an error was returned, even when downstream
+	// middlewares or handlers removed them.
+	// Optional. Default value false.
+	ApplyOnError bool
 }
 
 // DefaultSecureConfig is the default Secure middleware config.
@@ -79,8 +123,15 @@ var DefaultSecureConfig = SecureConfig{
 	ContentTypeNosniff: "nosniff",
 	XFrameOptions:      "SAMEORIGIN",
 	HSTSPreloadEnabled: false,
+	CSPNonceContextKey: "csp_nonce",
 }
 
+// cspNoncePlaceholder is replaced with per request nonce in SecureConfig.ContentSecurityPolicy
+const cspNoncePlaceholder = "$NONCE"
+
+// cspNonceLength is number of random bytes in Content-Security-Policy nonce (at least 128 bits as recommended by CSP3)
+const cspNonceLength = 16
+
 // Secure returns a Secure middleware.
 // Secure middleware provides protection against cross-site scripting (XSS) attack,
 // content type sniffing, clickjacking, insecure connection and other code injection
@@ -101,53 +152,188 @@ func (config SecureConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
 		config.Skipper = DefaultSecureConfig.Skipper
 	}
 
+	if config.CSPNonceContextKey == "" {
+		config.CSPNonceContextKey = DefaultSecureConfig.CSPNonceContextKey
+	}
+
+	if err := config.appendCSPReportDirectives(); err != nil {
+		return nil, err
+	}
+
+	reportTo := ""
+	if config.CSPReportToEndpoint != "" {
+		b, err := json.Marshal(cspReportToGroup{
+			Group:     config.CSPReportTo,
+			MaxAge:    cspReportToMaxAge,
+			Endpoints: []cspReportToEndpoint{{URL: config.CSPReportToEndpoint}},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		reportTo = string(b)
+	}
+
+	if config.PreferCSPFrameAncestors && !config.CSPReportOnly && hasCSPDirective(config.ContentSecurityPolicy, "frame-ancestors") {
+		config.XFrameOptions = ""
+	}
+
+	useNonce := strings.Contains(config.ContentSecurityPolicy, cspNoncePlaceholder)
+
 	return func(next echox.HandlerFunc) echox.HandlerFunc {
 		return func(c echox.Context) error {
 			if config.Skipper(c) {
 				return next(c)
 			}
 
-			req := c.Request()
-			res := c.Response()
+			var policy string
+			if config.ContentSecurityPolicy != "" {
+				policy = config.ContentSecurityPolicy
+
+				if useNonce {
+					nonce, err := generateCSPNonce()
+					if err != nil {
+						return err
+					}
 
-			if config.XSSProtection != "" {
-				res.Header().Set(echox.HeaderXXSSProtection, config.XSSProtection)
+					c.Set(config.CSPNonceContextKey, nonce)
+					policy = strings.ReplaceAll(policy, cspNoncePlaceholder, nonce)
+				}
 			}
 
-			if config.ContentTypeNosniff != "" {
-				res.Header().Set(echox.HeaderXContentTypeOptions, config.ContentTypeNosniff)
+			config.setHeaders(c, policy)
+
+			if reportTo != "" {
+				c.Response().Header().Set(echox.HeaderReportTo, reportTo)
 			}
 
-			if config.XFrameOptions != "" {
-				res.Header().Set(echox.HeaderXFrameOptions, config.XFrameOptions)
+			if config.ApplyOnError {
+				c.Response().Before(func() {
+					config.setHeaders(c, policy)
+				})
 			}
 
-			if (c.IsTLS() || (req.Header.Get(echox.HeaderXForwardedProto) == "https")) && config.HSTSMaxAge != 0 {
-				subdomains := ""
-				if !config.HSTSExcludeSubdomains {
-					subdomains = "; includeSubdomains"
-				}
+			return next(c)
+		}
+	}, nil
+}
 
-				if config.HSTSPreloadEnabled {
-					subdomains = fmt.Sprintf("%s; preload", subdomains)
-				}
+// cspReportToMaxAge is lifetime in seconds of reporting group sent in `Report-To` header
+const cspReportToMaxAge = 86400
 
-				res.Header().Set(echox.HeaderStrictTransportSecurity, fmt.Sprintf("max-age=%d%s", config.HSTSMaxAge, subdomains))
-			}
+type cspReportToGroup struct {
+	Group     string                `json:"group"`
+	MaxAge    int                   `json:"max_age"`
+	Endpoints []cspReportToEndpoint `json:"endpoints"`
+}
 
-			if config.ContentSecurityPolicy != "" {
-				if config.CSPReportOnly {
-					res.Header().Set(echox.HeaderContentSecurityPolicyReportOnly, config.ContentSecurityPolicy)
-				} else {
-					res.Header().Set(echox.HeaderContentSecurityPolicy, config.ContentSecurityPolicy)
-				}
-			}
+type cspReportToEndpoint struct {
+	URL string `json:"url"`
+}
 
-			if config.ReferrerPolicy != "" {
-				res.Header().Set(echox.HeaderReferrerPolicy, config.ReferrerPolicy)
-			}
+// appendCSPReportDirectives validates CSP reporting options and appends `report-uri` and `report-to` directives to
+// ContentSecurityPolicy
+func (config *SecureConfig) appendCSPReportDirectives() error {
+	if config.CSPReportURI == "" && config.CSPReportTo == "" {
+		if config.CSPReportToEndpoint != "" {
+			return errors.New("echo secure middleware CSP report-to endpoint requires CSP report-to group")
+		}
 
-			return next(c)
+		return nil
+	}
+
+	if config.ContentSecurityPolicy == "" {
+		return errors.New("echo secure middleware CSP reporting requires content security policy")
+	}
+
+	if config.CSPReportToEndpoint != "" && config.CSPReportTo == "" {
+		return errors.New("echo secure middleware CSP report-to endpoint requires CSP report-to group")
+	}
+
+	if strings.ContainsAny(config.CSPReportURI, ";,\r\n") || strings.ContainsAny(config.CSPReportTo, "; ,\r\n\t") {
+		return errors.New("echo secure middleware CSP report directive contains invalid characters")
+	}
+
+	policy := strings.TrimRight(config.ContentSecurityPolicy, "; ")
+
+	if config.CSPReportURI != "" {
+		policy += "; report-uri " + config.CSPReportURI
+	}
+
+	if config.CSPReportTo != "" {
+		policy += "; report-to " + config.CSPReportTo
+	}
+
+	config.ContentSecurityPolicy = policy
+
+	return nil
+}
+
+// hasCSPDirective checks if Content-Security-Policy contains directive with given name
+func hasCSPDirective(policy string, name string) bool {
+	for _, directive := range strings.Split(policy, ";") {
+		directiveName, _, _ := strings.Cut(strings.TrimSpace(directive), " ")
+		if strings.EqualFold(directiveName, name) {
+			return true
 		}
-	}, nil
+	}
+
+	return false
+}
+
+func generateCSPNonce() (string, error) {
+	b := make([]byte, cspNonceLength)
+	if _, err := io.ReadFull(RandReader, b); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// setHeaders sets the security headers with given (already nonce-substituted) Content-Security-Policy
+func (config SecureConfig) setHeaders(c echox.Context, policy string) {
+	req := c.Request()
+	res := c.Response()
+
+	if config.XSSProtection != "" {
+		res.Header().Set(echox.HeaderXXSSProtection, config.XSSProtection)
+	}
+
+	if config.ContentTypeNosniff != "" {
+		res.Header().Set(echox.HeaderXContentTypeOptions, config.ContentTypeNosniff)
+	}
+
+	if config.XFrameOptions != "" {
+		res.Header().Set(echox.HeaderXFrameOptions, config.XFrameOptions)
+	}
+
+	if (c.IsTLS() || (req.Header.Get(echox.HeaderXForwardedProto) == "https")) && config.HSTSMaxAge != 0 {
+		includeSubdomains := !config.HSTSExcludeSubdomains
+		if config.HSTSIncludeSubdomainsFunc != nil {
+			includeSubdomains = config.HSTSIncludeSubdomainsFunc(c)
+		}
+
+		subdomains := ""
+		if includeSubdomains {
+			subdomains = "; includeSubdomains"
+		}
+
+		if config.HSTSPreloadEnabled {
+			subdomains = fmt.Sprintf("%s; preload", subdomains)
+		}
+
+		res.Header().Set(echox.HeaderStrictTransportSecurity, fmt.Sprintf("max-age=%d%s", config.HSTSMaxAge, subdomains))
+	}
+
+	if policy != "" {
+		if config.CSPReportOnly {
+			res.Header().Set(echox.HeaderContentSecurityPolicyReportOnly, policy)
+		} else {
+			res.Header().Set(echox.HeaderContentSecurityPolicy, policy)
+		}
+	}
+
+	if config.ReferrerPolicy != "" {
+		res.Header().Set(echox.HeaderReferrerPolicy, config.ReferrerPolicy)
+	}
 }
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"encoding/base64"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -144,3 +145,314 @@ func TestSecureWithConfig_HSTSExcludeSubdomains(t *testing.T) {
 
 	assert.Equal(t, "max-age=3600; preload", rec.Header().Get(echox.HeaderStrictTransportSecurity))
 }
+
+func TestSecureWithConfig_HSTSIncludeSubdomainsFunc(t *testing.T) {
+	var testCases = []struct {
+		name       string
+		whenHost   string
+		expectHSTS string
+	}{
+		{
+			name:       "ok, subdomains included for apex domain",
+			whenHost:   "example.com",
+			expectHSTS: "max-age=3600; includeSubdomains",
+		},
+		{
+			name:       "ok, subdomains excluded for other host",
+			whenHost:   "legacy.example.org",
+			expectHSTS: "max-age=3600",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Host = tc.whenHost
+			req.Header.Set(echox.HeaderXForwardedProto, "https")
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := SecureWithConfig(SecureConfig{
+				HSTSMaxAge:            3600,
+				HSTSExcludeSubdomains: true, // ignored when func is set
+				HSTSIncludeSubdomainsFunc: func(c echox.Context) bool {
+					return c.Request().Host == "example.com"
+				},
+			})(func(c echox.Context) error {
+				return c.String(http.StatusOK, "test")
+			})(c)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectHSTS, rec.Header().Get(echox.HeaderStrictTransportSecurity))
+		})
+	}
+}
+
+func TestSecureWithConfig_CSPNonce(t *testing.T) {
+	e := echox.New()
+	mw := SecureWithConfig(SecureConfig{
+		ContentSecurityPolicy: "default-src 'self'; script-src 'nonce-$NONCE'; style-src 'nonce-$NONCE'",
+	})
+
+	nonces := make([]string, 0, 2)
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := mw(func(c echox.Context) error {
+			return c.String(http.StatusOK, "test")
+		})(c)
+		assert.NoError(t, err)
+
+		nonce, ok := c.Get("csp_nonce").(string)
+		assert.True(t, ok)
+
+		decoded, err := base64.StdEncoding.DecodeString(nonce)
+		assert.NoError(t, err)
+		assert.Len(t, decoded, 16)
+
+		expect := "default-src 'self'; script-src 'nonce-" + nonce + "'; style-src 'nonce-" + nonce + "'"
+		assert.Equal(t, expect, rec.Header().Get(echox.HeaderContentSecurityPolicy))
+
+		nonces = append(nonces, nonce)
+	}
+
+	assert.NotEqual(t, nonces[0], nonces[1])
+}
+
+func TestSecureWithConfig_CSPNonceCustomContextKey(t *testing.T) {
+	e := echox.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := SecureWithConfig(SecureConfig{
+		ContentSecurityPolicy: "script-src 'nonce-$NONCE'",
+		CSPReportOnly:         true,
+		CSPNonceContextKey:    "nonce",
+	})(func(c echox.Context) error {
+		return c.String(http.StatusOK, "test")
+	})(c)
+	assert.NoError(t, err)
+
+	nonce, _ := c.Get("nonce").(string)
+	assert.NotEmpty(t, nonce)
+	assert.Nil(t, c.Get("csp_nonce"))
+	assert.Equal(t, "script-src 'nonce-"+nonce+"'", rec.Header().Get(echox.HeaderContentSecurityPolicyReportOnly))
+}
+
+func TestSecureWithConfig_ApplyOnError(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		givenApply    bool
+		whenHandler   echox.HandlerFunc
+		expectHeaders bool
+	}{
+		{
+			name:       "ok, headers present on error response",
+			givenApply: true,
+			whenHandler: func(c echox.Context) error {
+				return echox.ErrForbidden
+			},
+			expectHeaders: true,
+		},
+		{
+			name:       "ok, headers removed downstream are set again for error response",
+			givenApply: true,
+			whenHandler: func(c echox.Context) error {
+				c.Response().Header().Del(echox.HeaderXFrameOptions)
+				c.Response().Header().Del(echox.HeaderContentSecurityPolicy)
+				return echox.ErrForbidden
+			},
+			expectHeaders: true,
+		},
+		{
+			name:       "ok, without ApplyOnError headers removed downstream are missing",
+			givenApply: false,
+			whenHandler: func(c echox.Context) error {
+				c.Response().Header().Del(echox.HeaderXFrameOptions)
+				c.Response().Header().Del(echox.HeaderContentSecurityPolicy)
+				return echox.ErrForbidden
+			},
+			expectHeaders: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			e.Use(SecureWithConfig(SecureConfig{
+				XFrameOptions:         "DENY",
+				ContentSecurityPolicy: "default-src 'self'",
+				ApplyOnError:          tc.givenApply,
+			}))
+			e.GET("/", tc.whenHandler)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusForbidden, rec.Code)
+			if tc.expectHeaders {
+				assert.Equal(t, "DENY", rec.Header().Get(echox.HeaderXFrameOptions))
+				assert.Equal(t, "default-src 'self'", rec.Header().Get(echox.HeaderContentSecurityPolicy))
+			} else {
+				assert.Equal(t, "", rec.Header().Get(echox.HeaderXFrameOptions))
+				assert.Equal(t, "", rec.Header().Get(echox.HeaderContentSecurityPolicy))
+			}
+		})
+	}
+}
+
+func TestSecureWithConfig_CSPReport(t *testing.T) {
+	var testCases = []struct {
+		name                string
+		givenConfig         SecureConfig
+		expectCSP           string
+		expectCSPReportOnly string
+		expectReportTo      string
+		expectErr           string
+	}{
+		{
+			name: "ok, report-uri",
+			givenConfig: SecureConfig{
+				ContentSecurityPolicy: "default-src 'self';",
+				CSPReportURI:          "https://example.com/csp-reports",
+			},
+			expectCSP: "default-src 'self'; report-uri https://example.com/csp-reports",
+		},
+		{
+			name: "ok, report-uri and report-to with endpoint",
+			givenConfig: SecureConfig{
+				ContentSecurityPolicy: "default-src 'self'",
+				CSPReportURI:          "/csp-reports",
+				CSPReportTo:           "csp-endpoint",
+				CSPReportToEndpoint:   "https://example.com/csp-reports",
+			},
+			expectCSP:      "default-src 'self'; report-uri /csp-reports; report-to csp-endpoint",
+			expectReportTo: `{"group":"csp-endpoint","max_age":86400,"endpoints":[{"url":"https://example.com/csp-reports"}]}`,
+		},
+		{
+			name: "ok, report-only policy",
+			givenConfig: SecureConfig{
+				ContentSecurityPolicy: "default-src 'self'",
+				CSPReportOnly:         true,
+				CSPReportTo:           "csp-endpoint",
+			},
+			expectCSPReportOnly: "default-src 'self'; report-to csp-endpoint",
+		},
+		{
+			name: "nok, report config without policy",
+			givenConfig: SecureConfig{
+				CSPReportURI: "/csp-reports",
+			},
+			expectErr: "echo secure middleware CSP reporting requires content security policy",
+		},
+		{
+			name: "nok, report-to endpoint without group",
+			givenConfig: SecureConfig{
+				ContentSecurityPolicy: "default-src 'self'",
+				CSPReportToEndpoint:   "https://example.com/csp-reports",
+			},
+			expectErr: "echo secure middleware CSP report-to endpoint requires CSP report-to group",
+		},
+		{
+			name: "nok, directive injection",
+			givenConfig: SecureConfig{
+				ContentSecurityPolicy: "default-src 'self'",
+				CSPReportURI:          "/csp-reports; script-src *",
+			},
+			expectErr: "echo secure middleware CSP report directive contains invalid characters",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mw, err := tc.givenConfig.ToMiddleware()
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			e := echox.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err = mw(func(c echox.Context) error {
+				return c.String(http.StatusOK, "test")
+			})(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectCSP, rec.Header().Get(echox.HeaderContentSecurityPolicy))
+			assert.Equal(t, tc.expectCSPReportOnly, rec.Header().Get(echox.HeaderContentSecurityPolicyReportOnly))
+			assert.Equal(t, tc.expectReportTo, rec.Header().Get(echox.HeaderReportTo))
+		})
+	}
+}
+
+func TestSecureWithConfig_PreferCSPFrameAncestors(t *testing.T) {
+	var testCases = []struct {
+		name                string
+		givenConfig         SecureConfig
+		expectXFrameOptions string
+	}{
+		{
+			name: "ok, X-Frame-Options omitted when CSP has frame-ancestors",
+			givenConfig: SecureConfig{
+				XFrameOptions:           "DENY",
+				ContentSecurityPolicy:   "default-src 'self'; frame-ancestors 'self'",
+				PreferCSPFrameAncestors: true,
+			},
+			expectXFrameOptions: "",
+		},
+		{
+			name: "ok, X-Frame-Options kept when CSP has no frame-ancestors",
+			givenConfig: SecureConfig{
+				XFrameOptions:           "DENY",
+				ContentSecurityPolicy:   "default-src 'self'",
+				PreferCSPFrameAncestors: true,
+			},
+			expectXFrameOptions: "DENY",
+		},
+		{
+			name: "ok, X-Frame-Options kept with report-only CSP",
+			givenConfig: SecureConfig{
+				XFrameOptions:           "DENY",
+				ContentSecurityPolicy:   "frame-ancestors 'self'",
+				CSPReportOnly:           true,
+				PreferCSPFrameAncestors: true,
+			},
+			expectXFrameOptions: "DENY",
+		},
+		{
+			name: "ok, both headers sent by default",
+			givenConfig: SecureConfig{
+				XFrameOptions:         "DENY",
+				ContentSecurityPolicy: "frame-ancestors 'self'",
+			},
+			expectXFrameOptions: "DENY",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := SecureWithConfig(tc.givenConfig)(func(c echox.Context) error {
+				return c.String(http.StatusOK, "test")
+			})(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectXFrameOptions, rec.Header().Get(echox.HeaderXFrameOptions))
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/theopenlane/echox"
+)
+
+// SetHeadersConfig defines the config for SetHeaders middleware.
+type SetHeadersConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// Headers are static headers set to every response (i.e. `X-App-Version`).
+	// Optional, but either Headers or HeaderFunc is required.
+	Headers map[string]string
+
+	// HeaderFunc returns headers set to response of the request (i.e. computed from request or context values).
+	// Headers returned by HeaderFunc are set after Headers and take precedence over them.
+	// Optional, but either Headers or HeaderFunc is required.
+	HeaderFunc func(c echox.Context) map[string]string
+
+	// SkipExisting leaves headers that already have a value in response (i.e. set by previous middlewares) unchanged.
+	// Optional. Default value false (existing values are overwritten).
+	SkipExisting bool
+}
+
+// SetHeaders returns a SetHeaders middleware that sets given headers to every response. Headers are set before the
+// next handler is called so handlers can still change them.
+func SetHeaders(headers map[string]string) echox.MiddlewareFunc {
+	return SetHeadersWithConfig(SetHeadersConfig{Headers: headers})
+}
+
+// SetHeadersWithConfig returns a SetHeaders middleware with config or panics on invalid configuration.
+// See: `SetHeaders()`.
+func SetHeadersWithConfig(config SetHeadersConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts SetHeadersConfig to middleware or returns an error for invalid configuration
+func (config SetHeadersConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if config.Skipper == nil {
+		config.Skipper = DefaultSkipper
+	}
+
+	if len(config.Headers) == 0 && config.HeaderFunc == nil {
+		return nil, errors.New("echo set headers middleware requires headers or header func")
+	}
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			set := func(headers map[string]string) {
+				for name, value := range headers {
+					if config.SkipExisting && header.Get(name) != "" {
+						continue
+					}
+
+					header.Set(name, value)
+				}
+			}
+
+			set(config.Headers)
+
+			if config.HeaderFunc != nil {
+				set(config.HeaderFunc(c))
+			}
+
+			return next(c)
+		}
+	}, nil
+}
@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
+)
+
+func TestSetHeaders(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		givenConfig   SetHeadersConfig
+		givenExisting map[string]string
+		expectHeaders map[string]string
+	}{
+		{
+			name:          "ok, static headers",
+			givenConfig:   SetHeadersConfig{Headers: map[string]string{"X-App-Version": "1.2.3", "X-Environment": "prod"}},
+			expectHeaders: map[string]string{"X-App-Version": "1.2.3", "X-Environment": "prod"},
+		},
+		{
+			name: "ok, static and dynamic headers",
+			givenConfig: SetHeadersConfig{
+				Headers: map[string]string{"X-App-Version": "1.2.3"},
+				HeaderFunc: func(c echox.Context) map[string]string {
+					return map[string]string{"X-Tenant": c.Request().Header.Get("X-Tenant-Id")}
+				},
+			},
+			expectHeaders: map[string]string{"X-App-Version": "1.2.3", "X-Tenant": "acme"},
+		},
+		{
+			name: "ok, dynamic headers take precedence",
+			givenConfig: SetHeadersConfig{
+				Headers: map[string]string{"X-Environment": "prod"},
+				HeaderFunc: func(c echox.Context) map[string]string {
+					return map[string]string{"X-Environment": "canary"}
+				},
+			},
+			expectHeaders: map[string]string{"X-Environment": "canary"},
+		},
+		{
+			name:          "ok, existing values are overwritten",
+			givenConfig:   SetHeadersConfig{Headers: map[string]string{"X-Environment": "prod"}},
+			givenExisting: map[string]string{"X-Environment": "dev"},
+			expectHeaders: map[string]string{"X-Environment": "prod"},
+		},
+		{
+			name: "ok, existing values are kept with SkipExisting",
+			givenConfig: SetHeadersConfig{
+				Headers:      map[string]string{"X-Environment": "prod", "X-App-Version": "1.2.3"},
+				SkipExisting: true,
+			},
+			givenExisting: map[string]string{"X-Environment": "dev"},
+			expectHeaders: map[string]string{"X-Environment": "dev", "X-App-Version": "1.2.3"},
+		},
+		{
+			name: "ok, skipper",
+			givenConfig: SetHeadersConfig{
+				Skipper: func(c echox.Context) bool { return true },
+				Headers: map[string]string{"X-App-Version": "1.2.3"},
+			},
+			expectHeaders: map[string]string{"X-App-Version": ""},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			e.Use(func(next echox.HandlerFunc) echox.HandlerFunc {
+				return func(c echox.Context) error {
+					for k, v := range tc.givenExisting {
+						c.Response().Header().Set(k, v)
+					}
+					return next(c)
+				}
+			})
+			e.Use(SetHeadersWithConfig(tc.givenConfig))
+			e.GET("/", func(c echox.Context) error {
+				return c.String(http.StatusOK, "OK")
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("X-Tenant-Id", "acme")
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			for k, v := range tc.expectHeaders {
+				assert.Equal(t, v, rec.Header().Get(k), k)
+			}
+		})
+	}
+}
+
+func TestSetHeadersWithConfig_missingHeaders(t *testing.T) {
+	mw, err := SetHeadersConfig{}.ToMiddleware()
+
+	assert.EqualError(t, err, "echo set headers middleware requires headers or header func")
+	assert.Nil(t, mw)
+}
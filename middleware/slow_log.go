@@ -0,0 +1,215 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/theopenlane/echox"
+)
+
+// SlowLogConfig defines the config for SlowLog middleware.
+type SlowLogConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// Store records request latencies per route.
+	// Required.
+	Store *SlowLogStore
+}
+
+// SlowRoute is latency summary of single route reported by SlowLogStore.
+type SlowRoute struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// Count is number of latencies in the window the summary was calculated from.
+	Count int `json:"count"`
+	// P95 is 95th percentile latency in nanoseconds.
+	P95 time.Duration `json:"p95"`
+	// Max is maximum latency in nanoseconds.
+	Max time.Duration `json:"max"`
+}
+
+// SlowLogStore keeps latencies of the most recent requests for each route in bounded ring buffers. It is safe for
+// concurrent use.
+type SlowLogStore struct {
+	routes     map[slowLogKey]*slowLogRing
+	mutex      sync.Mutex
+	windowSize int
+	topN       int
+
+	timeNow func() time.Time
+}
+
+type slowLogKey struct {
+	method string
+	path   string
+}
+
+type slowLogRing struct {
+	latencies []time.Duration
+	next      int
+}
+
+// SlowLogStoreConfig represents configuration for SlowLogStore
+type SlowLogStoreConfig struct {
+	WindowSize int // WindowSize is number of most recent latencies kept per route
+	TopN       int // TopN is number of routes returned by SlowLogStore.Handler
+}
+
+// DefaultSlowLogStoreConfig provides default configuration values for SlowLogStore
+var DefaultSlowLogStoreConfig = SlowLogStoreConfig{
+	WindowSize: 100,
+	TopN:       10,
+}
+
+// SlowLog returns a SlowLog middleware recording request latencies into given store.
+//
+// Latencies are recorded per route template (i.e. `/users/:id`) and not per request path so all requests to the same
+// route are summarized together. Requests that did not match any route are not recorded. Serve the current slowest
+// routes with `store.Handler`.
+//
+// Example:
+//
+//	store := middleware.NewSlowLogStore()
+//	e.Use(middleware.SlowLog(store))
+//	e.GET("/debug/slow", store.Handler)
+func SlowLog(store *SlowLogStore) echox.MiddlewareFunc {
+	return SlowLogWithConfig(SlowLogConfig{Store: store})
+}
+
+// SlowLogWithConfig returns a SlowLog middleware with config.
+// See: `SlowLog()`.
+func SlowLogWithConfig(config SlowLogConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts SlowLogConfig to middleware or returns an error for invalid configuration
+func (config SlowLogConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if config.Skipper == nil {
+		config.Skipper = DefaultSkipper
+	}
+
+	if config.Store == nil {
+		return nil, errors.New("echo slow log middleware requires a store")
+	}
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			start := config.Store.timeNow()
+			err := next(c)
+
+			if path := c.Path(); path != "" {
+				config.Store.Record(c.Request().Method, path, config.Store.timeNow().Sub(start))
+			}
+
+			return err
+		}
+	}, nil
+}
+
+// NewSlowLogStore returns an instance of SlowLogStore with default configuration.
+func NewSlowLogStore() *SlowLogStore {
+	return NewSlowLogStoreWithConfig(DefaultSlowLogStoreConfig)
+}
+
+// NewSlowLogStoreWithConfig returns an instance of SlowLogStore with the provided configuration.
+// WindowSize and TopN will be set to default values if not provided.
+func NewSlowLogStoreWithConfig(config SlowLogStoreConfig) (store *SlowLogStore) {
+	store = &SlowLogStore{}
+
+	store.windowSize = config.WindowSize
+	if config.WindowSize <= 0 {
+		store.windowSize = DefaultSlowLogStoreConfig.WindowSize
+	}
+
+	store.topN = config.TopN
+	if config.TopN <= 0 {
+		store.topN = DefaultSlowLogStoreConfig.TopN
+	}
+
+	store.routes = make(map[slowLogKey]*slowLogRing)
+	store.timeNow = time.Now
+
+	return
+}
+
+// Record adds request latency for given route method and path template. When window for the route is full the oldest
+// latency is overwritten.
+func (store *SlowLogStore) Record(method, path string, latency time.Duration) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	key := slowLogKey{method: method, path: path}
+
+	ring, ok := store.routes[key]
+	if !ok {
+		ring = &slowLogRing{latencies: make([]time.Duration, 0, store.windowSize)}
+		store.routes[key] = ring
+	}
+
+	if len(ring.latencies) < store.windowSize {
+		ring.latencies = append(ring.latencies, latency)
+		return
+	}
+
+	ring.latencies[ring.next] = latency
+	ring.next = (ring.next + 1) % store.windowSize
+}
+
+// Top returns at most n routes sorted by their 95th percentile latency, slowest first.
+func (store *SlowLogStore) Top(n int) []SlowRoute {
+	store.mutex.Lock()
+	result := make([]SlowRoute, 0, len(store.routes))
+
+	for key, ring := range store.routes {
+		sorted := append([]time.Duration(nil), ring.latencies...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		// nearest-rank percentile
+		rank := (len(sorted)*95 + 99) / 100
+
+		result = append(result, SlowRoute{
+			Method: key.method,
+			Path:   key.path,
+			Count:  len(sorted),
+			P95:    sorted[rank-1],
+			Max:    sorted[len(sorted)-1],
+		})
+	}
+	store.mutex.Unlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.P95 != b.P95 {
+			return a.P95 > b.P95
+		}
+
+		if a.Max != b.Max {
+			return a.Max > b.Max
+		}
+
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+
+		return a.Method < b.Method
+	})
+
+	if n >= 0 && len(result) > n {
+		result = result[:n]
+	}
+
+	return result
+}
+
+// Handler is a handler responding with JSON array of SlowLogStoreConfig.TopN slowest routes.
+func (store *SlowLogStore) Handler(c echox.Context) error {
+	return c.JSON(http.StatusOK, store.Top(store.topN))
+}
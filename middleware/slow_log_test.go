@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
+)
+
+func TestSlowLog(t *testing.T) {
+	store := NewSlowLogStore()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.timeNow = func() time.Time { return now }
+
+	e := echox.New()
+	e.Use(SlowLog(store))
+	e.GET("/users/:id", func(c echox.Context) error {
+		ms, _ := strconv.Atoi(c.PathParam("id"))
+		now = now.Add(time.Duration(ms) * time.Millisecond)
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/health", func(c echox.Context) error {
+		now = now.Add(time.Millisecond)
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/debug/slow", store.Handler)
+
+	for i := 1; i <= 100; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/users/"+strconv.Itoa(i), nil)
+		e.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	for i := 0; i < 5; i++ {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	}
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/not-found", nil))
+
+	req := httptest.NewRequest(http.MethodGet, "/debug/slow", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var result []SlowRoute
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+
+	expect := []SlowRoute{
+		{Method: http.MethodGet, Path: "/users/:id", Count: 100, P95: 95 * time.Millisecond, Max: 100 * time.Millisecond},
+		{Method: http.MethodGet, Path: "/health", Count: 5, P95: time.Millisecond, Max: time.Millisecond},
+	}
+	assert.Equal(t, expect, result)
+}
+
+func TestSlowLogStore_window(t *testing.T) {
+	store := NewSlowLogStoreWithConfig(SlowLogStoreConfig{WindowSize: 10})
+
+	for i := 20; i >= 1; i-- {
+		store.Record(http.MethodGet, "/users/:id", time.Duration(i)*time.Millisecond)
+	}
+
+	// only 10 most recent latencies (10ms..1ms) are kept
+	result := store.Top(5)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, 10, result[0].Count)
+		assert.Equal(t, 10*time.Millisecond, result[0].P95)
+		assert.Equal(t, 10*time.Millisecond, result[0].Max)
+	}
+}
+
+func TestSlowLogStore_Top(t *testing.T) {
+	store := NewSlowLogStore()
+
+	store.Record(http.MethodGet, "/a", 10*time.Millisecond)
+	store.Record(http.MethodGet, "/b", 30*time.Millisecond)
+	store.Record(http.MethodPost, "/b", 20*time.Millisecond)
+	store.Record(http.MethodGet, "/c", 5*time.Millisecond)
+
+	result := store.Top(2)
+	if assert.Len(t, result, 2) {
+		assert.Equal(t, SlowRoute{Method: http.MethodGet, Path: "/b", Count: 1, P95: 30 * time.Millisecond, Max: 30 * time.Millisecond}, result[0])
+		assert.Equal(t, SlowRoute{Method: http.MethodPost, Path: "/b", Count: 1, P95: 20 * time.Millisecond, Max: 20 * time.Millisecond}, result[1])
+	}
+	assert.Len(t, store.Top(10), 4)
+}
+
+func TestSlowLogStore_concurrentRecord(t *testing.T) {
+	store := NewSlowLogStoreWithConfig(SlowLogStoreConfig{WindowSize: 50})
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 1; j <= 100; j++ {
+				store.Record(http.MethodGet, "/users/:id", time.Duration(j)*time.Millisecond)
+				store.Top(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	result := store.Top(1)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, 50, result[0].Count)
+		assert.LessOrEqual(t, result[0].Max, 100*time.Millisecond)
+	}
+}
+
+func TestSlowLogWithConfig_requiresStore(t *testing.T) {
+	_, err := SlowLogConfig{}.ToMiddleware()
+
+	assert.EqualError(t, err, "echo slow log middleware requires a store")
+}
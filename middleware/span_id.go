@@ -0,0 +1,103 @@
+package middleware
+
+import "github.com/theopenlane/echox"
+
+const (
+	// ContextKeySpanID is context key under which SpanID middleware stores span ID generated for the current request.
+	ContextKeySpanID = "span_id"
+	// ContextKeyParentSpanID is context key under which SpanID middleware stores parent span ID received with request.
+	ContextKeyParentSpanID = "parent_span_id"
+)
+
+// SpanIDConfig defines the config for SpanID middleware.
+type SpanIDConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// Generator defines a function to generate a span ID.
+	// Optional. Default value random.String(16).
+	Generator func() string
+
+	// SpanIDHandler defines a function which is executed for a span id and parent span id (empty when request did not
+	// have one).
+	SpanIDHandler func(c echox.Context, spanID string, parentSpanID string)
+
+	// ParentHeader defines what header to look for to populate the parent span id.
+	// Optional. Default value echox.HeaderXParentSpan.
+	ParentHeader string
+
+	// ParentValidator decides if parent span id received in ParentHeader is accepted. Invalid ids are dropped (treated
+	// as if request had no parent span) so they are not stored in context and logs.
+	// Optional. Default value DefaultRequestIDValidator.
+	ParentValidator func(parentSpanID string) bool
+
+	// TargetHeader defines response header the generated span id is sent with.
+	// Optional. Default value echox.HeaderXSpanID.
+	TargetHeader string
+}
+
+// SpanID returns a middleware that generates span ID for every request and captures parent span ID from
+// `X-Parent-Span` request header. This is lightweight correlation of requests between services without full tracing
+// library. Both values are stored in context under ContextKeySpanID and ContextKeyParentSpanID keys, span ID is sent
+// to client with `X-Span-Id` response header and both can be logged with Logger (`${span_id}`, `${parent_span_id}`
+// tags) and RequestLogger (LogSpanID field) middlewares.
+func SpanID() echox.MiddlewareFunc {
+	return SpanIDWithConfig(SpanIDConfig{})
+}
+
+// SpanIDWithConfig returns a SpanID middleware with config or panics on invalid configuration.
+func SpanIDWithConfig(config SpanIDConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts SpanIDConfig to middleware or returns an error for invalid configuration
+func (config SpanIDConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if config.Skipper == nil {
+		config.Skipper = DefaultSkipper
+	}
+
+	if config.Generator == nil {
+		config.Generator = createRandomStringGenerator(16)
+	}
+
+	if config.ParentHeader == "" {
+		config.ParentHeader = echox.HeaderXParentSpan
+	}
+
+	if config.TargetHeader == "" {
+		config.TargetHeader = echox.HeaderXSpanID
+	}
+
+	if config.ParentValidator == nil {
+		config.ParentValidator = DefaultRequestIDValidator
+	}
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			parentID := c.Request().Header.Get(config.ParentHeader)
+			if parentID != "" && !config.ParentValidator(parentID) {
+				parentID = ""
+			}
+			spanID := config.Generator()
+
+			c.Set(ContextKeySpanID, spanID)
+			c.Set(ContextKeyParentSpanID, parentID)
+			c.Response().Header().Set(config.TargetHeader, spanID)
+
+			if config.SpanIDHandler != nil {
+				config.SpanIDHandler(c, spanID, parentID)
+			}
+
+			return next(c)
+		}
+	}, nil
+}
+
+func contextString(c echox.Context, key string) string {
+	v, _ := c.Get(key).(string)
+	return v
+}
@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
+)
+
+func TestSpanID(t *testing.T) {
+	var testCases = []struct {
+		name             string
+		whenParentHeader string
+		expectParentID   string
+	}{
+		{
+			name:           "ok, generates span id without parent",
+			expectParentID: "",
+		},
+		{
+			name:             "ok, captures parent span from header",
+			whenParentHeader: "parent123",
+			expectParentID:   "parent123",
+		},
+		{
+			name:             "ok, parent span with control characters is dropped",
+			whenParentHeader: "parent\rinjected",
+			expectParentID:   "",
+		},
+		{
+			name:             "ok, too long parent span is dropped",
+			whenParentHeader: strings.Repeat("a", 129),
+			expectParentID:   "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.whenParentHeader != "" {
+				req.Header.Set(echox.HeaderXParentSpan, tc.whenParentHeader)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			var spanID, parentID interface{}
+			err := SpanID()(func(c echox.Context) error {
+				spanID = c.Get(ContextKeySpanID)
+				parentID = c.Get(ContextKeyParentSpanID)
+
+				return c.String(http.StatusOK, "test")
+			})(c)
+
+			assert.NoError(t, err)
+			assert.Len(t, spanID, 16)
+			assert.Equal(t, spanID, rec.Header().Get(echox.HeaderXSpanID))
+			assert.Equal(t, tc.expectParentID, parentID)
+		})
+	}
+}
+
+func TestSpanIDWithConfig(t *testing.T) {
+	e := echox.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-B3-ParentSpanId", "parent")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	calledSpanID, calledParentID := "", ""
+	mw, err := SpanIDConfig{
+		Generator:    func() string { return "customGenerator" },
+		ParentHeader: "X-B3-ParentSpanId",
+		TargetHeader: "X-B3-SpanId",
+		SpanIDHandler: func(c echox.Context, spanID string, parentSpanID string) {
+			calledSpanID = spanID
+			calledParentID = parentSpanID
+		},
+	}.ToMiddleware()
+	assert.NoError(t, err)
+
+	err = mw(func(c echox.Context) error {
+		return c.String(http.StatusOK, "test")
+	})(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "customGenerator", rec.Header().Get("X-B3-SpanId"))
+	assert.Empty(t, rec.Header().Get(echox.HeaderXSpanID))
+	assert.Equal(t, "customGenerator", calledSpanID)
+	assert.Equal(t, "parent", calledParentID)
+}
+
+func TestSpanIDWithConfig_skipper(t *testing.T) {
+	e := echox.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	generatorCalled := false
+	mw := SpanIDWithConfig(SpanIDConfig{
+		Skipper: func(c echox.Context) bool {
+			return true
+		},
+		Generator: func() string {
+			generatorCalled = true
+			return "customGenerator"
+		},
+	})
+
+	err := mw(func(c echox.Context) error {
+		return c.String(http.StatusOK, "test")
+	})(c)
+
+	assert.NoError(t, err)
+	assert.False(t, generatorCalled)
+	assert.Nil(t, c.Get(ContextKeySpanID))
+	assert.Empty(t, rec.Header().Get(echox.HeaderXSpanID))
+}
+
+func TestSpanID_loggers(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	var values RequestLoggerValues
+
+	e := echox.New()
+	e.Use(LoggerWithConfig(LoggerConfig{
+		Format: `${span_id}|${parent_span_id}`,
+		Output: buf,
+	}))
+	e.Use(RequestLoggerWithConfig(RequestLoggerConfig{
+		LogSpanID: true,
+		LogValuesFunc: func(c echox.Context, v RequestLoggerValues) error {
+			values = v
+			return nil
+		},
+	}))
+	e.Use(SpanIDWithConfig(SpanIDConfig{Generator: func() string { return "span" }}))
+	e.GET("/", func(c echox.Context) error {
+		return c.String(http.StatusOK, "test")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echox.HeaderXParentSpan, "parent")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "span|parent", buf.String())
+	assert.Equal(t, "span", values.SpanID)
+	assert.Equal(t, "parent", values.ParentSpanID)
+}
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/theopenlane/echox"
+)
+
+// StripHeadersConfig defines the config for StripHeaders middleware.
+type StripHeadersConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// Headers are request header names removed before the next handler is called. Names are case-insensitive. Name
+	// ending with `*` removes all headers with that prefix (i.e. `X-Internal-*`).
+	// Optional, but either Headers or HopByHop is required.
+	Headers []string
+
+	// HopByHop removes the standard hop-by-hop headers (see HopByHopHeaders) and headers listed in request
+	// `Connection` header. Note: this also removes `Upgrade` so do not use it on routes serving websockets.
+	// Optional, but either Headers or HopByHop is required.
+	HopByHop bool
+}
+
+// HopByHopHeaders are headers meaningful only for a single transport-level connection that must not be forwarded
+// by proxies. See: https://www.rfc-editor.org/rfc/rfc9110#section-7.6.1
+var HopByHopHeaders = []string{
+	echox.HeaderConnection,
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Proxy-Connection",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	echox.HeaderUpgrade,
+}
+
+// StripHeaders returns a StripHeaders middleware that removes given headers from the request so handlers (and
+// backends requests are proxied to) never see client supplied values of them. Should be registered early, preferably
+// with `Echo#Pre(StripHeaders("X-Internal-*"))`.
+func StripHeaders(names ...string) echox.MiddlewareFunc {
+	return StripHeadersWithConfig(StripHeadersConfig{Headers: names})
+}
+
+// StripHopByHop returns a StripHeaders middleware that removes hop-by-hop headers from the request.
+// See: `StripHeaders()`.
+func StripHopByHop() echox.MiddlewareFunc {
+	return StripHeadersWithConfig(StripHeadersConfig{HopByHop: true})
+}
+
+// StripHeadersWithConfig returns a StripHeaders middleware with config or panics on invalid configuration.
+// See: `StripHeaders()`.
+func StripHeadersWithConfig(config StripHeadersConfig) echox.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts StripHeadersConfig to middleware or returns an error for invalid configuration
+func (config StripHeadersConfig) ToMiddleware() (echox.MiddlewareFunc, error) {
+	if config.Skipper == nil {
+		config.Skipper = DefaultSkipper
+	}
+
+	if len(config.Headers) == 0 && !config.HopByHop {
+		return nil, errors.New("echo strip headers middleware requires headers or hop by hop")
+	}
+
+	names := make([]string, 0, len(config.Headers))
+	prefixes := make([]string, 0, len(config.Headers))
+
+	for _, name := range config.Headers {
+		if prefix, ok := strings.CutSuffix(name, "*"); ok {
+			prefixes = append(prefixes, prefix)
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	strip := newHeaderStripper(names, prefixes, config.HopByHop)
+
+	return func(next echox.HandlerFunc) echox.HandlerFunc {
+		return func(c echox.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			strip(c.Request().Header)
+
+			return next(c)
+		}
+	}, nil
+}
@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/theopenlane/echox"
+)
+
+func TestStripHeaders(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		givenConfig   StripHeadersConfig
+		whenHeaders   map[string]string
+		expectRemoved []string
+		expectKept    []string
+	}{
+		{
+			name:          "ok, prefix pattern removes client supplied internal headers",
+			givenConfig:   StripHeadersConfig{Headers: []string{"x-internal-*"}},
+			whenHeaders:   map[string]string{"X-Internal-Admin": "true", "X-Internal-User": "1", "X-Request-Id": "abc"},
+			expectRemoved: []string{"X-Internal-Admin", "X-Internal-User"},
+			expectKept:    []string{"X-Request-Id"},
+		},
+		{
+			name:          "ok, exact names",
+			givenConfig:   StripHeadersConfig{Headers: []string{"x-forwarded-user"}},
+			whenHeaders:   map[string]string{"X-Forwarded-User": "admin", "X-Forwarded-Username": "admin"},
+			expectRemoved: []string{"X-Forwarded-User"},
+			expectKept:    []string{"X-Forwarded-Username"},
+		},
+		{
+			name:        "ok, hop-by-hop and headers listed in Connection",
+			givenConfig: StripHeadersConfig{HopByHop: true},
+			whenHeaders: map[string]string{
+				"Connection":          "keep-alive, X-Secret",
+				"Keep-Alive":          "timeout=5",
+				"Proxy-Authorization": "Basic Zm9vOmJhcg==",
+				"X-Secret":            "s3cret",
+				"X-Request-Id":        "abc",
+			},
+			expectRemoved: []string{"Connection", "Keep-Alive", "Proxy-Authorization", "X-Secret"},
+			expectKept:    []string{"X-Request-Id"},
+		},
+		{
+			name: "ok, skipper",
+			givenConfig: StripHeadersConfig{
+				Skipper: func(c echox.Context) bool { return true },
+				Headers: []string{"X-Internal-*"},
+			},
+			whenHeaders: map[string]string{"X-Internal-Admin": "true"},
+			expectKept:  []string{"X-Internal-Admin"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echox.New()
+			e.Pre(StripHeadersWithConfig(tc.givenConfig))
+
+			var seen http.Header
+			e.GET("/", func(c echox.Context) error {
+				seen = c.Request().Header.Clone()
+				return c.String(http.StatusOK, "OK")
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range tc.whenHeaders {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			for _, name := range tc.expectRemoved {
+				assert.Empty(t, seen.Values(name), name)
+			}
+			for _, name := range tc.expectKept {
+				assert.NotEmpty(t, seen.Values(name), name)
+			}
+		})
+	}
+}
+
+func TestStripHeaders_internalAdmin(t *testing.T) {
+	e := echox.New()
+	e.Pre(StripHeaders("X-Internal-*"))
+	e.GET("/", func(c echox.Context) error {
+		return c.String(http.StatusOK, c.Request().Header.Get("X-Internal-Admin"))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Internal-Admin", "true")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "", rec.Body.String())
+}
+
+func TestStripHopByHop(t *testing.T) {
+	e := echox.New()
+	e.Pre(StripHopByHop())
+	e.GET("/", func(c echox.Context) error {
+		return c.String(http.StatusOK, c.Request().Header.Get("Proxy-Authorization"))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Proxy-Authorization", "Basic Zm9vOmJhcg==")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, "", rec.Body.String())
+}
+
+func TestStripHeadersWithConfig_missingHeaders(t *testing.T) {
+	mw, err := StripHeadersConfig{}.ToMiddleware()
+
+	assert.EqualError(t, err, "echo strip headers middleware requires headers or hop by hop")
+	assert.Nil(t, mw)
+}
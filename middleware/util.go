@@ -33,6 +33,13 @@ func matchScheme(domain, pattern string) bool {
 
 // matchSubdomain compares authority with wildcard
 func matchSubdomain(domain, pattern string) bool {
+	return matchSubdomainLabels(domain, pattern, false)
+}
+
+// matchSubdomainLabels matches domain against pattern with `*` wildcard label. When singleLabel is true wildcard
+// matches exactly one domain label (`*.example.com` matches `a.example.com` but not `a.b.example.com`), otherwise
+// it matches any number of labels.
+func matchSubdomainLabels(domain, pattern string, singleLabel bool) bool {
 	if !matchScheme(domain, pattern) {
 		return false
 	}
@@ -72,6 +79,10 @@ func matchSubdomain(domain, pattern string) bool {
 
 		p := patComp[i]
 		if p == "*" {
+			if singleLabel {
+				return v != "" && i == len(domComp)-1 && i == len(patComp)-1
+			}
+
 			return true
 		}
 
@@ -89,6 +100,13 @@ func createRandomStringGenerator(length uint8) func() string {
 	}
 }
 
+// RandReader is the source of randomness used by middlewares to generate security tokens and identifiers (i.e.
+// CSRF tokens, request IDs, span IDs and Content-Security-Policy nonces). It can be replaced with an approved DRBG for
+// compliance builds or with deterministic reader in tests. RandReader must not be changed while middlewares are
+// serving requests.
+// Default value crypto/rand.Reader.
+var RandReader io.Reader = rand.Reader
+
 // https://tip.golang.org/doc/go1.19#:~:text=Read%20no%20longer%20buffers%20random%20data%20obtained%20from%20the%20operating%20system%20between%20calls
 var randomReaderPool = sync.Pool{New: func() interface{} {
 	return bufio.NewReader(rand.Reader)
@@ -99,8 +117,13 @@ const randomStringCharsetLen = 52 // len(randomStringCharset)
 const randomStringMaxByte = 255 - (256 % randomStringCharsetLen)
 
 func randomString(length uint8) string {
-	reader := randomReaderPool.Get().(*bufio.Reader)
-	defer randomReaderPool.Put(reader)
+	var reader io.Reader = RandReader
+	if reader == rand.Reader {
+		bufReader := randomReaderPool.Get().(*bufio.Reader)
+		defer randomReaderPool.Put(bufReader)
+
+		reader = bufReader
+	}
 
 	b := make([]byte, length)
 	r := make([]byte, length+(length/4)) // perf: avoid read from rand.Reader many times
@@ -110,7 +133,7 @@ func randomString(length uint8) string {
 	for {
 		_, err := io.ReadFull(reader, r)
 		if err != nil {
-			panic("unexpected error happened when reading from bufio.NewReader(crypto/rand.Reader)")
+			panic("unexpected error happened when reading from RandReader")
 		}
 
 		for _, rb := range r {
@@ -1,11 +1,16 @@
 package middleware
 
 import (
+	"bytes"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/theopenlane/echox"
 )
 
 type testLogger struct {
@@ -160,3 +165,38 @@ func TestRandomStringBias(t *testing.T) {
 		}
 	}
 }
+
+func TestRandReader(t *testing.T) {
+	defer func(r io.Reader) { RandReader = r }(RandReader)
+
+	seed := make([]byte, 256)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+
+	RandReader = bytes.NewReader(seed)
+	assert.Equal(t, "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdef", randomString(32))
+
+	RandReader = bytes.NewReader(seed)
+	e := echox.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := RequestID()(func(c echox.Context) error {
+		return c.NoContent(http.StatusOK)
+	})(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdef", rec.Header().Get(echox.HeaderXRequestID))
+}
+
+func TestRandReader_failingReaderPanics(t *testing.T) {
+	defer func(r io.Reader) { RandReader = r }(RandReader)
+
+	RandReader = bytes.NewReader(nil)
+
+	assert.PanicsWithValue(t, "unexpected error happened when reading from RandReader", func() {
+		randomString(16)
+	})
+}
@@ -0,0 +1,33 @@
+package echox
+
+import "strings"
+
+// NegotiateMediaType returns media type from offers that best satisfies `Accept` header value. Offers are ranked by
+// quality value of the media range they match and then by specificity of that range (exact type before `type/*` before
+// `*/*`). Ties are resolved by order of offers so they should be listed in server preference. Returns the first offer
+// when accept is empty and empty string when none of the offers is acceptable.
+func NegotiateMediaType(accept string, offers ...string) string {
+	if len(offers) == 0 {
+		return ""
+	}
+
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+
+	best := ""
+	bestQ, bestSpecificity := 0.0, -1
+
+	for _, offer := range offers {
+		q, specificity := acceptQuality(accept, strings.ToLower(offer))
+		if q <= 0 {
+			continue
+		}
+
+		if q > bestQ || (q == bestQ && specificity > bestSpecificity) {
+			best, bestQ, bestSpecificity = offer, q, specificity
+		}
+	}
+
+	return best
+}
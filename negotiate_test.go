@@ -0,0 +1,70 @@
+package echox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateMediaType(t *testing.T) {
+	var testCases = []struct {
+		name       string
+		whenAccept string
+		whenOffers []string
+		expect     string
+	}{
+		{
+			name:       "ok, empty accept results first offer",
+			whenOffers: []string{MIMEApplicationJSON, MIMEApplicationXML},
+			expect:     MIMEApplicationJSON,
+		},
+		{
+			name:       "ok, exact match",
+			whenAccept: "application/xml",
+			whenOffers: []string{MIMEApplicationJSON, MIMEApplicationXML},
+			expect:     MIMEApplicationXML,
+		},
+		{
+			name:       "ok, any type results first offer",
+			whenAccept: "*/*",
+			whenOffers: []string{MIMEApplicationJSON, MIMEApplicationXML},
+			expect:     MIMEApplicationJSON,
+		},
+		{
+			name:       "ok, quality values",
+			whenAccept: "application/json;q=0.5, application/xml;q=0.9",
+			whenOffers: []string{MIMEApplicationJSON, MIMEApplicationXML},
+			expect:     MIMEApplicationXML,
+		},
+		{
+			name:       "ok, more specific range wins on equal quality",
+			whenAccept: "*/*, application/xml",
+			whenOffers: []string{MIMEApplicationJSON, MIMEApplicationXML},
+			expect:     MIMEApplicationXML,
+		},
+		{
+			name:       "ok, type wildcard",
+			whenAccept: "text/*",
+			whenOffers: []string{MIMEApplicationJSON, MIMETextPlain},
+			expect:     MIMETextPlain,
+		},
+		{
+			name:       "nok, zero quality excludes offer",
+			whenAccept: "application/json;q=0",
+			whenOffers: []string{MIMEApplicationJSON},
+			expect:     "",
+		},
+		{
+			name:       "nok, nothing acceptable",
+			whenAccept: "application/xml",
+			whenOffers: []string{MIMEApplicationJSON},
+			expect:     "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, NegotiateMediaType(tc.whenAccept, tc.whenOffers...))
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package echox
+
+import (
+	"net/http"
+	"strings"
+)
+
+// OptionsResponse is JSON body sent by Context.AllowResponse when routes for the path declare summaries.
+type OptionsResponse struct {
+	// Methods lists methods allowed for the path, in same order as in `Allow` header.
+	Methods []string `json:"methods"`
+	// Summaries maps method to Route.Summary of route registered for it. Methods without summary are omitted.
+	Summaries map[string]string `json:"summaries,omitempty"`
+}
+
+// AllowResponse sends response to OPTIONS request with `Allow` header listing methods Router has for matched path.
+// When routes for the path declare Route.Summary the response is 200 with OptionsResponse as JSON body, otherwise
+// it is 204 with no body.
+func (c *DefaultContext) AllowResponse() error {
+	// See RFC 7231 section 7.4.1: An origin server MUST generate an Allow field in a 405 (Method Not Allowed)
+	// response and MAY do so in any other response. For disabled resources an empty Allow header may be returned
+	allow, ok := c.Get(ContextKeyHeaderAllow).(string)
+	if ok && allow != "" {
+		c.response.Header().Set(HeaderAllow, allow)
+	}
+
+	summaries, _ := c.Get(ContextKeyRouteSummaries).(map[string]string)
+	if len(summaries) == 0 {
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	resp := OptionsResponse{
+		Methods:   []string{},
+		Summaries: make(map[string]string, len(summaries)),
+	}
+
+	for _, method := range strings.Split(allow, ",") {
+		if method = strings.TrimSpace(method); method != "" {
+			resp.Methods = append(resp.Methods, method)
+		}
+	}
+
+	for method, summary := range summaries {
+		resp.Summaries[method] = summary
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
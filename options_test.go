@@ -0,0 +1,103 @@
+package echox
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContext_AllowResponse(t *testing.T) {
+	var testCases = []struct {
+		name         string
+		givenRoutes  []Route
+		expectStatus int
+		expectAllow  string
+		expectBody   string
+	}{
+		{
+			name: "ok, no summaries responds with 204",
+			givenRoutes: []Route{
+				{Method: http.MethodGet, Path: "/users"},
+				{Method: http.MethodPost, Path: "/users"},
+			},
+			expectStatus: http.StatusNoContent,
+			expectAllow:  "OPTIONS, GET, POST",
+		},
+		{
+			name: "ok, summaries are listed in body",
+			givenRoutes: []Route{
+				{Method: http.MethodGet, Path: "/users", Summary: "List users"},
+				{Method: http.MethodPost, Path: "/users", Summary: "Create user"},
+				{Method: http.MethodDelete, Path: "/users"},
+			},
+			expectStatus: http.StatusOK,
+			expectAllow:  "OPTIONS, DELETE, GET, POST",
+			expectBody:   `{"methods":["OPTIONS","DELETE","GET","POST"],"summaries":{"GET":"List users","POST":"Create user"}}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			for _, r := range tc.givenRoutes {
+				r.Handler = func(c Context) error { return c.String(http.StatusOK, "OK") }
+				_, err := e.AddRoute(r)
+				assert.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodOptions, "/users", nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectStatus, rec.Code)
+			assert.Equal(t, tc.expectAllow, rec.Header().Get(HeaderAllow))
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
+
+func TestRouteInfo_Summary(t *testing.T) {
+	e := New()
+	ri, err := e.AddRoute(Route{
+		Method:  http.MethodGet,
+		Path:    "/users",
+		Handler: func(c Context) error { return nil },
+		Summary: "List users",
+	})
+	assert.NoError(t, err)
+
+	sri, ok := ri.(SummaryRouteInfo)
+	assert.True(t, ok)
+	assert.Equal(t, "List users", sri.Summary())
+}
+
+func TestContext_AllowResponse_summariesAreCopied(t *testing.T) {
+	e := New()
+	e.Pre(func(next HandlerFunc) HandlerFunc {
+		return func(c Context) error {
+			err := next(c)
+			if summaries, ok := c.Get(ContextKeyRouteSummaries).(map[string]string); ok {
+				summaries[http.MethodGet] = "changed"
+			}
+			return err
+		}
+	})
+	_, err := e.AddRoute(Route{
+		Method:  http.MethodGet,
+		Path:    "/users",
+		Summary: "List users",
+		Handler: func(c Context) error { return c.String(http.StatusOK, "OK") },
+	})
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodOptions, "/users", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, `{"methods":["OPTIONS","GET"],"summaries":{"GET":"List users"}}`+"\n", rec.Body.String())
+	}
+}
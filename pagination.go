@@ -0,0 +1,80 @@
+package echox
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// PaginationLimitParam is query parameter name for page size used by Context.Pagination
+	PaginationLimitParam = "limit"
+	// PaginationOffsetParam is query parameter name for page offset used by Context.Pagination
+	PaginationOffsetParam = "offset"
+	// PaginationCursorParam is query parameter name for page cursor used by Context.Pagination
+	PaginationCursorParam = "cursor"
+)
+
+// PaginationOpts configures defaults and bounds for Context.Pagination.
+type PaginationOpts struct {
+	// DefaultLimit is used when request does not have limit query parameter.
+	// Optional. Defaults to MaxLimit when it is set, otherwise there is no default limit (0).
+	DefaultLimit int
+	// MinLimit is lower bound limit is clamped to.
+	// Optional. Default value 1.
+	MinLimit int
+	// MaxLimit is upper bound limit is clamped to.
+	// Optional. Default value 0 (no upper bound).
+	MaxLimit int
+}
+
+// Pagination contains pagination parameters parsed from request query parameters.
+type Pagination struct {
+	Limit  int
+	Offset int
+	Cursor string
+}
+
+// Pagination parses `limit`, `offset` and `cursor` query parameters. Limit given in request is clamped to MinLimit
+// and MaxLimit and negative offset to 0. Returns 400 (bad request) error when limit or offset is not a number.
+func (c *DefaultContext) Pagination(opts PaginationOpts) (Pagination, error) {
+	if opts.MinLimit <= 0 {
+		opts.MinLimit = 1
+	}
+
+	if opts.DefaultLimit == 0 {
+		opts.DefaultLimit = opts.MaxLimit
+	}
+
+	p := Pagination{
+		Limit:  opts.DefaultLimit,
+		Cursor: c.QueryParam(PaginationCursorParam),
+	}
+
+	if value := c.QueryParam(PaginationLimitParam); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			return Pagination{}, newInvalidQueryParamError(PaginationLimitParam, err)
+		}
+
+		p.Limit = max(limit, opts.MinLimit)
+		if opts.MaxLimit > 0 {
+			p.Limit = min(p.Limit, opts.MaxLimit)
+		}
+	}
+
+	if value := c.QueryParam(PaginationOffsetParam); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil {
+			return Pagination{}, newInvalidQueryParamError(PaginationOffsetParam, err)
+		}
+
+		p.Offset = max(offset, 0)
+	}
+
+	return p, nil
+}
+
+func newInvalidQueryParamError(name string, err error) *HTTPError {
+	return NewHTTPErrorWithInternal(http.StatusBadRequest, err, fmt.Sprintf("invalid value for query parameter: %s", name))
+}
@@ -0,0 +1,93 @@
+package echox
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContext_Pagination(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		givenOpts   PaginationOpts
+		whenURL     string
+		expect      Pagination
+		expectError string
+	}{
+		{
+			name:      "ok, defaults applied",
+			givenOpts: PaginationOpts{DefaultLimit: 20, MaxLimit: 100},
+			whenURL:   "/users",
+			expect:    Pagination{Limit: 20, Offset: 0},
+		},
+		{
+			name:      "ok, default limit falls back to max limit",
+			givenOpts: PaginationOpts{MaxLimit: 100},
+			whenURL:   "/users",
+			expect:    Pagination{Limit: 100, Offset: 0},
+		},
+		{
+			name:      "ok, values from query",
+			givenOpts: PaginationOpts{DefaultLimit: 20, MaxLimit: 100},
+			whenURL:   "/users?limit=50&offset=10&cursor=abc",
+			expect:    Pagination{Limit: 50, Offset: 10, Cursor: "abc"},
+		},
+		{
+			name:      "ok, limit over the max is clamped",
+			givenOpts: PaginationOpts{DefaultLimit: 20, MaxLimit: 100},
+			whenURL:   "/users?limit=1000",
+			expect:    Pagination{Limit: 100, Offset: 0},
+		},
+		{
+			name:      "ok, limit under the min is clamped",
+			givenOpts: PaginationOpts{DefaultLimit: 20, MinLimit: 5, MaxLimit: 100},
+			whenURL:   "/users?limit=0",
+			expect:    Pagination{Limit: 5, Offset: 0},
+		},
+		{
+			name:      "ok, negative offset is clamped",
+			givenOpts: PaginationOpts{DefaultLimit: 20},
+			whenURL:   "/users?offset=-10",
+			expect:    Pagination{Limit: 20, Offset: 0},
+		},
+		{
+			name:      "ok, no max limit",
+			givenOpts: PaginationOpts{DefaultLimit: 20},
+			whenURL:   "/users?limit=1000",
+			expect:    Pagination{Limit: 1000, Offset: 0},
+		},
+		{
+			name:        "nok, invalid limit",
+			givenOpts:   PaginationOpts{DefaultLimit: 20},
+			whenURL:     "/users?limit=ten",
+			expectError: `code=400, message=invalid value for query parameter: limit, internal=strconv.Atoi: parsing "ten": invalid syntax`,
+		},
+		{
+			name:        "nok, invalid offset",
+			givenOpts:   PaginationOpts{DefaultLimit: 20},
+			whenURL:     "/users?offset=1.5",
+			expectError: `code=400, message=invalid value for query parameter: offset, internal=strconv.Atoi: parsing "1.5": invalid syntax`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, tc.whenURL, nil), httptest.NewRecorder())
+
+			p, err := c.Pagination(tc.givenOpts)
+
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				assert.Equal(t, Pagination{}, p)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, p)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package echox
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PathParamEnum returns the path parameter for the provided name when its value is one of allowed values.
+// Returns BindingError (400) naming the invalid value and allowed values otherwise.
+func (c *DefaultContext) PathParamEnum(name string, allowed ...string) (string, error) {
+	return paramEnum(name, c.PathParam(name), false, allowed)
+}
+
+// PathParamEnumFold is case-insensitive version of PathParamEnum. Returned value is spelled as in allowed values.
+func (c *DefaultContext) PathParamEnumFold(name string, allowed ...string) (string, error) {
+	return paramEnum(name, c.PathParam(name), true, allowed)
+}
+
+// QueryParamEnum returns the query param for the provided name when its value is one of allowed values (i.e.
+// `c.QueryParamEnum("sort", "asc", "desc")`). Returns BindingError (400) naming the invalid value and allowed values
+// otherwise.
+func (c *DefaultContext) QueryParamEnum(name string, allowed ...string) (string, error) {
+	return paramEnum(name, c.QueryParam(name), false, allowed)
+}
+
+// QueryParamEnumFold is case-insensitive version of QueryParamEnum. Returned value is spelled as in allowed values.
+func (c *DefaultContext) QueryParamEnumFold(name string, allowed ...string) (string, error) {
+	return paramEnum(name, c.QueryParam(name), true, allowed)
+}
+
+func paramEnum(name string, value string, ignoreCase bool, allowed []string) (string, error) {
+	for _, a := range allowed {
+		if a == value || (ignoreCase && strings.EqualFold(a, value)) {
+			return a, nil
+		}
+	}
+
+	return "", NewBindingError(
+		name,
+		[]string{value},
+		fmt.Sprintf("invalid value %q, allowed values: %s", value, strings.Join(allowed, ", ")),
+		nil,
+	)
+}
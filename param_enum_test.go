@@ -0,0 +1,93 @@
+package echox
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContext_QueryParamEnum(t *testing.T) {
+	var testCases = []struct {
+		name      string
+		givenFold bool
+		whenURL   string
+		expect    string
+		expectErr string
+	}{
+		{
+			name:    "ok, valid value",
+			whenURL: "/?sort=desc",
+			expect:  "desc",
+		},
+		{
+			name:      "nok, invalid value",
+			whenURL:   "/?sort=random",
+			expectErr: `code=400, message=invalid value "random", allowed values: asc, desc, field=sort`,
+		},
+		{
+			name:      "nok, missing value",
+			whenURL:   "/",
+			expectErr: `code=400, message=invalid value "", allowed values: asc, desc, field=sort`,
+		},
+		{
+			name:      "nok, case differs",
+			whenURL:   "/?sort=DESC",
+			expectErr: `code=400, message=invalid value "DESC", allowed values: asc, desc, field=sort`,
+		},
+		{
+			name:      "ok, case differs with case-insensitive match",
+			givenFold: true,
+			whenURL:   "/?sort=DESC",
+			expect:    "desc",
+		},
+		{
+			name:      "nok, invalid value with case-insensitive match",
+			givenFold: true,
+			whenURL:   "/?sort=up",
+			expectErr: `code=400, message=invalid value "up", allowed values: asc, desc, field=sort`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, tc.whenURL, nil), httptest.NewRecorder())
+
+			var value string
+			var err error
+			if tc.givenFold {
+				value, err = c.QueryParamEnumFold("sort", "asc", "desc")
+			} else {
+				value, err = c.QueryParamEnum("sort", "asc", "desc")
+			}
+
+			assert.Equal(t, tc.expect, value)
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+
+				var be *BindingError
+				if assert.ErrorAs(t, err, &be) {
+					assert.Equal(t, http.StatusBadRequest, be.Code)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestContext_PathParamEnum(t *testing.T) {
+	e := New()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	c.SetPathParams(PathParams{{Name: "format", Value: "JSON"}})
+
+	value, err := c.PathParamEnum("format", "json", "xml")
+	assert.Equal(t, "", value)
+	assert.EqualError(t, err, `code=400, message=invalid value "JSON", allowed values: json, xml, field=format`)
+
+	value, err = c.PathParamEnumFold("format", "json", "xml")
+	assert.NoError(t, err)
+	assert.Equal(t, "json", value)
+}
@@ -0,0 +1,37 @@
+package echox
+
+import (
+	"strings"
+)
+
+// RequireIfMatch checks `If-Match` request header against current entity-tag of the resource (i.e. `"v42"` with
+// quotes) for optimistic concurrency control. Returns 412 (Precondition Failed) error when header does not match and
+// 428 (Precondition Required) error when header is missing (see Echo.IfMatchMissingStatus).
+//
+// Entity-tags are compared with strong comparison (RFC 9110 13.1.1) so weak entity-tags (`W/"v42"`) never match.
+// `If-Match: *` matches any current entity-tag.
+func (c *DefaultContext) RequireIfMatch(currentETag string) error {
+	values := c.request.Header.Values(HeaderIfMatch)
+	if len(values) == 0 {
+		if c.echo != nil && c.echo.IfMatchMissingStatus != 0 {
+			return NewHTTPError(c.echo.IfMatchMissingStatus)
+		}
+
+		return ErrPreconditionRequired
+	}
+
+	if currentETag == "" || strings.HasPrefix(currentETag, "W/") {
+		return ErrPreconditionFailed
+	}
+
+	for _, v := range values {
+		for _, tag := range strings.Split(v, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "*" || tag == currentETag {
+				return nil
+			}
+		}
+	}
+
+	return ErrPreconditionFailed
+}
@@ -0,0 +1,84 @@
+package echox
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContext_RequireIfMatch(t *testing.T) {
+	var testCases = []struct {
+		name               string
+		givenMissingStatus int
+		whenIfMatch        []string
+		whenCurrentETag    string
+		expectErr          string
+	}{
+		{
+			name:            "ok, matching entity-tag",
+			whenIfMatch:     []string{`"v42"`},
+			whenCurrentETag: `"v42"`,
+		},
+		{
+			name:            "ok, matching entity-tag in list",
+			whenIfMatch:     []string{`"v41", "v42"`},
+			whenCurrentETag: `"v42"`,
+		},
+		{
+			name:            "ok, wildcard",
+			whenIfMatch:     []string{"*"},
+			whenCurrentETag: `"v42"`,
+		},
+		{
+			name:            "nok, mismatch",
+			whenIfMatch:     []string{`"v41"`},
+			whenCurrentETag: `"v42"`,
+			expectErr:       "code=412, message=Precondition Failed",
+		},
+		{
+			name:            "nok, weak entity-tags do not match with strong comparison",
+			whenIfMatch:     []string{`W/"v42"`},
+			whenCurrentETag: `W/"v42"`,
+			expectErr:       "code=412, message=Precondition Failed",
+		},
+		{
+			name:            "nok, wildcard does not match missing resource",
+			whenIfMatch:     []string{"*"},
+			whenCurrentETag: "",
+			expectErr:       "code=412, message=Precondition Failed",
+		},
+		{
+			name:            "nok, missing header",
+			whenCurrentETag: `"v42"`,
+			expectErr:       "code=428, message=Precondition Required",
+		},
+		{
+			name:               "nok, missing header with configured status",
+			givenMissingStatus: http.StatusPreconditionFailed,
+			whenCurrentETag:    `"v42"`,
+			expectErr:          "code=412, message=Precondition Failed",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			e.IfMatchMissingStatus = tc.givenMissingStatus
+
+			req := httptest.NewRequest(http.MethodPut, "/", nil)
+			for _, v := range tc.whenIfMatch {
+				req.Header.Add(HeaderIfMatch, v)
+			}
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			err := c.RequireIfMatch(tc.whenCurrentETag)
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package echox
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// HTTPRange is byte range requested with `Range` request header (RFC 9110).
+type HTTPRange struct {
+	Start  int64
+	Length int64
+}
+
+// ContentRange returns `Content-Range` header value for the range of content with given size
+// (i.e. `bytes 0-499/1234`).
+func (r HTTPRange) ContentRange(size int64) string {
+	return "bytes " + strconv.FormatInt(r.Start, 10) + "-" + strconv.FormatInt(r.Start+r.Length-1, 10) + "/" +
+		strconv.FormatInt(size, 10)
+}
+
+// Range parses `Range` request header against content of given size and returns requested byte ranges. Returns
+// nil ranges and nil error when request has no `Range` header. Returns ErrRangeNotSatisfiable when header is
+// malformed or none of the ranges overlaps the content - handler should respond with 416 and
+// `Content-Range: bytes */<size>` header then.
+func (c *DefaultContext) Range(size int64) ([]HTTPRange, error) {
+	header := c.request.Header.Get(HeaderRange)
+	if header == "" {
+		return nil, nil
+	}
+
+	ranges, err := parseRange(header, size)
+	if err != nil {
+		return nil, ErrRangeNotSatisfiable.WithInternal(err)
+	}
+
+	return ranges, nil
+}
+
+var (
+	errInvalidRange = errors.New("invalid range")
+	errNoOverlap    = errors.New("invalid range: failed to overlap")
+)
+
+// parseRange parses `Range` header value (i.e. `bytes=0-499,1000-`). Ranges that start beyond the content are
+// skipped and error is returned only when none of the ranges overlaps the content.
+func parseRange(header string, size int64) ([]HTTPRange, error) {
+	const prefix = "bytes="
+
+	if !strings.HasPrefix(header, prefix) {
+		return nil, errInvalidRange
+	}
+
+	var ranges []HTTPRange
+
+	noOverlap := false
+
+	for _, spec := range strings.Split(header[len(prefix):], ",") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+
+		startStr, endStr, ok := strings.Cut(spec, "-")
+		if !ok {
+			return nil, errInvalidRange
+		}
+
+		startStr, endStr = strings.TrimSpace(startStr), strings.TrimSpace(endStr)
+
+		var r HTTPRange
+
+		if startStr == "" {
+			// suffix range `-500` selects last 500 bytes
+			if endStr == "" || endStr[0] == '-' {
+				return nil, errInvalidRange
+			}
+
+			n, err := strconv.ParseInt(endStr, 10, 64)
+			if err != nil || n < 0 {
+				return nil, errInvalidRange
+			}
+
+			if n == 0 {
+				noOverlap = true
+				continue
+			}
+
+			if n > size {
+				n = size
+			}
+
+			r.Start = size - n
+			r.Length = n
+		} else {
+			start, err := strconv.ParseInt(startStr, 10, 64)
+			if err != nil || start < 0 {
+				return nil, errInvalidRange
+			}
+
+			if start >= size {
+				noOverlap = true
+				continue
+			}
+
+			r.Start = start
+
+			if endStr == "" {
+				r.Length = size - start
+			} else {
+				end, err := strconv.ParseInt(endStr, 10, 64)
+				if err != nil || start > end {
+					return nil, errInvalidRange
+				}
+
+				if end >= size {
+					end = size - 1
+				}
+
+				r.Length = end - start + 1
+			}
+		}
+
+		if r.Length > 0 {
+			ranges = append(ranges, r)
+		}
+	}
+
+	if noOverlap && len(ranges) == 0 {
+		return nil, errNoOverlap
+	}
+
+	if len(ranges) == 0 {
+		return nil, errInvalidRange
+	}
+
+	return ranges, nil
+}
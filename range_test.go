@@ -0,0 +1,114 @@
+package echox
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContext_Range(t *testing.T) {
+	var testCases = []struct {
+		name      string
+		whenRange string
+		whenSize  int64
+		expect    []HTTPRange
+		expectErr string
+	}{
+		{
+			name:     "ok, no range header",
+			whenSize: 1000,
+		},
+		{
+			name:      "ok, single range",
+			whenRange: "bytes=0-499",
+			whenSize:  1000,
+			expect:    []HTTPRange{{Start: 0, Length: 500}},
+		},
+		{
+			name:      "ok, open ended range",
+			whenRange: "bytes=900-",
+			whenSize:  1000,
+			expect:    []HTTPRange{{Start: 900, Length: 100}},
+		},
+		{
+			name:      "ok, end beyond size is truncated",
+			whenRange: "bytes=900-1999",
+			whenSize:  1000,
+			expect:    []HTTPRange{{Start: 900, Length: 100}},
+		},
+		{
+			name:      "ok, multiple ranges",
+			whenRange: "bytes=0-49, 100-149,500-",
+			whenSize:  1000,
+			expect:    []HTTPRange{{Start: 0, Length: 50}, {Start: 100, Length: 50}, {Start: 500, Length: 500}},
+		},
+		{
+			name:      "ok, suffix range",
+			whenRange: "bytes=-200",
+			whenSize:  1000,
+			expect:    []HTTPRange{{Start: 800, Length: 200}},
+		},
+		{
+			name:      "ok, suffix range larger than size",
+			whenRange: "bytes=-2000",
+			whenSize:  1000,
+			expect:    []HTTPRange{{Start: 0, Length: 1000}},
+		},
+		{
+			name:      "ok, unsatisfiable range is skipped when other overlaps",
+			whenRange: "bytes=2000-2100,0-9",
+			whenSize:  1000,
+			expect:    []HTTPRange{{Start: 0, Length: 10}},
+		},
+		{
+			name:      "nok, unsatisfiable range",
+			whenRange: "bytes=1000-1100",
+			whenSize:  1000,
+			expectErr: "code=416, message=Requested Range Not Satisfiable, internal=invalid range: failed to overlap",
+		},
+		{
+			name:      "nok, invalid unit",
+			whenRange: "items=0-5",
+			whenSize:  1000,
+			expectErr: "code=416, message=Requested Range Not Satisfiable, internal=invalid range",
+		},
+		{
+			name:      "nok, start after end",
+			whenRange: "bytes=500-100",
+			whenSize:  1000,
+			expectErr: "code=416, message=Requested Range Not Satisfiable, internal=invalid range",
+		},
+		{
+			name:      "nok, malformed range",
+			whenRange: "bytes=abc",
+			whenSize:  1000,
+			expectErr: "code=416, message=Requested Range Not Satisfiable, internal=invalid range",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.whenRange != "" {
+				req.Header.Set(HeaderRange, tc.whenRange)
+			}
+			c := New().NewContext(req, httptest.NewRecorder())
+
+			ranges, err := c.Range(tc.whenSize)
+
+			assert.Equal(t, tc.expect, ranges)
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHTTPRange_ContentRange(t *testing.T) {
+	assert.Equal(t, "bytes 0-499/1234", HTTPRange{Start: 0, Length: 500}.ContentRange(1234))
+	assert.Equal(t, "bytes 1200-1233/1234", HTTPRange{Start: 1200, Length: 34}.ContentRange(1234))
+}
@@ -2,10 +2,16 @@ package echox
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"reflect"
 	"runtime"
+	"sort"
+	"strings"
+	"text/tabwriter"
 )
 
 // Route contains information to adding/registering new route with the router.
@@ -17,6 +23,54 @@ type Route struct {
 	Middlewares []MiddlewareFunc
 
 	Name string
+
+	// DisableAutoOptions instructs Router not to answer OPTIONS requests for this route path with its automatic
+	// OPTIONS method handler (see RouterConfig.OptionsMethodHandler). OPTIONS requests are then served only by
+	// handler explicitly registered for OPTIONS method and path, or result in 405 (method not allowed).
+	// Note: Router never generates HEAD handlers automatically - HEAD requests are served only by registered HEAD routes.
+	DisableAutoOptions bool
+
+	// Produces lists media types (i.e. `application/json`) route handler is declared to respond with. Router does not
+	// use this information itself - it is available to middlewares through RouteInfo implementing ProducesRouteInfo
+	// interface (see `middleware.ContentTypeEnforcer`).
+	Produces []string
+
+	// BodyLimit is maximum allowed size in bytes of request body for this route. It overrides limit configured for
+	// `middleware.BodyLimit` middleware (added with `e.Use`) so single route can accept larger or smaller bodies than
+	// the rest of the application. It is available to middlewares through RouteInfo implementing BodyLimitRouteInfo.
+	// Zero value means that route does not override the limit.
+	BodyLimit int64
+
+	// Summary is short human readable description of what route does. Router includes summaries of all methods
+	// registered for path in body of automatic OPTIONS response (see Context.AllowResponse). It is available to
+	// middlewares through RouteInfo implementing SummaryRouteInfo interface.
+	Summary string
+}
+
+// ProducesRouteInfo is implemented by RouteInfo that knows which media types route is declared to respond with.
+type ProducesRouteInfo interface {
+	// Produces returns media types route is declared to respond with.
+	Produces() []string
+}
+
+// BodyLimitRouteInfo is implemented by RouteInfo that knows request body size limit declared for the route.
+type BodyLimitRouteInfo interface {
+	// BodyLimit returns maximum allowed size in bytes of request body declared for route or 0 when not declared.
+	BodyLimit() int64
+}
+
+// SummaryRouteInfo is implemented by RouteInfo that knows human readable summary declared for the route.
+type SummaryRouteInfo interface {
+	// Summary returns short description of the route or empty string when not declared.
+	Summary() string
+}
+
+// ReverseStrictRouteInfo is implemented by RouteInfo that can reverse route to URL string failing when path parameters
+// are left unsubstituted.
+type ReverseStrictRouteInfo interface {
+	// ReverseStrict reverses route to URL string by replacing path parameters with given params values. Returns an
+	// error when number of given params values does not match number of path parameters in route path.
+	ReverseStrict(params ...interface{}) (string, error)
 }
 
 // ToRouteInfo converts Route to RouteInfo
@@ -27,10 +81,13 @@ func (r Route) ToRouteInfo(params []string) RouteInfo {
 	}
 
 	return routeInfo{
-		method: r.Method,
-		path:   r.Path,
-		params: append([]string(nil), params...),
-		name:   name,
+		method:    r.Method,
+		path:      r.Path,
+		params:    append([]string(nil), params...),
+		name:      name,
+		produces:  append([]string(nil), r.Produces...),
+		bodyLimit: r.BodyLimit,
+		summary:   r.Summary,
 	}
 }
 
@@ -54,10 +111,13 @@ func (r Route) ForGroup(pathPrefix string, middlewares []MiddlewareFunc) Routabl
 }
 
 type routeInfo struct {
-	method string
-	path   string
-	params []string
-	name   string
+	method    string
+	path      string
+	params    []string
+	name      string
+	produces  []string
+	bodyLimit int64
+	summary   string
 }
 
 func (r routeInfo) Method() string {
@@ -76,11 +136,43 @@ func (r routeInfo) Name() string {
 	return r.name
 }
 
+func (r routeInfo) Produces() []string {
+	return append([]string(nil), r.produces...)
+}
+
+func (r routeInfo) BodyLimit() int64 {
+	return r.bodyLimit
+}
+
+func (r routeInfo) Summary() string {
+	return r.summary
+}
+
 // Reverse reverses route to URL string by replacing path parameters with given params values.
 func (r routeInfo) Reverse(params ...interface{}) string {
+	uri, _ := r.reverse(params)
+
+	return uri
+}
+
+// ReverseStrict reverses route to URL string by replacing path parameters with given params values. Returns an error
+// when number of given params values does not match number of path parameters so no parameter is left unsubstituted.
+func (r routeInfo) ReverseStrict(params ...interface{}) (string, error) {
+	uri, placeholders := r.reverse(params)
+	if placeholders != len(params) {
+		return "", fmt.Errorf("route %s expects %d path params, got %d", r.path, placeholders, len(params))
+	}
+
+	return uri, nil
+}
+
+// reverse replaces path parameters with given params values and returns resulting URL and number of path parameters
+// (including ones left unsubstituted) in route path.
+func (r routeInfo) reverse(params []interface{}) (string, int) {
 	uri := new(bytes.Buffer)
 	ln := len(params)
 	n := 0
+	placeholders := 0
 
 	for i, l := 0, len(r.path); i < l; i++ {
 		hasBackslash := r.path[i] == '\\'
@@ -88,14 +180,18 @@ func (r routeInfo) Reverse(params ...interface{}) string {
 			i++ // backslash before colon escapes that colon. in that case skip backslash
 		}
 
-		if n < ln && (r.path[i] == anyLabel || (!hasBackslash && r.path[i] == paramLabel)) {
-			// in case of `*` wildcard or `:` (unescaped colon) param we replace everything till next slash or end of path
-			for ; i < l && r.path[i] != '/'; i++ {
-			}
+		if r.path[i] == anyLabel || (!hasBackslash && r.path[i] == paramLabel) {
+			placeholders++
+
+			if n < ln {
+				// in case of `*` wildcard or `:` (unescaped colon) param we replace everything till next slash or end of path
+				for ; i < l && r.path[i] != '/'; i++ {
+				}
 
-			uri.WriteString(fmt.Sprintf("%v", params[n]))
+				uri.WriteString(fmt.Sprintf("%v", params[n]))
 
-			n++
+				n++
+			}
 		}
 
 		if i < l {
@@ -103,7 +199,7 @@ func (r routeInfo) Reverse(params ...interface{}) string {
 		}
 	}
 
-	return uri.String()
+	return uri.String(), placeholders
 }
 
 // HandlerName returns string name for given function.
@@ -127,6 +223,112 @@ func (r Routes) Reverse(name string, params ...interface{}) (string, error) {
 	return "", errors.New("route not found")
 }
 
+// ReverseStrict reverses route with given name to URL string by replacing path parameters with given params values.
+// Returns an error when route is not found or number of given params values does not match number of path parameters.
+func (r Routes) ReverseStrict(name string, params ...interface{}) (string, error) {
+	for _, rr := range r {
+		if rr.Name() != name {
+			continue
+		}
+
+		strict, ok := rr.(ReverseStrictRouteInfo)
+		if !ok {
+			return "", errors.New("route does not support strict reverse")
+		}
+
+		return strict.ReverseStrict(params...)
+	}
+
+	return "", errors.New("route not found")
+}
+
+// Sorted returns a copy of routes sorted by path and then by method. Useful for generating deterministic route listings.
+// Results of FilterBy* methods can be sorted the same way with FilterSorted option, i.e.
+// `routes.FilterByMethod(http.MethodGet, echox.FilterSorted())`.
+func (r Routes) Sorted() Routes {
+	if r == nil {
+		return nil
+	}
+
+	result := make(Routes, len(r))
+	copy(result, r)
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Path() != result[j].Path() {
+			return result[i].Path() < result[j].Path()
+		}
+
+		return result[i].Method() < result[j].Method()
+	})
+
+	return result
+}
+
+type routeJSON struct {
+	Method string   `json:"method"`
+	Path   string   `json:"path"`
+	Name   string   `json:"name"`
+	Params []string `json:"params"`
+}
+
+// MarshalJSON encodes routes as JSON array of objects with method, path, name and params fields in routes order.
+// Use Sorted to get stable ordering.
+func (r Routes) MarshalJSON() ([]byte, error) {
+	result := make([]routeJSON, 0, len(r))
+
+	for _, rr := range r {
+		params := rr.Params()
+		if params == nil {
+			params = []string{}
+		}
+
+		result = append(result, routeJSON{
+			Method: rr.Method(),
+			Path:   rr.Path(),
+			Name:   rr.Name(),
+			Params: params,
+		})
+	}
+
+	return json.Marshal(result)
+}
+
+// String returns routes as text table with one route per line in `<method> <path> <name>` format.
+func (r Routes) String() string {
+	sb := new(strings.Builder)
+	_, _ = r.WriteTo(sb)
+
+	return sb.String()
+}
+
+// WriteTo writes routes as text table with one route per line in `<method> <path> <name>` format to w.
+func (r Routes) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{writer: w}
+	tw := tabwriter.NewWriter(cw, 0, 0, 1, ' ', 0)
+
+	for _, rr := range r {
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\n", rr.Method(), rr.Path(), rr.Name()); err != nil {
+			return cw.n, err
+		}
+	}
+
+	err := tw.Flush()
+
+	return cw.n, err
+}
+
+type countingWriter struct {
+	writer io.Writer
+	n      int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.n += int64(n)
+
+	return n, err
+}
+
 // FindByMethodPath searched for matching route info by method and path
 func (r Routes) FindByMethodPath(method string, path string) (RouteInfo, error) {
 	if r == nil {
@@ -142,65 +344,125 @@ func (r Routes) FindByMethodPath(method string, path string) (RouteInfo, error)
 	return nil, errors.New("route not found by method and path")
 }
 
-// FilterByMethod searched for matching route info by method
-func (r Routes) FilterByMethod(method string) (Routes, error) {
+// FilterOption configures results of Routes.FilterBy* methods
+type FilterOption func(o *filterOptions)
+
+type filterOptions struct {
+	sorted bool
+}
+
+// FilterSorted returns FilterOption that sorts filtered routes by path and then by method (same as Routes.Sorted).
+func FilterSorted() FilterOption {
+	return func(o *filterOptions) {
+		o.sorted = true
+	}
+}
+
+func (r Routes) filter(match func(ri RouteInfo) bool, notFoundMsg string, opts []FilterOption) (Routes, error) {
 	if r == nil {
-		return nil, errors.New("route not found by method")
+		return nil, errors.New(notFoundMsg)
 	}
 
 	result := make(Routes, 0)
 
 	for _, rr := range r {
-		if rr.Method() == method {
+		if match(rr) {
 			result = append(result, rr)
 		}
 	}
 
 	if len(result) == 0 {
-		return nil, errors.New("route not found by method")
+		return nil, errors.New(notFoundMsg)
+	}
+
+	o := filterOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.sorted {
+		return result.Sorted(), nil
 	}
 
 	return result, nil
 }
 
+// FilterByMethod searched for matching route info by method
+func (r Routes) FilterByMethod(method string, opts ...FilterOption) (Routes, error) {
+	return r.filter(func(ri RouteInfo) bool { return ri.Method() == method }, "route not found by method", opts)
+}
+
 // FilterByPath searched for matching route info by path
-func (r Routes) FilterByPath(path string) (Routes, error) {
-	if r == nil {
-		return nil, errors.New("route not found by path")
+func (r Routes) FilterByPath(path string, opts ...FilterOption) (Routes, error) {
+	return r.filter(func(ri RouteInfo) bool { return ri.Path() == path }, "route not found by path", opts)
+}
+
+// FilterByName searched for matching route info by name
+func (r Routes) FilterByName(name string, opts ...FilterOption) (Routes, error) {
+	return r.filter(func(ri RouteInfo) bool { return ri.Name() == name }, "route not found by name", opts)
+}
+
+// Match finds route that would be matched by Router for given method and concrete request path (i.e. `/users/42`
+// matches route `/users/:id`) and returns it with path parameter values extracted from the path. Matching follows
+// DefaultRouter rules (static > param > any). Returns an error when no route matches.
+func (r Routes) Match(method string, concretePath string) (RouteInfo, map[string]string, error) {
+	if len(r) == 0 {
+		return nil, nil, errors.New("route not found by method and concrete path")
 	}
 
-	result := make(Routes, 0)
+	router := NewRouter(RouterConfig{})
+	byMethodPath := make(map[string]RouteInfo, len(r))
+	maxParams := 0
 
-	for _, rr := range r {
-		if rr.Path() == path {
-			result = append(result, rr)
+	for _, ri := range r {
+		path := normalizeRoutePath(ri.Path())
+		if _, err := router.Add(Route{Method: ri.Method(), Path: path, Handler: notFoundHandler}); err != nil {
+			return nil, nil, err
+		}
+
+		byMethodPath[ri.Method()+" "+path] = ri
+		if l := len(ri.Params()); l > maxParams {
+			maxParams = l
 		}
 	}
 
-	if len(result) == 0 {
-		return nil, errors.New("route not found by path")
+	req, err := http.NewRequest(method, "/", nil)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return result, nil
-}
+	req.URL.Path = concretePath
 
-// FilterByName searched for matching route info by name
-func (r Routes) FilterByName(name string) (Routes, error) {
-	if r == nil {
-		return nil, errors.New("route not found by name")
+	pathParams := make(PathParams, maxParams)
+	c := &DefaultContext{request: req, pathParams: &pathParams}
+	router.Route(c)
+
+	matched := c.RouteInfo()
+	if matched == nil {
+		return nil, nil, errors.New("route not found by method and concrete path")
 	}
 
-	result := make(Routes, 0)
+	ri, ok := byMethodPath[matched.Method()+" "+matched.Path()]
+	if !ok {
+		return nil, nil, errors.New("route not found by method and concrete path")
+	}
 
-	for _, rr := range r {
-		if rr.Name() == name {
-			result = append(result, rr)
-		}
+	params := make(map[string]string, len(pathParams))
+	for _, p := range c.PathParams() {
+		params[p.Name] = p.Value
 	}
 
-	if len(result) == 0 {
-		return nil, errors.New("route not found by name")
+	return ri, params, nil
+}
+
+func normalizeRoutePath(path string) string {
+	if path == "" {
+		return "/"
 	}
 
-	return result, nil
+	if path[0] != '/' {
+		return "/" + path
+	}
+
+	return path
 }
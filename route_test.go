@@ -1,6 +1,8 @@
 package echox
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
@@ -115,6 +117,42 @@ func TestRoute_ToRouteInfo(t *testing.T) {
 				name:   "GET:users/:id/:file",
 			},
 		},
+		{
+			name: "ok, produces",
+			given: Route{
+				Method: http.MethodGet,
+				Path:   "/test",
+				Handler: func(c Context) error {
+					return c.JSON(http.StatusOK, "OK")
+				},
+				Produces: []string{MIMEApplicationJSON},
+			},
+			expect: routeInfo{
+				method:   http.MethodGet,
+				path:     "/test",
+				params:   nil,
+				name:     "GET:/test",
+				produces: []string{MIMEApplicationJSON},
+			},
+		},
+		{
+			name: "ok, body limit",
+			given: Route{
+				Method: http.MethodPost,
+				Path:   "/upload",
+				Handler: func(c Context) error {
+					return c.NoContent(http.StatusOK)
+				},
+				BodyLimit: 100 * 1024 * 1024,
+			},
+			expect: routeInfo{
+				method:    http.MethodPost,
+				path:      "/upload",
+				params:    nil,
+				name:      "POST:/upload",
+				bodyLimit: 100 * 1024 * 1024,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -257,6 +295,151 @@ func TestRoutes_FindByMethodPath(t *testing.T) {
 	}
 }
 
+func TestRoutes_Match(t *testing.T) {
+	matchRoutes := append(exampleRoutes(),
+		routeInfo{method: http.MethodGet, path: "/users/new", name: "GET:/users/new"},
+		routeInfo{method: http.MethodGet, path: "/static/*", params: []string{"*"}, name: "GET:/static/*"},
+		routeInfo{method: http.MethodPost, path: "/params\\::customVerb", params: []string{"customVerb"}, name: "POST:/params\\::customVerb"},
+	)
+
+	var testCases = []struct {
+		name         string
+		given        Routes
+		whenMethod   string
+		whenPath     string
+		expectName   string
+		expectParams map[string]string
+		expectError  string
+	}{
+		{
+			name:         "ok, static",
+			given:        matchRoutes,
+			whenMethod:   http.MethodGet,
+			whenPath:     "/users",
+			expectName:   "GET:/users",
+			expectParams: map[string]string{},
+		},
+		{
+			name:         "ok, param",
+			given:        matchRoutes,
+			whenMethod:   http.MethodPost,
+			whenPath:     "/users/42",
+			expectName:   "POST:/users/:id",
+			expectParams: map[string]string{"id": "42"},
+		},
+		{
+			name:         "ok, static has priority over param",
+			given:        matchRoutes,
+			whenMethod:   http.MethodGet,
+			whenPath:     "/users/new",
+			expectName:   "GET:/users/new",
+			expectParams: map[string]string{},
+		},
+		{
+			name:         "ok, wildcard",
+			given:        matchRoutes,
+			whenMethod:   http.MethodGet,
+			whenPath:     "/static/css/main.css",
+			expectName:   "GET:/static/*",
+			expectParams: map[string]string{"*": "css/main.css"},
+		},
+		{
+			name:         "ok, escaped colon",
+			given:        matchRoutes,
+			whenMethod:   http.MethodPost,
+			whenPath:     "/params:create",
+			expectName:   "POST:/params\\::customVerb",
+			expectParams: map[string]string{"customVerb": "create"},
+		},
+		{
+			name:        "nok, path not found",
+			given:       matchRoutes,
+			whenMethod:  http.MethodGet,
+			whenPath:    "/unknown",
+			expectError: "route not found by method and concrete path",
+		},
+		{
+			name:        "nok, method not found",
+			given:       matchRoutes,
+			whenMethod:  http.MethodPut,
+			whenPath:    "/users/42",
+			expectError: "route not found by method and concrete path",
+		},
+		{
+			name:        "nok, not found from nil",
+			given:       nil,
+			whenMethod:  http.MethodGet,
+			whenPath:    "/users/42",
+			expectError: "route not found by method and concrete path",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ri, params, err := tc.given.Match(tc.whenMethod, tc.whenPath)
+
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				assert.Nil(t, ri)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectName, ri.Name())
+			}
+
+			assert.Equal(t, tc.expectParams, params)
+		})
+	}
+}
+
+func TestRoutes_Sorted(t *testing.T) {
+	given := exampleRoutes()
+
+	sorted := given.Sorted()
+
+	result := make([]string, 0, len(sorted))
+	for _, ri := range sorted {
+		result = append(result, ri.Method()+" "+ri.Path())
+	}
+
+	assert.Equal(t, []string{
+		"DELETE /groups",
+		"POST /groups",
+		"GET /users",
+		"GET /users/:id",
+		"POST /users/:id",
+	}, result)
+
+	// original is not modified
+	assert.Equal(t, exampleRoutes(), given)
+	assert.Nil(t, Routes(nil).Sorted())
+}
+
+func TestRoutes_FilterSorted(t *testing.T) {
+	names := func(routes Routes) []string {
+		result := make([]string, 0, len(routes))
+		for _, ri := range routes {
+			result = append(result, ri.Method()+" "+ri.Path())
+		}
+		return result
+	}
+
+	byMethod, err := exampleRoutes().FilterByMethod(http.MethodPost, FilterSorted())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"POST /groups", "POST /users/:id"}, names(byMethod))
+
+	byName, err := exampleRoutes().FilterByName("non_unique_name", FilterSorted())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"DELETE /groups", "POST /groups"}, names(byName))
+
+	byPath, err := exampleRoutes().FilterByPath("/users/:id", FilterSorted())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"GET /users/:id", "POST /users/:id"}, names(byPath))
+
+	unsorted, err := exampleRoutes().FilterByMethod(http.MethodPost)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"POST /users/:id", "POST /groups"}, names(unsorted))
+}
+
 func TestRoutes_FilterByMethod(t *testing.T) {
 	var testCases = []struct {
 		name        string
@@ -520,3 +703,122 @@ func TestRouteInfo_Reverse(t *testing.T) {
 		})
 	}
 }
+
+func TestRouteInfo_ReverseStrict(t *testing.T) {
+	var testCases = []struct {
+		name       string
+		givenPath  string
+		whenParams []interface{}
+		expect     string
+		expectErr  string
+	}{
+		{
+			name:      "ok, static with no params",
+			givenPath: "/static",
+			expect:    "/static",
+		},
+		{
+			name:       "nok, static with non existent param",
+			givenPath:  "/static",
+			whenParams: []interface{}{"missing param"},
+			expectErr:  "route /static expects 0 path params, got 1",
+		},
+		{
+			name:      "nok, wildcard with no params",
+			givenPath: "/static/*",
+			expectErr: "route /static/* expects 1 path params, got 0",
+		},
+		{
+			name:       "ok, wildcard with params",
+			givenPath:  "/static/*",
+			whenParams: []interface{}{"foo.txt"},
+			expect:     "/static/foo.txt",
+		},
+		{
+			name:      "nok, single param without param",
+			givenPath: "/params/:foo",
+			expectErr: "route /params/:foo expects 1 path params, got 0",
+		},
+		{
+			name:       "nok, multi param with one param",
+			givenPath:  "/params/:foo/bar/:qux",
+			whenParams: []interface{}{"one"},
+			expectErr:  "route /params/:foo/bar/:qux expects 2 path params, got 1",
+		},
+		{
+			name:       "ok, multi param + wildcard with all params",
+			givenPath:  "/params/:foo/bar/:qux/*",
+			whenParams: []interface{}{"one", "two", "three"},
+			expect:     "/params/one/bar/two/three",
+		},
+		{
+			name:       "ok, escaped colon verbs",
+			givenPath:  "/params\\::customVerb",
+			whenParams: []interface{}{"PATCH"},
+			expect:     `/params:PATCH`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := routeInfo{path: tc.givenPath}
+
+			uri, err := r.ReverseStrict(tc.whenParams...)
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expect, uri)
+		})
+	}
+}
+
+func TestRoutes_ReverseStrict(t *testing.T) {
+	routes := exampleRoutes()
+
+	uri, err := routes.ReverseStrict("GET:/users/:id", 42)
+	assert.NoError(t, err)
+	assert.Equal(t, "/users/42", uri)
+
+	_, err = routes.ReverseStrict("GET:/users/:id")
+	assert.EqualError(t, err, "route /users/:id expects 1 path params, got 0")
+
+	_, err = routes.ReverseStrict("unknown")
+	assert.EqualError(t, err, "route not found")
+}
+
+func TestRoutes_MarshalJSON(t *testing.T) {
+	b, err := json.Marshal(exampleRoutes())
+	assert.NoError(t, err)
+
+	expect := `[
+{"method":"GET","path":"/users","name":"GET:/users","params":[]},
+{"method":"GET","path":"/users/:id","name":"GET:/users/:id","params":["id"]},
+{"method":"POST","path":"/users/:id","name":"POST:/users/:id","params":["id"]},
+{"method":"DELETE","path":"/groups","name":"non_unique_name","params":[]},
+{"method":"POST","path":"/groups","name":"non_unique_name","params":[]}
+]`
+	assert.JSONEq(t, expect, string(b))
+
+	b, err = json.Marshal(Routes(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", string(b))
+}
+
+func TestRoutes_String(t *testing.T) {
+	expect := "GET    /users     GET:/users\n" +
+		"GET    /users/:id GET:/users/:id\n" +
+		"POST   /users/:id POST:/users/:id\n" +
+		"DELETE /groups    non_unique_name\n" +
+		"POST   /groups    non_unique_name\n"
+
+	assert.Equal(t, expect, exampleRoutes().String())
+
+	buf := new(bytes.Buffer)
+	n, err := exampleRoutes().WriteTo(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, expect, buf.String())
+	assert.Equal(t, int64(len(expect)), n)
+}
@@ -3,6 +3,7 @@ package echox
 import (
 	"bytes"
 	"errors"
+	"maps"
 	"net/http"
 	"net/url"
 )
@@ -215,8 +216,9 @@ const (
 
 type routeMethod struct {
 	*routeInfo
-	handler      HandlerFunc
-	orgRouteInfo RouteInfo
+	handler            HandlerFunc
+	orgRouteInfo       RouteInfo
+	disableAutoOptions bool
 }
 
 type routeMethods struct {
@@ -237,6 +239,11 @@ type routeMethods struct {
 	notFoundHandler *routeMethod
 
 	allowHeader string
+	// summaries maps method to summary of route registered for it. It is nil when none of the routes has summary.
+	summaries map[string]string
+	// disableAutoOptions is true when at least one route registered to these methods has opted out of automatic
+	// OPTIONS method handler
+	disableAutoOptions bool
 }
 
 func (m *routeMethods) set(method string, r *routeMethod) {
@@ -279,6 +286,8 @@ func (m *routeMethods) set(method string, r *routeMethod) {
 	}
 
 	m.updateAllowHeader()
+	m.updateSummaries()
+	m.updateDisableAutoOptions()
 }
 
 func (m *routeMethods) find(method string) *routeMethod {
@@ -370,6 +379,54 @@ func (m *routeMethods) updateAllowHeader() {
 	m.allowHeader = buf.String()
 }
 
+func (m *routeMethods) updateSummaries() {
+	m.summaries = nil
+
+	add := func(method string, rm *routeMethod) {
+		if rm == nil || rm.routeInfo == nil || rm.summary == "" {
+			return
+		}
+		if m.summaries == nil {
+			m.summaries = make(map[string]string)
+		}
+		m.summaries[method] = rm.summary
+	}
+
+	add(http.MethodConnect, m.connect)
+	add(http.MethodDelete, m.delete)
+	add(http.MethodGet, m.get)
+	add(http.MethodHead, m.head)
+	add(http.MethodOptions, m.options)
+	add(http.MethodPatch, m.patch)
+	add(http.MethodPost, m.post)
+	add(PROPFIND, m.propfind)
+	add(http.MethodPut, m.put)
+	add(http.MethodTrace, m.trace)
+	add(REPORT, m.report)
+
+	for method, rm := range m.anyOther {
+		add(method, rm)
+	}
+}
+
+func (m *routeMethods) updateDisableAutoOptions() {
+	m.disableAutoOptions = false
+
+	for _, rm := range [...]*routeMethod{m.connect, m.delete, m.get, m.head, m.options, m.patch, m.post, m.propfind, m.put, m.trace, m.report} {
+		if rm != nil && rm.disableAutoOptions {
+			m.disableAutoOptions = true
+			return
+		}
+	}
+
+	for _, rm := range m.anyOther {
+		if rm.disableAutoOptions {
+			m.disableAutoOptions = true
+			return
+		}
+	}
+}
+
 func (m *routeMethods) isHandler() bool {
 	return m.get != nil ||
 		m.post != nil ||
@@ -584,9 +641,10 @@ func (r *DefaultRouter) Add(routable Routable) (RouteInfo, error) {
 				// path node is last fragment of route path. ie. `/users/:id`
 				ri = routable.ToRouteInfo(paramNames)
 				rm := routeMethod{
-					routeInfo:    &routeInfo{method: method, path: originalPath, params: paramNames, name: route.Name},
-					handler:      h,
-					orgRouteInfo: ri,
+					routeInfo:          &routeInfo{method: method, path: originalPath, params: paramNames, name: route.Name, produces: route.Produces, bodyLimit: route.BodyLimit, summary: route.Summary},
+					handler:            h,
+					orgRouteInfo:       ri,
+					disableAutoOptions: route.DisableAutoOptions,
 				}
 				r.insert(paramKind, path[:i], method, rm)
 
@@ -602,9 +660,10 @@ func (r *DefaultRouter) Add(routable Routable) (RouteInfo, error) {
 			paramNames = append(paramNames, "*")
 			ri = routable.ToRouteInfo(paramNames)
 			rm := routeMethod{
-				routeInfo:    &routeInfo{method: method, path: originalPath, params: paramNames, name: route.Name},
-				handler:      h,
-				orgRouteInfo: ri,
+				routeInfo:          &routeInfo{method: method, path: originalPath, params: paramNames, name: route.Name, produces: route.Produces, bodyLimit: route.BodyLimit, summary: route.Summary},
+				handler:            h,
+				orgRouteInfo:       ri,
+				disableAutoOptions: route.DisableAutoOptions,
 			}
 			r.insert(anyKind, path[:i+1], method, rm)
 
@@ -617,9 +676,10 @@ func (r *DefaultRouter) Add(routable Routable) (RouteInfo, error) {
 	if !wasAdded {
 		ri = routable.ToRouteInfo(paramNames)
 		rm := routeMethod{
-			routeInfo:    &routeInfo{method: method, path: originalPath, params: paramNames, name: route.Name},
-			handler:      h,
-			orgRouteInfo: ri,
+			routeInfo:          &routeInfo{method: method, path: originalPath, params: paramNames, name: route.Name, produces: route.Produces, bodyLimit: route.BodyLimit, summary: route.Summary},
+			handler:            h,
+			orgRouteInfo:       ri,
+			disableAutoOptions: route.DisableAutoOptions,
 		}
 		r.insert(staticKind, path, method, rm)
 	}
@@ -869,11 +929,7 @@ var methodNotAllowedHandler = func(c Context) error {
 var optionsMethodHandler = func(c Context) error {
 	// See RFC 7231 section 7.4.1: An origin server MUST generate an Allow field in a 405 (Method Not Allowed)
 	// response and MAY do so in any other response. For disabled resources an empty Allow header may be returned
-	routerAllowMethods, ok := c.Get(ContextKeyHeaderAllow).(string)
-	if ok && routerAllowMethods != "" {
-		c.Response().Header().Set(HeaderAllow, routerAllowMethods)
-	}
-	return c.NoContent(http.StatusNoContent)
+	return c.AllowResponse()
 }
 
 // Route looks up a handler registered for method and path. It also parses URL for path parameters and loads them
@@ -1117,9 +1173,13 @@ func (r *DefaultRouter) Route(c RoutableContext) HandlerFunc {
 			rInfo = methodNotAllowedRouteInfo
 
 			c.Set(ContextKeyHeaderAllow, currentNode.methods.allowHeader)
+			if currentNode.methods.summaries != nil {
+				// copy so handlers modifying the map can not change router state shared between requests
+				c.Set(ContextKeyRouteSummaries, maps.Clone(currentNode.methods.summaries))
+			}
 
 			rHandler = r.methodNotAllowedHandler
-			if req.Method == http.MethodOptions {
+			if req.Method == http.MethodOptions && !currentNode.methods.disableAutoOptions {
 				rHandler = r.optionsMethodHandler
 			}
 		}
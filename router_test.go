@@ -983,6 +983,98 @@ func TestRouterHandleMethodOptions(t *testing.T) {
 	}
 }
 
+func TestRouterDisableAutoOptions(t *testing.T) {
+	e := New()
+
+	_, err := e.AddRoute(Route{Method: http.MethodGet, Path: "/preflight", Handler: handlerFunc, DisableAutoOptions: true})
+	assert.NoError(t, err)
+	e.OPTIONS("/preflight", func(c Context) error {
+		c.Response().Header().Set(HeaderAccessControlAllowOrigin, "*")
+		return c.String(http.StatusOK, "custom preflight")
+	})
+
+	_, err = e.AddRoute(Route{Method: http.MethodGet, Path: "/custom", Handler: handlerFunc, DisableAutoOptions: true})
+	assert.NoError(t, err)
+
+	g := e.Group("/api")
+	_, err = g.AddRoute(Route{Method: http.MethodHead, Path: "/meta", Handler: handlerFunc, DisableAutoOptions: true})
+	assert.NoError(t, err)
+
+	e.GET("/users", handlerFunc)
+	e.POST("/users", handlerFunc)
+
+	var testCases = []struct {
+		name              string
+		whenMethod        string
+		whenURL           string
+		expectStatus      int
+		expectBody        string
+		expectAllowHeader string
+	}{
+		{
+			name:         "user OPTIONS handler handles preflight for opted out route",
+			whenMethod:   http.MethodOptions,
+			whenURL:      "/preflight",
+			expectStatus: http.StatusOK,
+			expectBody:   "custom preflight",
+		},
+		{
+			name:              "opted out route without OPTIONS handler responds with 405",
+			whenMethod:        http.MethodOptions,
+			whenURL:           "/custom",
+			expectStatus:      http.StatusMethodNotAllowed,
+			expectBody:        "{\"message\":\"Method Not Allowed\"}\n",
+			expectAllowHeader: "OPTIONS, GET",
+		},
+		{
+			name:              "opted out group route without OPTIONS handler responds with 405",
+			whenMethod:        http.MethodOptions,
+			whenURL:           "/api/meta",
+			expectStatus:      http.StatusMethodNotAllowed,
+			expectBody:        "{\"message\":\"Method Not Allowed\"}\n",
+			expectAllowHeader: "OPTIONS, HEAD",
+		},
+		{
+			name:              "other routes still get automatic OPTIONS handler",
+			whenMethod:        http.MethodOptions,
+			whenURL:           "/users",
+			expectStatus:      http.StatusNoContent,
+			expectAllowHeader: "OPTIONS, GET, POST",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.whenMethod, tc.whenURL, nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectStatus, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+			assert.Equal(t, tc.expectAllowHeader, rec.Header().Get(HeaderAllow))
+		})
+	}
+}
+
+func TestRouterDisableAutoOptions_removeRoute(t *testing.T) {
+	e := New()
+	r := e.router
+
+	_, err := r.Add(Route{Method: http.MethodGet, Path: "/users", Handler: handlerFunc})
+	assert.NoError(t, err)
+	_, err = r.Add(Route{Method: http.MethodPost, Path: "/users", Handler: handlerFunc, DisableAutoOptions: true})
+	assert.NoError(t, err)
+
+	status, _ := request(http.MethodOptions, "/users", e)
+	assert.Equal(t, http.StatusMethodNotAllowed, status)
+
+	assert.NoError(t, r.Remove(http.MethodPost, "/users"))
+
+	status, _ = request(http.MethodOptions, "/users", e)
+	assert.Equal(t, http.StatusNoContent, status)
+}
+
 func TestRouterTwoParam(t *testing.T) {
 	e := New()
 	e.GET("/users/:uid/files/:fid", handlerFunc)
@@ -44,6 +44,12 @@ type StartConfig struct {
 	// Defaults to 10 seconds
 	GracefulTimeout time.Duration
 
+	// DrainPeriod is period between GracefulContext completion and start of the server shutdown. At the start of the
+	// period Echo.BeginDrain is called so readiness probes (see middleware.HealthCheck) start to respond with 503 while
+	// server still accepts and serves requests, giving load balancer time to stop routing traffic to the instance.
+	// Defaults to 0 (shutdown starts immediately)
+	DrainPeriod time.Duration
+
 	// OnShutdownError allows customization of what happens when (graceful) server Shutdown method returns an error.
 	// Defaults to calling e.logger.Error(err)
 	OnShutdownError func(err error)
@@ -82,7 +88,7 @@ func (sc StartConfig) Start(e *Echo) error {
 		return err
 	}
 
-	return serve(&sc, &server, listener, logger)
+	return serve(&sc, e, &server, listener)
 }
 
 // StartTLS starts a HTTPS server.
@@ -130,10 +136,12 @@ func (sc StartConfig) StartTLS(e *Echo, certFile, keyFile interface{}) error {
 		return err
 	}
 
-	return serve(&sc, &s, listener, logger)
+	return serve(&sc, e, &s, listener)
 }
 
-func serve(sc *StartConfig, server *http.Server, listener net.Listener, logger Logger) error {
+func serve(sc *StartConfig, e *Echo, server *http.Server, listener net.Listener) error {
+	logger := e.Logger
+
 	if sc.BeforeServeFunc != nil {
 		if err := sc.BeforeServeFunc(server); err != nil {
 			return err
@@ -146,7 +154,7 @@ func serve(sc *StartConfig, server *http.Server, listener net.Listener, logger L
 		ctx, cancel := stdContext.WithCancel(sc.GracefulContext)
 		defer cancel() // make sure this graceful coroutine will end when serve returns by some other means
 
-		go gracefulShutdown(ctx, sc, server, logger)
+		go gracefulShutdown(ctx, sc, e, server)
 	}
 
 	return server.Serve(listener)
@@ -207,10 +215,15 @@ func filepathOrContent(fileOrContent interface{}, certFilesystem fs.FS) (content
 	}
 }
 
-func gracefulShutdown(gracefulCtx stdContext.Context, sc *StartConfig, server *http.Server, logger Logger) {
+func gracefulShutdown(gracefulCtx stdContext.Context, sc *StartConfig, e *Echo, server *http.Server) {
 	<-gracefulCtx.Done() // wait until shutdown context is closed.
 	// note: is server if closed by other means this method is still run but is good as no-op
 
+	if sc.DrainPeriod > 0 {
+		e.BeginDrain()
+		time.Sleep(sc.DrainPeriod)
+	}
+
 	timeout := sc.GracefulTimeout
 	if timeout == 0 {
 		timeout = 10 * time.Second
@@ -227,6 +240,6 @@ func gracefulShutdown(gracefulCtx stdContext.Context, sc *StartConfig, server *h
 			return
 		}
 
-		logger.Error(fmt.Errorf("failed to shut down server within given timeout: %w", err))
+		e.Logger.Error(fmt.Errorf("failed to shut down server within given timeout: %w", err))
 	}
 }
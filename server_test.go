@@ -863,3 +863,78 @@ func (l *testLogger) Printf(format string, args ...interface{}) {
 func (l *testLogger) Error(err error) {
 	_, _ = l.output.Write([]byte(err.Error()))
 }
+
+func TestStartConfig_DrainPeriod(t *testing.T) {
+	e := New()
+	e.GET("/ready", func(c Context) error {
+		if c.Echo().Draining() {
+			return c.String(http.StatusServiceUnavailable, "draining")
+		}
+		return c.String(http.StatusOK, "ready")
+	})
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	e.GET("/slow", func(c Context) error {
+		close(started)
+		<-release
+		return c.String(http.StatusOK, "done")
+	})
+
+	addrChan := make(chan string)
+	errCh := make(chan error, 1)
+
+	ctx, shutdown := stdContext.WithCancel(stdContext.Background())
+	defer shutdown()
+
+	go func() {
+		errCh <- (&StartConfig{
+			Address:         ":0",
+			HideBanner:      true,
+			GracefulContext: ctx,
+			GracefulTimeout: time.Second,
+			DrainPeriod:     300 * time.Millisecond,
+			ListenerAddrFunc: func(addr net.Addr) {
+				addrChan <- addr.String()
+			},
+		}).Start(e)
+	}()
+
+	addr, err := waitForServerStart(addrChan, errCh)
+	require.NoError(t, err)
+
+	code, body, err := doGet(fmt.Sprintf("http://%v/ready", addr))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ready", body)
+
+	type result struct {
+		code int
+		body string
+		err  error
+	}
+	slowResult := make(chan result, 1)
+	go func() {
+		code, body, err := doGet(fmt.Sprintf("http://%v/slow", addr))
+		slowResult <- result{code: code, body: body, err: err}
+	}()
+	<-started
+
+	shutdown()
+
+	// during drain period server still serves new requests but reports itself as not ready
+	assert.Eventually(t, e.Draining, 100*time.Millisecond, 5*time.Millisecond)
+	code, body, err = doGet(fmt.Sprintf("http://%v/ready", addr))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "draining", body)
+
+	close(release)
+
+	r := <-slowResult
+	assert.NoError(t, r.err)
+	assert.Equal(t, http.StatusOK, r.code)
+	assert.Equal(t, "done", r.body)
+
+	assert.Equal(t, http.ErrServerClosed, <-errCh)
+}